@@ -2,11 +2,14 @@ package main
 
 import (
 	"bufio"
+	"encoding/json"
+	"flag"
 	"fmt"
-	"net/rpc"
+	"io"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 	"twophasecommit/node"
 	"twophasecommit/utils"
 
@@ -15,7 +18,7 @@ import (
 
 func main() {
 	if len(os.Args) < 2 {
-		fmt.Println("Usage: go run main.go [server|client]")
+		fmt.Println("Usage: go run main.go [server|client|certs|schema]")
 		os.Exit(1)
 	}
 
@@ -23,10 +26,14 @@ func main() {
 		startServer()
 	} else if os.Args[1] == "client" {
 		startClient()
+	} else if os.Args[1] == "certs" {
+		generateCertificates()
+	} else if os.Args[1] == "schema" {
+		writeSchema()
 	} else if os.Args[1] == "test" {
 		testing()
 	} else {
-		fmt.Println("Usage: go run main.go [server|client]")
+		fmt.Println("Usage: go run main.go [server|client|certs|schema]")
 		os.Exit(1)
 	}
 }
@@ -64,49 +71,207 @@ func testing() {
 		fmt.Printf("Error reading log file: %v\n", err)
 	}
 }
+
+// Make a CA and certificates for a test cluster, by default for the nodes
+// started by "server" and the client
+func generateCertificates() {
+	flags := flag.NewFlagSet("certs", flag.ExitOnError)
+	dir := flags.String("dir", "certs", "directory to write the certificates to")
+	flags.Parse(os.Args[2:])
+	names := flags.Args()
+	if len(names) == 0 {
+		names = []string{"C1", "C2", "C3", "A", "B", "C", node.ClientCertName}
+	}
+	if err := utils.GenerateCertificates(*dir, names); err != nil {
+		fmt.Printf("Error generating certificates: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote CA and certificates for %s to %s\n", strings.Join(names, ", "), *dir)
+}
+
+// Write the JSON-RPC schema, to stdout unless given a file
+func writeSchema() {
+	flags := flag.NewFlagSet("schema", flag.ExitOnError)
+	output := flags.String("o", "", "file to write the schema to")
+	flags.Parse(os.Args[2:])
+	data, err := json.MarshalIndent(node.JSONRPCSchema(), "", "  ")
+	if err != nil {
+		fmt.Printf("Error encoding schema: %v\n", err)
+		os.Exit(1)
+	}
+	data = append(data, '\n')
+	if *output == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(*output, data, 0644); err != nil {
+		fmt.Printf("Error writing schema: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// Credentials of name from the certificates in dir, none without a directory
+func loadCredentials(dir string, name string) (*node.Credentials, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	return node.LoadCredentials(utils.CertFiles(dir, name))
+}
+
+// Flags of every server command
+type serverFlags struct {
+	transport   *string
+	certDir     *string
+	gatewayAddr *string
+	jsonRPCAddr *string
+}
+
+func addServerFlags(flags *flag.FlagSet) serverFlags {
+	return serverFlags{
+		transport:   flags.String("transport", utils.Transport, "transport nodes call each other with (rpc or grpc)"),
+		certDir:     flags.String("tls", utils.CertDir, "directory of certificates made by \"certs\", to use mutual TLS (plaintext when empty)"),
+		gatewayAddr: flags.String("http", "", "address to serve the HTTP/JSON gateway on, e.g. 127.0.0.1:8080 (off when empty)"),
+		jsonRPCAddr: flags.String("jsonrpc", "", "address to serve JSON-RPC on, e.g. 127.0.0.1:7090 (off when empty)"),
+	}
+}
+
+// Give n the transport and certificate of its name, serving the gateway and
+// JSON-RPC on it when asked
+func (f serverFlags) setUpNode(n *node.Node, gateway bool) (node.Transport, error) {
+	credentials, err := loadCredentials(*f.certDir, n.Name)
+	if err != nil {
+		return nil, err
+	}
+	nodeTransport, err := node.NewTransport(*f.transport, credentials)
+	if err != nil {
+		return nil, err
+	}
+	n.UseTransport(nodeTransport)
+	n.UseCredentials(credentials)
+	if gateway && *f.gatewayAddr != "" {
+		go func() {
+			if err := n.ServeGateway(*f.gatewayAddr); err != nil {
+				fmt.Printf("Error serving HTTP gateway: %v\n", err)
+			}
+		}()
+	}
+	if gateway && *f.jsonRPCAddr != "" {
+		go func() {
+			if err := n.ServeJSONRPC(*f.jsonRPCAddr); err != nil {
+				fmt.Printf("Error serving JSON-RPC: %v\n", err)
+			}
+		}()
+	}
+	return nodeTransport, nil
+}
+
+// "server" runs a whole test cluster in one process, "server coordinator" and
+// "server participant" run a single node each, so nodes can be spread over hosts
 func startServer() {
-	// Start Coordinator
-	err := utils.ClearNodeDataDir()
+	if len(os.Args) > 2 {
+		switch os.Args[2] {
+		case "coordinator":
+			startCoordinator()
+			return
+		case "participant":
+			startParticipant()
+			return
+		}
+	}
+	flags := flag.NewFlagSet("server", flag.ExitOnError)
+	storageBackend := flags.String("storage", utils.StorageBackend, "storage backend for participant balances (file or memory)")
+	ratesFile := flags.String("rates", utils.RatesFile, "exchange rates used to convert transfers, one \"FROM TO RATE\" per line")
+	listenAddr := flags.String("listen", utils.JoinAddress, "address of the first coordinator, the others and the participants use free ports")
+	gatewayNode := flags.String("http-node", "A", "participant or coordinator serving the HTTP/JSON gateway and JSON-RPC")
+	server := addServerFlags(flags)
+	flags.Parse(os.Args[2:])
+	transportName := server.transport
+	certDir := server.certDir
+
+	// Used to set the cluster up, as a client
+	clientCredentials, err := loadCredentials(*certDir, node.ClientCertName)
 	if err != nil {
-		fmt.Printf("Error clearing node_data directory: %v\n", err)
+		fmt.Printf("Error: %v\n", err)
 		return
 	}
-	port, err := utils.FindAvailablePort()
+	transport, err := node.NewTransport(*transportName, clientCredentials)
 	if err != nil {
-		fmt.Printf("Error finding available port: %v\n", err)
+		fmt.Printf("Error: %v\n", err)
 		return
 	}
-	addrCoordinator := fmt.Sprintf("%s:%d", utils.IPAddress, port)
-	go func(addr string) {
-		coordinator, err := node.NewCoordinator(addr)
+	// Each node uses the certificate with its name
+	setUpNode := func(n *node.Node) error {
+		_, err := server.setUpNode(n, n.Name == *gatewayNode)
+		return err
+	}
+
+	err = utils.ClearNodeDataDir()
+	if err != nil {
+		fmt.Printf("Error clearing node_data directory: %v\n", err)
+		return
+	}
+
+	// Start Coordinators, one is elected leader and the others stand by
+	coordinatorNames := []string{"C1", "C2", "C3"}
+	coordinatorAddrs := []string{*listenAddr}
+	for range coordinatorNames[1:] {
+		port, err := utils.FindAvailablePort()
 		if err != nil {
-			fmt.Printf("Error creating coordinator: %v\n", err)
+			fmt.Printf("Error finding available port: %v\n", err)
+			return
+		}
+		coordinatorAddrs = append(coordinatorAddrs, fmt.Sprintf("%s:%d", utils.IPAddress, port))
+	}
+	for i, name := range coordinatorNames {
+		var peers []string
+		for j, addr := range coordinatorAddrs {
+			if j != i {
+				peers = append(peers, addr)
+			}
+		}
+		go func(addr string, name string, peers []string) {
+			coordinator, err := node.NewCoordinator(addr, name, peers)
+			if err != nil {
+				fmt.Printf("Error creating coordinator %s: %v\n", name, err)
+				return
+			}
+			if err := setUpNode(coordinator); err != nil {
+				fmt.Printf("Error starting coordinator %s: %v\n", name, err)
+				return
+			}
+			if err := coordinator.LoadRates(*ratesFile); err != nil {
+				fmt.Printf("Error starting coordinator %s: %v\n", name, err)
+				return
+			}
+			coordinator.Start()
+		}(coordinatorAddrs[i], name, peers)
+		err = utils.WaitForServerReady(transport, coordinatorAddrs[i])
+		if err != nil {
+			fmt.Printf("Error waiting for %s to be ready: %v\n", name, err)
 			return
 		}
-		coordinator.Start()
-	}(addrCoordinator)
-	err = utils.WaitForServerReady(addrCoordinator)
-	if err != nil {
-		fmt.Printf("Error waiting for C to be ready: %v\n", err)
-		return
 	}
 
 	// Start Participant A
-	port, err = utils.FindAvailablePort()
+	port, err := utils.FindAvailablePort()
 	if err != nil {
 		fmt.Printf("Error finding available port: %v\n", err)
 		return
 	}
 	addrParticipantA := fmt.Sprintf("%s:%d", utils.IPAddress, port)
 	go func(addr string) {
-		participantA, err := node.NewParticipant(addr, "A")
+		participantA, err := node.NewParticipant(addr, "A", *storageBackend)
 		if err != nil {
 			fmt.Printf("Error creating participant A: %v\n", err)
 			return
 		}
+		if err := setUpNode(participantA); err != nil {
+			fmt.Printf("Error starting participant A: %v\n", err)
+			return
+		}
 		participantA.Start()
 	}(addrParticipantA)
-	err = utils.WaitForServerReady(addrParticipantA)
+	err = utils.WaitForServerReady(transport, addrParticipantA)
 	if err != nil {
 		fmt.Printf("Error waiting for P-A to be ready: %v\n", err)
 		return
@@ -120,24 +285,53 @@ func startServer() {
 	}
 	addrParticipantB := fmt.Sprintf("%s:%d", utils.IPAddress, port)
 	go func(addr string) {
-		participantB, err := node.NewParticipant(addr, "B")
+		participantB, err := node.NewParticipant(addr, "B", *storageBackend)
 		if err != nil {
 			fmt.Printf("Error creating participant A: %v\n", err)
 			return
 		}
+		if err := setUpNode(participantB); err != nil {
+			fmt.Printf("Error starting participant B: %v\n", err)
+			return
+		}
 		participantB.Start()
 	}(addrParticipantB)
-	err = utils.WaitForServerReady(addrParticipantB)
+	err = utils.WaitForServerReady(transport, addrParticipantB)
 	if err != nil {
 		fmt.Printf("Error waiting for P-B to be ready: %v\n", err)
 		return
 	}
-	// Send coordinator to participants (prepare variables)
-	var req = node.ParticipantConnectToCoordinatorRequest{Addr: addrCoordinator}
+	// Start Participant C, attached beneath B which acts as its sub-coordinator
+	port, err = utils.FindAvailablePort()
+	if err != nil {
+		fmt.Printf("Error finding available port: %v\n", err)
+		return
+	}
+	addrParticipantC := fmt.Sprintf("%s:%d", utils.IPAddress, port)
+	go func(addr string) {
+		participantC, err := node.NewParticipant(addr, "C", *storageBackend)
+		if err != nil {
+			fmt.Printf("Error creating participant C: %v\n", err)
+			return
+		}
+		if err := setUpNode(participantC); err != nil {
+			fmt.Printf("Error starting participant C: %v\n", err)
+			return
+		}
+		participantC.Start()
+	}(addrParticipantC)
+	err = utils.WaitForServerReady(transport, addrParticipantC)
+	if err != nil {
+		fmt.Printf("Error waiting for P-C to be ready: %v\n", err)
+		return
+	}
+
+	// Send coordinators to participants (prepare variables)
+	var req = node.ParticipantConnectToCoordinatorRequest{Addrs: coordinatorAddrs}
 	var res node.ParticipantConnectToCoordinatorResponse
 
 	// Send coordinator to Participant A
-	client, err := rpc.Dial("tcp", addrParticipantA)
+	client, err := transport.Dial(addrParticipantA)
 	if err != nil {
 		fmt.Printf("Error sending C address to P-A: %v\n", err)
 		return
@@ -149,7 +343,7 @@ func startServer() {
 	client.Close()
 
 	// Send coordinator to Participant A
-	client, err = rpc.Dial("tcp", addrParticipantB)
+	client, err = transport.Dial(addrParticipantB)
 	if err != nil {
 		fmt.Printf("Error sending C address to P-A: %v\n", err)
 		return
@@ -160,89 +354,295 @@ func startServer() {
 	}
 	client.Close()
 
-	nodesInfo := []string{
-		"Coordinator: " + addrCoordinator,
-		"Participant A: " + addrParticipantA,
-		"Participant B: " + addrParticipantB,
-	}
-
-	// Store node data to file for client to read from
-	err = utils.WriteNodeInfoToFile(nodesInfo, "nodes.txt")
+	// Send Participant B to Participant C as its coordinator
+	var subReq = node.ParticipantConnectToCoordinatorRequest{Addrs: []string{addrParticipantB}}
+	client, err = transport.Dial(addrParticipantC)
 	if err != nil {
-		fmt.Printf("Error writing node info to file: %v\n", err)
+		fmt.Printf("Error sending P-B address to P-C: %v\n", err)
+		return
+	}
+	if err := client.Call("Node.ParticipantConnectToCoordinator", &subReq, &res); err != nil {
+		fmt.Printf("Error sending P-B address to P-C: %v\n", err)
 		return
 	}
+	client.Close()
 
+	fmt.Printf("Cluster running, clients join through %s\n", *listenAddr)
 	select {}
 
 }
 
-func startClient() {
-	var client *rpc.Client
-	var currentAddr string
-	var currentName string
-	var currentType string
-	scanner := bufio.NewScanner(os.Stdin)
+// Run one coordinator, electing a leader with the coordinators at its peers
+func startCoordinator() {
+	flags := flag.NewFlagSet("server coordinator", flag.ExitOnError)
+	name := flags.String("name", "C1", "name of the coordinator, unique in the cluster")
+	listenAddr := flags.String("listen", utils.JoinAddress, "address to serve on, which other nodes reach it by")
+	peers := flags.String("peers", "", "comma separated addresses of the other coordinators")
+	ratesFile := flags.String("rates", utils.RatesFile, "exchange rates used to convert transfers, one \"FROM TO RATE\" per line")
+	server := addServerFlags(flags)
+	flags.Parse(os.Args[3:])
 
-	connectToServer := func() {
-		servers, err := utils.ReadNodeInfoFromFile("nodes.txt")
-		if err != nil {
-			fmt.Printf("Error reading server info: %v\n", err)
-			os.Exit(1)
-		}
-		fmt.Println("Available servers:")
-		for i, server := range servers {
-			fmt.Printf("%d: %s\n", i+1, server)
-		}
-		fmt.Print("Choose a server to connect to: ")
-		var choice int
-		fmt.Scanln(&choice)
-		if choice < 1 || choice > len(servers) {
-			fmt.Println("Invalid choice")
+	coordinator, err := node.NewCoordinator(*listenAddr, *name, splitAddrs(*peers))
+	if err != nil {
+		fmt.Printf("Error creating coordinator %s: %v\n", *name, err)
+		os.Exit(1)
+	}
+	if _, err := server.setUpNode(coordinator, true); err != nil {
+		fmt.Printf("Error starting coordinator %s: %v\n", *name, err)
+		os.Exit(1)
+	}
+	if err := coordinator.LoadRates(*ratesFile); err != nil {
+		fmt.Printf("Error starting coordinator %s: %v\n", *name, err)
+		os.Exit(1)
+	}
+	coordinator.Start()
+}
+
+// Run one participant, which joins the coordinators itself and joins them
+// again whenever they lose track of it
+func startParticipant() {
+	flags := flag.NewFlagSet("server participant", flag.ExitOnError)
+	name := flags.String("name", "", "name of the participant, unique among its coordinator's participants")
+	listenAddr := flags.String("listen", "", "address to serve on, which other nodes reach it by")
+	join := flags.String("join", utils.JoinAddress, "comma separated addresses of a coordinator, or of the participant to join as its sub-coordinator")
+	storageBackend := flags.String("storage", utils.StorageBackend, "storage backend for participant balances (file or memory)")
+	server := addServerFlags(flags)
+	flags.Parse(os.Args[3:])
+	if *name == "" || *listenAddr == "" {
+		fmt.Println("Usage: server participant -name NAME -listen ADDR [-join ADDR]")
+		os.Exit(1)
+	}
+
+	participant, err := node.NewParticipant(*listenAddr, *name, *storageBackend)
+	if err != nil {
+		fmt.Printf("Error creating participant %s: %v\n", *name, err)
+		os.Exit(1)
+	}
+	transport, err := server.setUpNode(participant, true)
+	if err != nil {
+		fmt.Printf("Error starting participant %s: %v\n", *name, err)
+		os.Exit(1)
+	}
+	go func() {
+		// Coordinators connect back to it when it joins
+		if err := utils.WaitForServerReady(transport, *listenAddr); err != nil {
+			fmt.Printf("Error waiting for %s to be ready: %v\n", *name, err)
 			os.Exit(1)
 		}
-		selectedServer := servers[choice-1]
-		parts := strings.Split(selectedServer, ":")
-		if len(parts) < 3 {
-			fmt.Println("Invalid server format")
+		if err := participant.Join(splitAddrs(*join)); err != nil {
+			fmt.Printf("Error joining %s: %v\n", *join, err)
 			os.Exit(1)
 		}
-		IPAddress := strings.TrimSpace(parts[len(parts)-2])
-		Port := strings.TrimSpace(parts[len(parts)-1])
-		client, err = rpc.Dial("tcp", fmt.Sprintf("%s:%s", IPAddress, Port))
-		if err != nil {
-			fmt.Printf("Error dialing RPC server: %v\n", err)
-			os.Exit(1)
+	}()
+	participant.Start()
+}
+
+func splitAddrs(addrs string) []string {
+	var result []string
+	for _, addr := range strings.Split(addrs, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			result = append(result, addr)
 		}
-		var req node.GetInfoRequest
-		var res node.GetInfoResponse
-		if err := client.Call("Node.GetInfo", &req, &res); err != nil {
-			fmt.Printf("Error callign RPC method: %v\n", err)
-			os.Exit(1)
+	}
+	return result
+}
+
+func startClient() {
+	flags := flag.NewFlagSet("client", flag.ExitOnError)
+	transportName := flags.String("transport", utils.Transport, "transport to call the nodes with (rpc or grpc)")
+	certDir := flags.String("tls", utils.CertDir, "directory of certificates made by \"certs\", to use mutual TLS (plaintext when empty)")
+	join := flags.String("join", utils.JoinAddress, "address of a node to list the cluster's nodes from")
+	flags.Parse(os.Args[2:])
+	credentials, err := loadCredentials(*certDir, node.ClientCertName)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Calls follow the chosen node across restarts, and go through another
+	// participant while it is down when they can
+	var client *node.ClusterClient
+	for {
+		client, err = node.DialCluster(*transportName, credentials, *join)
+		if err == nil {
+			break
 		}
-		currentAddr = res.Addr
-		currentName = res.Name
-		currentType = res.Type
-		if currentType == "Participant" {
+		fmt.Printf("Waiting for the cluster at %s: %v\n", *join, err)
+		time.Sleep(3 * time.Second)
+	}
+	defer client.Close()
+	client.Log = func(message string) {
+		fmt.Println(message)
+	}
+	var currentAddr string
+	var currentName string
+	var currentType string
+	var currentAccount string
+	scanner := bufio.NewScanner(os.Stdin)
+
+	// Choose the node to act as, false once there is no more input
+	connectToServer := func() bool {
+		for {
+			if err := client.Refresh(); err != nil {
+				fmt.Printf("Error: %v, showing the nodes listed before\n", err)
+			}
+			servers := client.Nodes()
+			fmt.Println("Available servers:")
+			for i, server := range servers {
+				name := server.Name
+				if name == "" {
+					name = "(unreachable)"
+				}
+				fmt.Printf("%d: %s %s: %s\n", i+1, server.Type, name, server.Addr)
+			}
+			fmt.Print("Choose a server to connect to: ")
+			var choice int
+			if _, err := fmt.Scanln(&choice); err == io.EOF {
+				return false
+			}
+			if choice < 1 || choice > len(servers) {
+				fmt.Println("Invalid choice")
+				continue
+			}
+			entry, err := client.Connect(servers[choice-1].Addr)
+			if err != nil {
+				fmt.Printf("Error connecting to %s: %v\n", servers[choice-1].Addr, err)
+				continue
+			}
+			currentAddr = entry.Addr
+			currentName = entry.Name
+			currentType = entry.Type
+			currentAccount = "main"
 			fmt.Printf("Connected to %s-%s\n", currentType, currentName)
-		} else {
-			fmt.Printf("Connected to %s\n", currentType)
+			return true
 		}
 	}
 
-	getBalance := func() float64 {
-		var req node.GetBalanceRequest
+	getBalance := func(account string) node.GetBalanceResponse {
+		var req node.GetBalanceRequest = node.GetBalanceRequest{
+			Account: account,
+		}
 		var res node.GetBalanceResponse
 		if err := client.Call("Node.GetBalance", &req, &res); err != nil {
 			fmt.Printf("Error calling RPC method: %v\n", err)
 		}
-		return res.Balance
+		return res
 	}
 
-	connectToServer() // Initial connection setup
+	// Hold on the current account whose ID starts with prefix
+	findHold := func(prefix string) (node.Hold, error) {
+		var req = node.ListHoldsRequest{Account: currentAccount}
+		var res node.ListHoldsResponse
+		if err := client.Call("Node.ListHolds", &req, &res); err != nil {
+			return node.Hold{}, err
+		}
+		var found []node.Hold
+		for _, hold := range res.Holds {
+			if strings.HasPrefix(hold.HoldID.String(), prefix) {
+				found = append(found, hold)
+			}
+		}
+		if len(found) != 1 {
+			return node.Hold{}, fmt.Errorf("%d holds on %s match %q", len(found), currentAccount, prefix)
+		}
+		return found[0], nil
+	}
+
+	// Interactively build a multi-participant transaction
+	buildTransactions := func() ([]node.Transaction, error) {
+		// Get the list of participants
+		var listReq node.ListParticipantsRequest
+		var listRes node.ListParticipantsResponse
+		if err := client.Call("Node.ListParticipants", &listReq, &listRes); err != nil {
+			return nil, err
+		}
+		transactions := []node.Transaction{}
+
+		for {
+			fmt.Println("Select a participant to include in the transaction:")
+			for i, name := range listRes.Names {
+				address := listRes.Addresses[i]
+				addedLabel := ""
+				for _, tx := range transactions {
+					if tx.Addr == address {
+						addedLabel = " (added)"
+						break
+					}
+				}
+				fmt.Printf("%d: %s - %s%s\n", i+1, name, address, addedLabel)
+			}
+
+			fmt.Print("Enter participant number: ")
+			var participantChoice int
+			fmt.Scanln(&participantChoice)
+			if participantChoice < 1 || participantChoice > len(listRes.Names) {
+				fmt.Println("Invalid participant choice")
+				continue
+			}
+
+			targetAddr := listRes.Addresses[participantChoice-1]
+			targetName := listRes.Names[participantChoice-1]
+
+			fmt.Printf("Enter account on participant '%s' (blank for main): ", targetName)
+			var targetAccount string
+			fmt.Scanln(&targetAccount)
+			if targetAccount == "" {
+				targetAccount = "main"
+			}
+
+			// Check if the account has already been added
+			alreadyAdded := false
+			for _, tx := range transactions {
+				if tx.Addr == targetAddr && tx.Account == targetAccount {
+					alreadyAdded = true
+					break
+				}
+			}
+			if alreadyAdded {
+				fmt.Println("This account has already been added. Please choose a different one.")
+				continue
+			}
+
+			fmt.Printf("Enter operation and amount for '%s/%s' (%s): ", targetName, targetAccount, operationExamples())
+			var opInput string
+			fmt.Scanln(&opInput)
+			operation, amount, expected, err := node.ParseOperation(opInput)
+			if err != nil {
+				fmt.Printf("Error parsing operation: %v\n", err)
+				continue
+			}
+			transaction := node.Transaction{
+				Addr:      targetAddr,
+				Name:      targetName,
+				Account:   targetAccount,
+				Operation: operation,
+				Amount:    amount,
+				Expected:  expected,
+			}
+			if err := node.ValidateTransaction(transaction); err != nil {
+				fmt.Printf("Invalid operation: %v\n", err)
+				continue
+			}
+			transactions = append(transactions, transaction)
+
+			fmt.Print("Do you want to add more accounts to this transaction? (yes/no): ")
+			var more string
+			fmt.Scanln(&more)
+			if strings.ToLower(more) != "yes" {
+				break
+			}
+		}
+		return transactions, nil
+	}
+
+	if !connectToServer() {
+		return
+	}
 
 	fmt.Println("Enter commands (get 'help' to see full options):")
 	for {
+		// The same node, at a new address if it restarted elsewhere
+		currentAddr = client.Entry().Addr
 		fmt.Print("> ")
 		scanner.Scan()
 		input := scanner.Text()
@@ -280,17 +680,339 @@ func startClient() {
 					if address == currentAddr {
 						selfLabel = " (self)"
 					}
-					fmt.Printf("%d: %s - %s%s\n", i+1, name, address, selfLabel)
+					liveness := res.Liveness[i]
+					if !res.LastSeen[i].IsZero() {
+						liveness += fmt.Sprintf(", last seen %s", res.LastSeen[i].Format(time.TimeOnly))
+					}
+					if res.IDs[i] != uuid.Nil {
+						liveness += ", id " + res.IDs[i].String()
+					}
+					// Coordinators from before versioning send none
+					if i < len(res.Versions) {
+						liveness += fmt.Sprintf(", protocol %d", res.Versions[i])
+					}
+					fmt.Printf("%d: %s - %s%s [%s]\n", i+1, name, address, selfLabel, liveness)
 				}
 			}
+		case "leave":
+			// Leave the coordinators, keeping this participant's identity to join again
+			var req node.LeaveRequest
+			var res node.LeaveResponse
+			if err := client.Call("Node.Leave", &req, &res); err != nil {
+				fmt.Printf("Error leaving: %v\n", err)
+				continue
+			}
+			fmt.Println("Left the coordinators.")
+		case "join":
+			// Join the coordinators left before again
+			var req node.ParticipantConnectToCoordinatorRequest
+			var res node.ParticipantConnectToCoordinatorResponse
+			if err := client.Call("Node.ParticipantConnectToCoordinator", &req, &res); err != nil {
+				fmt.Printf("Error joining: %v\n", err)
+				continue
+			}
+			fmt.Printf("Joined as %s\n", res.ID)
+		case "remove":
+			// Remove a participant from the current node's membership
+			if len(parts) < 2 {
+				fmt.Println("Usage: remove <participant>")
+				continue
+			}
+			var listReq node.ListParticipantsRequest
+			var listRes node.ListParticipantsResponse
+			if err := client.Call("Node.ListParticipants", &listReq, &listRes); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			var id uuid.UUID
+			for i, name := range listRes.Names {
+				if name == parts[1] {
+					id = listRes.IDs[i]
+				}
+			}
+			if id == uuid.Nil {
+				fmt.Printf("%s is not a direct participant\n", parts[1])
+				continue
+			}
+			var req = node.RemoveParticipantRequest{ID: id}
+			var res node.RemoveParticipantResponse
+			if err := client.Call("Node.RemoveParticipant", &req, &res); err != nil {
+				fmt.Printf("Error removing %s: %v\n", parts[1], err)
+				continue
+			}
+			fmt.Printf("Removed %s from %s-%s\n", parts[1], currentType, currentName)
+		case "leader":
+			// Show which coordinator is currently leader
+			var req node.GetLeaderRequest
+			var res node.GetLeaderResponse
+			if err := client.Call("Node.GetLeader", &req, &res); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			if res.LeaderAddr == "" {
+				fmt.Println("No leader elected yet.")
+				continue
+			}
+			fmt.Printf("Leader: %s\n", res.LeaderAddr)
+		case "conns":
+			// Show the current server's connections to other nodes
+			var req node.GetConnectionsRequest
+			var res node.GetConnectionsResponse
+			if err := client.Call("Node.GetConnections", &req, &res); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			if len(res.Connections) == 0 {
+				fmt.Println("No connections.")
+				continue
+			}
+			for _, conn := range res.Connections {
+				name := conn.Name
+				if name == "" {
+					name = "?"
+				}
+				fmt.Printf("%s - %s: %s since %s", name, conn.Address, conn.State, conn.Since.Format(time.TimeOnly))
+				if conn.Failures > 0 {
+					fmt.Printf(", %d failed dials", conn.Failures)
+				}
+				if conn.LastError != "" {
+					fmt.Printf(" (last error: %s)", conn.LastError)
+				}
+				fmt.Println()
+			}
 		case "switch":
 			// Connect to a new server
-			if client != nil {
-				client.Close()
+			if !connectToServer() {
+				return
 			}
-			connectToServer()
 		case "bal":
-			fmt.Printf("Balance: %.2f\n", getBalance())
+			account := currentAccount
+			if len(parts) == 2 {
+				account = strings.TrimSpace(parts[1])
+			}
+			balance := getBalance(account)
+			fmt.Printf("Balance of %s: %s %s\n", account, balance.Balance, balance.Currency)
+			if balance.Available.Cmp(balance.Balance) != 0 {
+				fmt.Printf("Available: %s %s\n", balance.Available, balance.Currency)
+			}
+		case "use":
+			// Switch the account used by bal, deposit, withdraw and send
+			if len(parts) != 2 {
+				fmt.Println("Usage: use <account>")
+				continue
+			}
+			account := strings.TrimSpace(parts[1])
+			var req node.GetBalanceRequest = node.GetBalanceRequest{
+				Account: account,
+			}
+			var res node.GetBalanceResponse
+			if err := client.Call("Node.GetBalance", &req, &res); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			currentAccount = account
+			fmt.Printf("Using account %s/%s\n", currentName, currentAccount)
+		case "accounts":
+			var req node.ListAccountsRequest
+			var res node.ListAccountsResponse
+			if err := client.Call("Node.ListAccounts", &req, &res); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			for _, account := range res.Accounts {
+				currentLabel := ""
+				if account.Account == currentAccount {
+					currentLabel = " (current)"
+				}
+				owner := ""
+				if account.Owner != "" {
+					owner = ", owned by " + account.Owner
+				}
+				fmt.Printf("%s/%s: %s %s [%s%s]%s\n", currentName, account.Account, account.Balance, account.Currency, account.Status, owner, currentLabel)
+			}
+		case "history":
+			// history [account] [page=N] [since=TIME] [until=TIME]
+			const pageSize = 10
+			req := node.GetHistoryRequest{Account: currentAccount, Limit: pageSize}
+			page := 1
+			var argErr error
+			if len(parts) == 2 {
+				for _, arg := range strings.Fields(parts[1]) {
+					key, value, found := strings.Cut(arg, "=")
+					switch {
+					case !found:
+						req.Account = arg
+					case key == "page":
+						page, argErr = strconv.Atoi(value)
+						if argErr == nil && page < 1 {
+							argErr = fmt.Errorf("page must be at least 1")
+						}
+					case key == "since":
+						req.Since, argErr = parseTime(value)
+					case key == "until":
+						req.Until, argErr = parseTime(value)
+					default:
+						argErr = fmt.Errorf("unknown option %s", key)
+					}
+				}
+			}
+			if argErr != nil {
+				fmt.Printf("Error: %v\n", argErr)
+				fmt.Println("Usage: history [account] [page=N] [since=TIME] [until=TIME]")
+				continue
+			}
+			req.Offset = (page - 1) * pageSize
+			var res node.GetHistoryResponse
+			if err := client.Call("Node.GetHistory", &req, &res); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			pages := (res.Total + pageSize - 1) / pageSize
+			fmt.Printf("History of %s/%s, page %d of %d (%d entries)\n", currentName, req.Account, page, max(pages, 1), res.Total)
+			for _, entry := range res.Entries {
+				counterparts := "-"
+				if len(entry.Counterparts) > 0 {
+					counterparts = strings.Join(entry.Counterparts, ",")
+				}
+				converted := ""
+				if entry.Conversion != nil {
+					converted = fmt.Sprintf(" [%s %s at %s]", entry.Conversion.Original, entry.Conversion.From, entry.Conversion.Rate)
+				}
+				fmt.Printf("%s %s %-8s %s%s: %s -> %s (with %s)\n", entry.Time.Format("2006-01-02 15:04:05"),
+					entry.TransactionID.String()[:8], entry.Operation, entry.Amount, converted, entry.Before, entry.After, counterparts)
+			}
+		case "policy":
+			// policy [overdraft|min|maxdebit|daily <amount>], 0 removes a limit
+			var getReq = node.GetAccountPolicyRequest{Account: currentAccount}
+			var getRes node.GetAccountPolicyResponse
+			if err := client.Call("Node.GetAccountPolicy", &getReq, &getRes); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			policy := getRes.Policy
+			if len(parts) == 2 {
+				args := strings.Fields(parts[1])
+				if len(args) != 2 {
+					fmt.Println("Usage: policy [overdraft|min|maxdebit|daily <amount>]")
+					continue
+				}
+				amount, err := node.ParseDecimal(args[1])
+				if err != nil {
+					fmt.Printf("Error parsing amount: %v\n", err)
+					continue
+				}
+				switch args[0] {
+				case "overdraft":
+					policy.Overdraft = amount
+				case "min":
+					policy.MinBalance = amount
+				case "maxdebit":
+					policy.MaxDebit = amount
+				case "daily":
+					policy.DailyDebitLimit = amount
+				default:
+					fmt.Println("Usage: policy [overdraft|min|maxdebit|daily <amount>]")
+					continue
+				}
+				var setReq = node.SetAccountPolicyRequest{Account: currentAccount, Policy: policy}
+				var setRes node.SetAccountPolicyResponse
+				if err := client.Call("Node.SetAccountPolicy", &setReq, &setRes); err != nil {
+					fmt.Printf("Error calling RPC method: %v\n", err)
+					continue
+				}
+			}
+			fmt.Printf("Policy of %s/%s: overdraft %s, minimum balance %s, max debit %s, daily debit limit %s\n", currentName, currentAccount,
+				policy.Overdraft, policy.MinBalance, policy.MaxDebit, policy.DailyDebitLimit)
+		case "audit":
+			// audit [since=TIME], checks money is conserved across all participants
+			var req node.AuditRequest
+			if len(parts) == 2 {
+				value, found := strings.CutPrefix(strings.TrimSpace(parts[1]), "since=")
+				var err error
+				if found {
+					req.Since, err = parseTime(value)
+				}
+				if !found || err != nil {
+					fmt.Println("Usage: audit [since=TIME]")
+					continue
+				}
+			}
+			var res node.AuditResponse
+			if err := client.Call("Node.Audit", &req, &res); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			report := res.Report
+			fmt.Printf("Audit of %s at %s\n", strings.Join(report.Participants, ", "), report.Cut.Format("2006-01-02 15:04:05"))
+			for _, totals := range report.Totals {
+				fmt.Printf("%s: baseline %s + deposits %s - withdrawals %s + exchanged %s = expected %s\n", totals.Currency,
+					totals.BaselineTotal, totals.Deposits, totals.Withdrawals, totals.Exchanged, totals.ExpectedTotal)
+				fmt.Printf("%s: actual %s, discrepancy %s\n", totals.Currency, totals.ActualTotal, totals.Discrepancy)
+			}
+			for _, issue := range report.Issues {
+				location := strings.Trim(issue.Participant+"/"+issue.Account, "/")
+				if issue.TransactionID != uuid.Nil {
+					location = strings.TrimSpace(issue.TransactionID.String() + " " + location)
+				}
+				fmt.Printf("  %s: %s\n", location, issue.Description)
+			}
+			if report.Consistent {
+				fmt.Println("Money is conserved")
+			}
+		case "open":
+			// open <account> [currency] [scale] [owner=NAME] [balance=AMOUNT], scale being
+			// the decimal places of its currency
+			req := node.OpenAccountRequest{Scale: 2}
+			var positional []string
+			var argErr error
+			if len(parts) == 2 {
+				for _, arg := range strings.Fields(parts[1]) {
+					key, value, found := strings.Cut(arg, "=")
+					switch {
+					case !found:
+						positional = append(positional, arg)
+					case key == "owner":
+						req.Owner = value
+					case key == "balance":
+						req.InitialBalance, argErr = node.ParseDecimal(value)
+					default:
+						argErr = fmt.Errorf("unknown option %s", key)
+					}
+				}
+			}
+			if argErr == nil && (len(positional) < 1 || len(positional) > 3) {
+				argErr = fmt.Errorf("an account name is needed")
+			}
+			if argErr == nil && len(positional) >= 2 {
+				req.Currency = strings.ToUpper(positional[1])
+			}
+			if argErr == nil && len(positional) == 3 {
+				req.Scale, argErr = strconv.Atoi(positional[2])
+			}
+			if argErr != nil {
+				fmt.Printf("Error: %v\n", argErr)
+				fmt.Println("Usage: open <account> [currency] [scale] [owner=NAME] [balance=AMOUNT]")
+				continue
+			}
+			req.Account = positional[0]
+			var res node.OpenAccountResponse
+			if err := client.Call("Node.OpenAccount", &req, &res); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			fmt.Printf("Opened account %s/%s\n", currentName, req.Account)
+		case "freeze", "unfreeze", "close":
+			// freeze|unfreeze|close [account]
+			req := node.ChangeAccountRequest{Account: currentAccount, Operation: node.OperationType(command)}
+			if len(parts) == 2 {
+				req.Account = strings.TrimSpace(parts[1])
+			}
+			var res node.ChangeAccountResponse
+			if err := client.Call("Node.ChangeAccount", &req, &res); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			fmt.Printf("Account %s/%s is now %s\n", currentName, req.Account, res.Status)
 		case "deposit":
 			if currentType != "Participant" {
 				fmt.Println("Deposit command is only available for participants.")
@@ -300,20 +1022,21 @@ func startClient() {
 				fmt.Printf("Usage: deposit <amount>\n")
 				continue
 			}
-			amount, err := strconv.ParseFloat(parts[1], 64)
+			amount, err := node.ParseDecimal(parts[1])
 			if err != nil {
 				fmt.Printf("Error parsing amount: %v", err)
 				continue
 			}
 			var req node.DepositRequest = node.DepositRequest{
-				Amount: amount,
+				Account: currentAccount,
+				Amount:  amount,
 			}
 			var res node.DepositResponse
 			if err := client.Call("Node.Deposit", &req, &res); err != nil {
 				fmt.Printf("Error calling RPC method: %v\n", err)
 				continue
 			}
-			fmt.Printf("Deposited %.2f\n", amount)
+			fmt.Printf("Deposited %s to %s\n", amount, currentAccount)
 		case "withdraw":
 			if currentType != "Participant" {
 				fmt.Println("Withdraw command is only available for participants.")
@@ -323,25 +1046,131 @@ func startClient() {
 				fmt.Printf("Usage: deposit <amout>")
 				continue
 			}
-			amount, err := strconv.ParseFloat(parts[1], 64)
+			amount, err := node.ParseDecimal(parts[1])
 			if err != nil {
 				fmt.Printf("Error parsing amount: %v", err)
 				continue
 			}
-			if amount <= 0 {
+			if amount.Sign() <= 0 {
 				fmt.Println("Invalid amount. Please enter a positive number.")
 				continue
 			}
 			var withdrawReq node.WithdrawRequest = node.WithdrawRequest{
-				Amount: amount,
+				Account: currentAccount,
+				Amount:  amount,
 			}
 			var withdrawRes node.WithdrawResponse
 			if err := client.Call("Node.Withdraw", &withdrawReq, &withdrawRes); err != nil {
 				fmt.Printf("Error calling Withdraw RPC method: %v\n", err)
 				continue
 			}
-			fmt.Printf("Withdrew %.2f\n", amount)
+			fmt.Printf("Withdrew %s from %s\n", amount, currentAccount)
+		case "hold":
+			// hold <amount> [ttl=DURATION]
+			args := []string{}
+			if len(parts) == 2 {
+				args = strings.Fields(parts[1])
+			}
+			if len(args) < 1 || len(args) > 2 {
+				fmt.Println("Usage: hold <amount> [ttl=DURATION]")
+				continue
+			}
+			req := node.PlaceHoldRequest{Account: currentAccount}
+			var err error
+			if req.Amount, err = node.ParseDecimal(args[0]); err != nil {
+				fmt.Printf("Error parsing amount: %v\n", err)
+				continue
+			}
+			if len(args) == 2 {
+				value, found := strings.CutPrefix(args[1], "ttl=")
+				if req.TTL, err = time.ParseDuration(value); !found || err != nil {
+					fmt.Println("Usage: hold <amount> [ttl=DURATION]")
+					continue
+				}
+			}
+			var res node.PlaceHoldResponse
+			if err := client.Call("Node.PlaceHold", &req, &res); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			fmt.Printf("Hold %s of %s on %s/%s until %s\n", res.Hold.HoldID, res.Hold.Amount, currentName, currentAccount, res.Hold.Expires.Format("15:04:05"))
+		case "holds":
+			var req = node.ListHoldsRequest{Account: currentAccount}
+			var res node.ListHoldsResponse
+			if err := client.Call("Node.ListHolds", &req, &res); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			if len(res.Holds) == 0 {
+				fmt.Printf("No holds on %s/%s\n", currentName, currentAccount)
+			}
+			for _, hold := range res.Holds {
+				fmt.Printf("%s %s until %s\n", hold.HoldID, hold.Amount, hold.Expires.Format("2006-01-02 15:04:05"))
+			}
+		case "release":
+			// release <hold-id>, any unique prefix of it will do
+			if len(parts) != 2 {
+				fmt.Println("Usage: release <hold-id>")
+				continue
+			}
+			hold, err := findHold(strings.TrimSpace(parts[1]))
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				continue
+			}
+			var req = node.ReleaseHoldRequest{HoldID: hold.HoldID}
+			var res node.ReleaseHoldResponse
+			if err := client.Call("Node.ReleaseHold", &req, &res); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			fmt.Printf("Released hold of %s on %s/%s\n", hold.Amount, currentName, currentAccount)
+		case "capture":
+			// capture <hold-id> <participant>/<account> [amount], the whole hold when no amount is given
+			args := []string{}
+			if len(parts) == 2 {
+				args = strings.Fields(parts[1])
+			}
+			if len(args) < 2 || len(args) > 3 {
+				fmt.Println("Usage: capture <hold-id> <participant>/<account> [amount]")
+				continue
+			}
+			hold, err := findHold(args[0])
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				continue
+			}
+			req := node.CaptureHoldRequest{HoldID: hold.HoldID}
+			if len(args) == 3 {
+				if req.Amount, err = node.ParseDecimal(args[2]); err != nil {
+					fmt.Printf("Error parsing amount: %v\n", err)
+					continue
+				}
+			}
+			var listReq node.ListParticipantsRequest
+			var listRes node.ListParticipantsResponse
+			if err := client.Call("Node.ListParticipants", &listReq, &listRes); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			req.To.Name, req.To.Account = parseAccountAddress(args[1])
+			for i, name := range listRes.Names {
+				if name == req.To.Name {
+					req.To.Addr = listRes.Addresses[i]
+				}
+			}
+			if req.To.Addr == "" {
+				fmt.Printf("Unknown participant %s\n", req.To.Name)
+				continue
+			}
+			var res node.CaptureHoldResponse
+			if err := client.Call("Node.CaptureHold", &req, &res); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			fmt.Printf("Captured hold on %s/%s for %s/%s\n", currentName, currentAccount, req.To.Name, req.To.Account)
 		case "send":
+			// send [<participant>/<account> <amount>], prompts for anything not given
 			var listReq node.ListParticipantsRequest
 			var listRes node.ListParticipantsResponse
 			if err := client.Call("Node.ListParticipants", &listReq, &listRes); err != nil {
@@ -352,48 +1181,91 @@ func startClient() {
 				fmt.Println("No participants available to send to.")
 				continue
 			}
-			fmt.Println("Select a participant to send to:")
-			for i, name := range listRes.Names {
-				address := listRes.Addresses[i] // Get the corresponding address
-				selfLabel := ""
-				if address == currentAddr {
-					selfLabel = " (self)"
+			var targetAddr, targetName, targetAccount string
+			var amount node.Decimal
+			if len(parts) == 2 {
+				args := strings.Fields(parts[1])
+				if len(args) != 2 {
+					fmt.Println("Usage: send <participant>/<account> <amount>")
+					continue
+				}
+				var err error
+				targetName, targetAccount = parseAccountAddress(args[0])
+				amount, err = node.ParseDecimal(args[1])
+				if err != nil {
+					fmt.Printf("Error parsing amount: %v\n", err)
+					continue
+				}
+				for i, name := range listRes.Names {
+					if name == targetName {
+						targetAddr = listRes.Addresses[i]
+					}
+				}
+				if targetAddr == "" {
+					fmt.Printf("Unknown participant %s\n", targetName)
+					continue
+				}
+			} else {
+				fmt.Println("Select a participant to send to:")
+				for i, name := range listRes.Names {
+					address := listRes.Addresses[i] // Get the corresponding address
+					selfLabel := ""
+					if address == currentAddr {
+						selfLabel = " (self)"
+					}
+					fmt.Printf("%d: %s - %s%s\n", i+1, name, address, selfLabel)
+				}
+				var participantChoice int
+				fmt.Print("Enter participant number: ")
+				fmt.Scanln(&participantChoice)
+				if participantChoice < 1 || participantChoice > len(listRes.Names) {
+					fmt.Println("Invalid participant choice")
+					continue
+				}
+				targetAddr = listRes.Addresses[participantChoice-1]
+				targetName = listRes.Names[participantChoice-1]
+				fmt.Print("Enter the account to send to (blank for main): ")
+				fmt.Scanln(&targetAccount)
+				if targetAccount == "" {
+					targetAccount = "main"
+				}
+				fmt.Print("Enter the amount to send: ")
+				var amountInput string
+				fmt.Scanln(&amountInput)
+				var err error
+				amount, err = node.ParseDecimal(amountInput)
+				if err != nil {
+					fmt.Printf("Error parsing amount: %v\n", err)
+					continue
 				}
-				fmt.Printf("%d: %s - %s%s\n", i+1, name, address, selfLabel)
-			}
-			var participantChoice int
-			fmt.Print("Enter participant number: ")
-			fmt.Scanln(&participantChoice)
-			if participantChoice < 1 || participantChoice > len(listRes.Names) {
-				fmt.Println("Invalid participant choice")
-				continue
 			}
-			targetAddr := listRes.Addresses[participantChoice-1]
-			targetName := listRes.Names[participantChoice-1]
-			if targetAddr == currentAddr {
+			if targetAddr == currentAddr && targetAccount == currentAccount {
 				fmt.Println("Cannot send to self.")
 				continue
 			}
-			var amount float64
-			fmt.Print("Enter the amount to send: ")
-			fmt.Scanln(&amount)
-			if amount <= 0 {
+			if amount.Sign() <= 0 {
 				fmt.Println("Invalid amount")
 				continue
 			}
 
-			// Create transactions for sender and receiver
+			// Create transactions for sender and receiver, the amount is in the
+			// sender's currency and converted for the receiver if needed
+			currency := getBalance(currentAccount).Currency
 			senderTransaction := node.Transaction{
 				Addr:      currentAddr,
 				Name:      currentName,
+				Account:   currentAccount,
 				Operation: "subtract",
 				Amount:    amount,
+				Currency:  currency,
 			}
 			receiverTransaction := node.Transaction{
 				Addr:      targetAddr,
 				Name:      targetName,
+				Account:   targetAccount,
 				Operation: "add",
 				Amount:    amount,
+				Currency:  currency,
 			}
 
 			// Create ClientParticipantTransactionRequest with both transactions
@@ -405,102 +1277,13 @@ func startClient() {
 				fmt.Printf("Error calling RPC method: %v\n", err)
 				continue
 			}
-			fmt.Printf("Sent %.2f from %s to %s\n", amount, currentName, targetName)
+			fmt.Printf("Sent %s %s from %s/%s to %s/%s\n", amount, currency, currentName, currentAccount, targetName, targetAccount)
 		case "transaction":
-			// Get the list of participants
-			var listReq node.ListParticipantsRequest
-			var listRes node.ListParticipantsResponse
-			if err := client.Call("Node.ListParticipants", &listReq, &listRes); err != nil {
+			transactions, err := buildTransactions()
+			if err != nil {
 				fmt.Printf("Error calling RPC method: %v\n", err)
 				continue
 			}
-			parseOperation := func(input string) (string, float64, error) {
-				var operation string
-				var amount float64
-				var err error
-				if strings.HasPrefix(input, "+") {
-					operation = "add"
-					amount, err = strconv.ParseFloat(input[1:], 64)
-				} else if strings.HasPrefix(input, "-") {
-					operation = "subtract"
-					amount, err = strconv.ParseFloat(input[1:], 64)
-				} else if strings.HasPrefix(input, "*") {
-					operation = "multiply"
-					amount, err = strconv.ParseFloat(input[1:], 64)
-				} else {
-					err = fmt.Errorf("invalid operation format")
-				}
-				return operation, amount, err
-			}
-			transactions := []node.Transaction{}
-
-			for {
-				allAdded := len(transactions) == len(listRes.Names)
-				if allAdded {
-					fmt.Println("All available participants have been added to the transaction.")
-					break
-				}
-				fmt.Println("Select a participant to include in the transaction:")
-				for i, name := range listRes.Names {
-					address := listRes.Addresses[i]
-					addedLabel := ""
-					for _, tx := range transactions {
-						if tx.Addr == address {
-							addedLabel = " (added)"
-							break
-						}
-					}
-					fmt.Printf("%d: %s - %s%s\n", i+1, name, address, addedLabel)
-				}
-
-				fmt.Print("Enter participant number: ")
-				var participantChoice int
-				fmt.Scanln(&participantChoice)
-				if participantChoice < 1 || participantChoice > len(listRes.Names) {
-					fmt.Println("Invalid participant choice")
-					continue
-				}
-
-				targetAddr := listRes.Addresses[participantChoice-1]
-				targetName := listRes.Names[participantChoice-1]
-
-				// Check if the participant has already been added
-				alreadyAdded := false
-				for _, tx := range transactions {
-					if tx.Addr == targetAddr {
-						alreadyAdded = true
-						break
-					}
-				}
-				if alreadyAdded {
-					fmt.Println("This participant has already been added. Please choose a different one.")
-					continue
-				}
-
-				fmt.Printf("Enter operation and amount for participant '%s' (e.g., +50, -30, *1.2): ", targetName)
-				var opInput string
-				fmt.Scanln(&opInput)
-				operation, amount, err := parseOperation(opInput)
-				if err != nil {
-					fmt.Printf("Error parsing operation: %v\n", err)
-					continue
-				}
-
-				transactions = append(transactions, node.Transaction{
-					Addr:      targetAddr,
-					Name:      targetName,
-					Operation: operation,
-					Amount:    amount,
-				})
-
-				fmt.Print("Do you want to add more participants to this transaction? (yes/no): ")
-				var more string
-				fmt.Scanln(&more)
-				if strings.ToLower(more) != "yes" {
-					break
-				}
-			}
-
 			var req node.ClientParticipantTransactionRequest = node.ClientParticipantTransactionRequest{
 				Transactions: transactions,
 			}
@@ -511,6 +1294,136 @@ func startClient() {
 			}
 
 			fmt.Println("Transaction successfully processed.")
+		case "saga":
+			// Same as transaction, but each step commits on its own and is compensated on failure
+			transactions, err := buildTransactions()
+			if err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			var req node.ClientParticipantSagaRequest = node.ClientParticipantSagaRequest{
+				Transactions: transactions,
+			}
+			var res node.ClientParticipantSagaResponse
+			err = client.Call("Node.ClientParticipantSaga", &req, &res)
+			if res.Saga.SagaID != uuid.Nil {
+				printSaga(res.Saga)
+			}
+			if err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			fmt.Println("Saga successfully completed.")
+		case "sagas":
+			// Show saga progress, optionally for a single saga ID
+			var req node.GetSagaStatusRequest
+			if len(parts) == 2 {
+				sagaID, err := uuid.Parse(strings.TrimSpace(parts[1]))
+				if err != nil {
+					fmt.Printf("Error parsing saga ID: %v\n", err)
+					continue
+				}
+				req.SagaID = sagaID
+			}
+			var res node.GetSagaStatusResponse
+			if err := client.Call("Node.GetSagaStatus", &req, &res); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			if len(res.Sagas) == 0 {
+				fmt.Println("No sagas found.")
+			}
+			for _, saga := range res.Sagas {
+				printSaga(saga)
+			}
+		case "indoubt":
+			// Transactions waiting for a decision on this participant
+			var req node.ListInDoubtRequest
+			var res node.ListInDoubtResponse
+			if err := client.Call("Node.ListInDoubt", &req, &res); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			if len(res.TransactionIDs) == 0 {
+				fmt.Println("No in-doubt transactions.")
+			}
+			for _, transactionID := range res.TransactionIDs {
+				fmt.Println(transactionID)
+			}
+		case "heuristic":
+			// Force a decision on an in-doubt transaction
+			args := []string{}
+			if len(parts) == 2 {
+				args = strings.Fields(parts[1])
+			}
+			if len(args) != 2 || (args[0] != "commit" && args[0] != "abort") {
+				fmt.Println("Usage: heuristic <commit|abort> <transaction id>")
+				continue
+			}
+			transactionID, err := uuid.Parse(args[1])
+			if err != nil {
+				fmt.Printf("Error parsing transaction ID: %v\n", err)
+				continue
+			}
+			var req node.ForceHeuristicDecisionRequest = node.ForceHeuristicDecisionRequest{
+				TransactionID: transactionID,
+				Decision:      strings.ToUpper(args[0]),
+			}
+			var res node.ForceHeuristicDecisionResponse
+			if err := client.Call("Node.ForceHeuristicDecision", &req, &res); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			fmt.Printf("Heuristic %s recorded for %s\n", args[0], transactionID)
+		case "heuristics":
+			// Heuristic decisions and whether they matched the real decision
+			var req node.GetHeuristicOutcomesRequest
+			var res node.GetHeuristicOutcomesResponse
+			if err := client.Call("Node.GetHeuristicOutcomes", &req, &res); err != nil {
+				fmt.Printf("Error calling RPC method: %v\n", err)
+				continue
+			}
+			if len(res.Outcomes) == 0 {
+				fmt.Println("No heuristic decisions.")
+			}
+			for _, outcome := range res.Outcomes {
+				actual := outcome.Actual
+				if actual == "" {
+					actual = "unknown"
+				}
+				mixedLabel := ""
+				if outcome.Mixed {
+					mixedLabel = " (HEURISTIC MIXED)"
+				}
+				fmt.Printf("%s on %s: heuristic %s, decided %s%s\n", outcome.TransactionID, outcome.Participant, outcome.Heuristic, actual, mixedLabel)
+			}
+		case "rate":
+			// Set an exchange rate on the leader, e.g. "rate USD EUR 0.92"
+			if currentType != "Coordinator" {
+				fmt.Println("Rate command is only available for coordinators.")
+				continue
+			}
+			if len(parts) < 2 {
+				fmt.Println("Usage: rate <from> <to> <rate>")
+				continue
+			}
+			args := strings.Fields(parts[1])
+			if len(args) != 3 {
+				fmt.Println("Usage: rate <from> <to> <rate>")
+				continue
+			}
+			rate, err := node.ParseDecimal(args[2])
+			if err != nil {
+				fmt.Printf("Error parsing rate: %v\n", err)
+				continue
+			}
+			var req = node.SetExchangeRateRequest{Rate: node.ExchangeRate{From: strings.ToUpper(args[0]), To: strings.ToUpper(args[1]), Rate: rate}}
+			var res node.SetExchangeRateResponse
+			if err := client.Call("Node.SetExchangeRate", &req, &res); err != nil {
+				fmt.Printf("Error setting rate: %v\n", err)
+				continue
+			}
+			fmt.Printf("Exchange rate %s/%s set to %s\n", req.Rate.From, req.Rate.To, rate)
 		case "delay":
 			if currentType != "Participant" {
 				fmt.Println("SimulateDelay command is only available for participants.")
@@ -556,3 +1469,44 @@ func startClient() {
 		}
 	}
 }
+
+func printSaga(saga node.SagaState) {
+	fmt.Printf("Saga %s: %s\n", saga.SagaID, saga.Status)
+	for i, step := range saga.Steps {
+		fmt.Printf("  %d: %s/%s %s %s - %s", i+1, step.Transaction.Name, step.Transaction.Account, step.Transaction.Operation, step.Transaction.Amount, step.Status)
+		if step.Error != "" {
+			fmt.Printf(" (%s)", step.Error)
+		}
+		fmt.Println()
+	}
+}
+
+// Split "participant/account" into its parts, account defaults to main
+// Client shorthand of every registered operation, e.g. "+50 add"
+func operationExamples() string {
+	var examples []string
+	for _, spec := range node.Operations() {
+		example := spec.Symbol + "10"
+		if spec.UsesExpected {
+			example = spec.Symbol + "5:10"
+		}
+		examples = append(examples, fmt.Sprintf("%s %s", example, spec.Type))
+	}
+	return strings.Join(examples, ", ")
+}
+
+// Time given as RFC 3339 or as a date in local time
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, time.Local)
+}
+
+func parseAccountAddress(address string) (string, string) {
+	name, account, found := strings.Cut(address, "/")
+	if !found || account == "" {
+		account = "main"
+	}
+	return name, account
+}
@@ -3,70 +3,266 @@ package node
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Transaction struct {
-	Addr      string
-	Name      string
-	Operation string
-	Amount    float64
+	Addr      string        `json:"addr"`
+	Name      string        `json:"name"`
+	Account   string        `json:"account"` // Account on the participant, main if empty
+	Operation OperationType `json:"operation"`
+	Amount    Decimal       `json:"amount"`
+	Expected  Decimal       `json:"expected"` // Balance compare-and-set expects to find
+	Currency  string        `json:"currency"` // Of Amount, the account's currency if empty
+	// Set by the coordinator when Amount was converted into the account's currency
+	Conversion *Conversion `json:"conversion"`
+	Hold       uuid.UUID   `json:"hold"` // Hold a subtract captures, nil if none
 }
 
 // RPC: Participant to Coordinator transaction request
 type ParticipantCoordinatorTransactionRequest struct {
-	Transactions []Transaction
+	Transactions []Transaction `json:"transactions"`
 }
 
-type ParticipantCoordinatorTransactionResponse struct{}
+type ParticipantCoordinatorTransactionResponse struct {
+	TransactionID uuid.UUID `json:"transactionId"`
+}
 
 func (n *Node) ParticipantCoordinatorTransaction(req *ParticipantCoordinatorTransactionRequest, res *ParticipantCoordinatorTransactionResponse) error {
+	if !n.isLeader() {
+		return n.notLeaderError()
+	}
+	n.c_auditMutex.RLock()
+	defer n.c_auditMutex.RUnlock()
+
+	for _, tx := range req.Transactions {
+		if err := ValidateTransaction(tx); err != nil {
+			return fmt.Errorf("invalid transaction for %s: %v", tx.Name, err)
+		}
+	}
+	// Converted before the prepare entry is replicated, so the rates used are kept with it
+	transactions, err := n.convertCurrencies(req.Transactions)
+	if err != nil {
+		return fmt.Errorf("transaction not started: %v", err)
+	}
+
+	// Work out which direct participant is responsible for each transaction
+	subtrees, order, err := n.groupBySubtree(transactions)
+	if err != nil {
+		return err
+	}
+	if err := n.checkFeatures(transactions, false); err != nil {
+		return fmt.Errorf("transaction not started: %v", err)
+	}
+	if err := n.checkLiveness(order); err != nil {
+		return fmt.Errorf("transaction not started: %v", err)
+	}
+
 	// Generate Transaction ID
 	transactionID := uuid.New()
 	n.Print(fmt.Sprintf("---Transaction ID: %s---", transactionID))
+	res.TransactionID = transactionID
 
+	// Replicate before contacting participants so a new leader knows to finish it
+	err = n.replicateDecision(DecisionEntry{Type: "PREPARE", TransactionID: transactionID, Transactions: transactions})
+	if err != nil {
+		return fmt.Errorf("transaction not started: %v", err)
+	}
 	n.LogTransaction("PREPARE", transactionID)
 	// Step 1: Prepare Phase
 	n.Print("---Prepare phase---")
+	deadline := time.Now().Add(prepareTimeout)
 	var combinedError string
-	for _, tx := range req.Transactions {
-		err := n.sendPrepare(tx.Name, tx.Amount, tx.Operation, transactionID, req.Transactions)
+	for _, name := range order {
+		err := n.sendPrepare(name, subtrees[name], transactionID, transactions, deadline)
 		if err != nil {
 			if combinedError != "" {
 				combinedError += "; "
 			}
-			combinedError += fmt.Sprintf("transaction aborted for %s: %v", tx.Name, err)
+			combinedError += fmt.Sprintf("transaction aborted for %s: %v", name, err)
 		}
 	}
 	if combinedError != "" {
-		n.LogTransaction("ABORT", transactionID)
-		for _, txRollback := range req.Transactions {
-			n.sendAbort(txRollback.Name, transactionID)
+		if err := n.replicateDecision(DecisionEntry{Type: "ABORT", TransactionID: transactionID}); err != nil {
+			return fmt.Errorf("%s; abort decision not replicated: %v", combinedError, err)
 		}
+		n.LogTransaction("ABORT", transactionID)
+		n.recordAcknowledgements(transactionID, n.sendDecision("ABORT", transactionID, order))
 		return errors.New(combinedError)
 	}
 
+	if err := n.replicateDecision(DecisionEntry{Type: "COMMIT", TransactionID: transactionID}); err != nil {
+		return fmt.Errorf("commit decision not replicated, transaction in doubt: %v", err)
+	}
 	n.LogTransaction("COMMIT", transactionID)
 	// Step 2: Commit Phase
 	n.Print("---Commit phase---")
-	for _, tx := range req.Transactions {
-		n.sendCommit(tx.Name, transactionID)
+	n.recordAcknowledgements(transactionID, n.sendDecision("COMMIT", transactionID, order))
+
+	return nil
+}
+
+// Leader resending decisions participants have not acknowledged
+const decisionResendInterval = 2 * time.Second
+
+// Send decision on transactionID to the direct participants in order,
+// returning those that did not acknowledge it
+func (n *Node) sendDecision(decision string, transactionID uuid.UUID, order []string) []string {
+	var unacknowledged []string
+	for _, name := range order {
+		var err error
+		if decision == "COMMIT" {
+			err = n.sendCommit(name, transactionID)
+		} else {
+			err = n.sendAbort(name, transactionID)
+		}
+		if err != nil {
+			unacknowledged = append(unacknowledged, name)
+		}
+	}
+	return unacknowledged
+}
+
+// Record in the log which direct participants have yet to acknowledge the
+// decision on transactionID, so only they are sent it again
+func (n *Node) recordAcknowledgements(transactionID uuid.UUID, unacknowledged []string) {
+	err := n.replicateDecision(DecisionEntry{Type: "ACK", TransactionID: transactionID, Unacknowledged: unacknowledged})
+	if err != nil {
+		n.Print(fmt.Sprintf("Error recording acknowledgements of %s: %v", transactionID, err))
+	}
+}
+
+// Where a transaction stands according to the decision log
+type transactionOutcome struct {
+	TransactionID  uuid.UUID
+	Transactions   []Transaction
+	Decision       string   // COMMIT or ABORT, empty while undecided
+	Acknowledged   bool     // Once the decision was sent
+	Unacknowledged []string // Direct participants the decision is still to reach
+}
+
+// Finished once every participant acknowledged its decision
+func (o *transactionOutcome) finished() bool {
+	return o.Decision != "" && o.Acknowledged && len(o.Unacknowledged) == 0
+}
+
+// Outcomes of the transactions prepared in entries, in the order they were
+func transactionOutcomes(entries []DecisionEntry) []*transactionOutcome {
+	byID := make(map[uuid.UUID]*transactionOutcome)
+	var outcomes []*transactionOutcome
+	for _, entry := range entries {
+		outcome, ok := byID[entry.TransactionID]
+		if entry.Type == "PREPARE" && !ok {
+			outcome = &transactionOutcome{TransactionID: entry.TransactionID, Transactions: entry.Transactions}
+			byID[entry.TransactionID] = outcome
+			outcomes = append(outcomes, outcome)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		switch entry.Type {
+		case "COMMIT", "ABORT":
+			outcome.Decision = entry.Type
+		case "ACK":
+			outcome.Acknowledged = true
+			outcome.Unacknowledged = entry.Unacknowledged
+		}
 	}
+	return outcomes
+}
+
+// Send committed decisions again to the participants that have not
+// acknowledged them, which ignore decisions they have already applied
+func (n *Node) resendDecisions() {
+	for _, outcome := range transactionOutcomes(n.committedEntries()) {
+		if outcome.Decision == "" || outcome.finished() {
+			continue
+		}
+		targets := outcome.Unacknowledged
+		if !outcome.Acknowledged {
+			var err error
+			if _, targets, err = n.groupBySubtree(outcome.Transactions); err != nil {
+				n.Print(fmt.Sprintf("Error resending decision for %s: %v", outcome.TransactionID, err))
+				targets = nil
+			}
+		}
+		// Nor can participants removed since
+		targets = slices.DeleteFunc(slices.Clone(targets), func(name string) bool {
+			_, ok := n.member(name)
+			return !ok
+		})
+		n.Print(fmt.Sprintf("Resending %s of %s to %v", outcome.Decision, outcome.TransactionID, targets))
+		unacknowledged := n.sendDecision(outcome.Decision, outcome.TransactionID, targets)
+		if !outcome.Acknowledged || !slices.Equal(unacknowledged, outcome.Unacknowledged) {
+			n.recordAcknowledgements(outcome.TransactionID, unacknowledged)
+		}
+	}
+}
 
+// RPC: Outcome of a transaction, as recorded in the replicated decision log
+type GetTransactionStatusRequest struct {
+	TransactionID uuid.UUID `json:"transactionId"`
+}
+
+type GetTransactionStatusResponse struct {
+	Status       string        `json:"status"` // PREPARE while undecided, then COMMIT or ABORT
+	Transactions []Transaction `json:"transactions"`
+}
+
+func (n *Node) GetTransactionStatus(req *GetTransactionStatusRequest, res *GetTransactionStatusResponse) error {
+	if n.Type != "Coordinator" {
+		return n.callCoordinator("Node.GetTransactionStatus", req, res)
+	}
+	// Followers may not have every decision yet
+	if !n.isLeader() {
+		return n.notLeaderError()
+	}
+	// A decision not yet committed may still be overturned by a new leader,
+	// so until it is the transaction is undecided
+	find := func(entries []DecisionEntry) {
+		for _, entry := range entries {
+			if entry.TransactionID != req.TransactionID {
+				continue
+			}
+			switch entry.Type {
+			case "PREPARE":
+				res.Status = entry.Type
+				res.Transactions = entry.Transactions
+			case "COMMIT", "ABORT":
+				res.Status = entry.Type
+			}
+		}
+	}
+	find(n.committedEntries())
+	if res.Status == "" {
+		find(n.logEntries())
+		if res.Status != "" {
+			res.Status = "PREPARE"
+		}
+	}
+	if res.Status == "" {
+		// Also once every participant acknowledged the decision and it was compacted away
+		return fmt.Errorf("transaction %s not found", req.TransactionID)
+	}
 	return nil
 }
 
 // Send Prepare/CanCommit? request
-func (n *Node) sendPrepare(name string, amount float64, operation string, transactionID uuid.UUID, transactions []Transaction) error {
+func (n *Node) sendPrepare(name string, subtree *subtreeTransactions, transactionID uuid.UUID, transactions []Transaction, deadline time.Time) error {
 	n.Print("Request: CanCommit?")
 
 	req := ReceivePrepareRequest{
 		Transactions:  transactions,
 		TransactionID: transactionID,
-		Amount:        amount,
-		Operation:     operation,
+		Delegated:     subtree.Delegated,
+		Deadline:      deadline,
+	}
+	for _, tx := range subtree.Own {
+		req.Operations = append(req.Operations, tx.resourceOperation())
 	}
 	var res ReceivePrepareResponse
 
@@ -74,8 +270,12 @@ func (n *Node) sendPrepare(name string, amount float64, operation string, transa
 	done := make(chan error, 1)
 
 	// Perform the RPC call in a goroutine
+	data, ok := n.member(name)
+	if !ok {
+		return fmt.Errorf("unknown participant %s", name)
+	}
 	go func() {
-		err := n.c_participantClients[name].Client.Call("Node.ReceivePrepare", &req, &res)
+		err := n.call(data.Address, "Node.ReceivePrepare", &req, &res)
 		done <- err
 	}()
 
@@ -91,35 +291,45 @@ func (n *Node) sendPrepare(name string, amount float64, operation string, transa
 			return errors.New("received invalid response")
 		}
 		return nil
-	case <-time.After(5 * time.Second):
+	case <-time.After(time.Until(deadline)):
 		return errors.New("transaction aborted due to timeout")
 	}
 }
 
-// Send DoCommit
-func (n *Node) sendCommit(name string, transactionID uuid.UUID) {
+// Send DoCommit, the error telling whether name acknowledged it
+func (n *Node) sendCommit(name string, transactionID uuid.UUID) error {
 	n.Print("Request: DoCommit")
-	client := n.c_participantClients[name].Client
+	data, ok := n.member(name)
+	if !ok {
+		n.Print(fmt.Sprintf("Error sending commit: unknown participant %s", name))
+		return fmt.Errorf("unknown participant %s", name)
+	}
 	var req ReceiveCommitRequest = ReceiveCommitRequest{
 		TransactionID: transactionID,
 	}
 	var res ReceiveCommitResponse
-	err := client.Call("Node.ReceiveCommit", &req, &res)
+	err := n.call(data.Address, "Node.ReceiveCommit", &req, &res)
 	if err != nil {
 		n.Print(fmt.Sprintf("Error sending commit: %v", err))
 	}
+	return err
 }
 
-// Send DoAbort
-func (n *Node) sendAbort(name string, transactionID uuid.UUID) {
+// Send DoAbort, the error telling whether name acknowledged it
+func (n *Node) sendAbort(name string, transactionID uuid.UUID) error {
 	n.Print("Request: DoAbort")
-	client := n.c_participantClients[name].Client
+	data, ok := n.member(name)
+	if !ok {
+		n.Print(fmt.Sprintf("Error aborting back: unknown participant %s", name))
+		return fmt.Errorf("unknown participant %s", name)
+	}
 	var req ReceiveAbortRequest = ReceiveAbortRequest{
 		TransactionID: transactionID,
 	}
 	var res ReceiveCommitResponse
-	err := client.Call("Node.ReceiveAbort", &req, &res)
+	err := n.call(data.Address, "Node.ReceiveAbort", &req, &res)
 	if err != nil {
 		n.Print(fmt.Sprintf("Error aborting back: %v", err))
 	}
+	return err
 }
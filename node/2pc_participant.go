@@ -2,9 +2,7 @@ package node
 
 import (
 	"bufio"
-	"errors"
 	"fmt"
-	"net/rpc"
 	"os"
 	"path/filepath"
 	"time"
@@ -14,9 +12,11 @@ import (
 
 // RPC: Client to Participant transaction request. Forward request to Coordinator.
 type ClientParticipantTransactionRequest struct {
-	Transactions []Transaction
+	Transactions []Transaction `json:"transactions"`
+}
+type ClientParticipantTransactionResponse struct {
+	TransactionID uuid.UUID `json:"transactionId"`
 }
-type ClientParticipantTransactionResponse struct{}
 
 func (n *Node) ClientParticipantTransaction(req *ClientParticipantTransactionRequest, res *ClientParticipantTransactionResponse) error {
 	n.Print("----Transaction Request Start----")
@@ -27,23 +27,25 @@ func (n *Node) ClientParticipantTransaction(req *ClientParticipantTransactionReq
 		Transactions: req.Transactions,
 	}
 	var coordRes ParticipantCoordinatorTransactionResponse
-	err := n.p_coordinatorClient.Call("Node.ParticipantCoordinatorTransaction", &coordReq, &coordRes)
+	err := n.callCoordinator("Node.ParticipantCoordinatorTransaction", &coordReq, &coordRes)
 	if err != nil {
 		return fmt.Errorf("coordinator error: %v", err)
 	}
+	res.TransactionID = coordRes.TransactionID
 	n.Print("----Transaction Request End----")
 	return nil
 }
 
 // RPC: Process received Prepare/CanCommit? request
 type ReceivePrepareRequest struct {
-	Transactions  []Transaction
-	TransactionID uuid.UUID
-	Amount        float64
-	Operation     string
+	Transactions  []Transaction       `json:"transactions"`
+	TransactionID uuid.UUID           `json:"transactionId"`
+	Operations    []ResourceOperation `json:"operations"` // Empty when only the subtree takes part
+	Delegated     []Transaction       `json:"delegated"`  // Transactions for participants below this one
+	Deadline      time.Time           `json:"deadline"`
 }
 type ReceivePrepareResponse struct {
-	Response string
+	Response string `json:"response"`
 }
 
 func (n *Node) ReceivePrepare(req *ReceivePrepareRequest, res *ReceivePrepareResponse) error {
@@ -55,51 +57,26 @@ func (n *Node) ReceivePrepare(req *ReceivePrepareRequest, res *ReceivePrepareRes
 	}
 	n.LogTransaction("Prepare", req.TransactionID)
 
-	// Mutex to protect n.promisedCommit bool
+	// Mutex to serialise votes and decisions
 	n.commitMutex.Lock()
 	defer n.commitMutex.Unlock()
-	if n.promisedCommit {
-		n.Print(fmt.Sprintf(colorRed + "Response: VoteAbort (already promised)" + colorReset))
-		res.Response = "VoteAbort"
-		n.LogTransaction("VoteAbort", req.TransactionID)
-		return errors.New("already promised")
-	}
-	n.promisedCommit = true
-	bal, err := n.getBalance()
-	if err != nil {
-		n.promisedCommit = false
-		n.Print(fmt.Sprintf(colorRed + "Response: VoteAbort (error getting balance)" + colorReset))
-		res.Response = "VoteAbort"
-		n.LogTransaction("VoteAbort", req.TransactionID)
-		if n.sleepAfterRespondingToCoordinator {
-			go func() {
-				n.Print("Simulating delay (after)")
-				n.rejectIncoming = true
-				time.Sleep(10 * time.Second)
-				n.rejectIncoming = false
-			}()
+	counterparts := n.counterparts(req.Transactions)
+	for i := range req.Operations {
+		req.Operations[i].Counterparts = counterparts
+	}
+	err := n.prepareOperations(req.TransactionID, req.Operations)
+	if err == nil && len(req.Delegated) > 0 {
+		if err = n.prepareSubtree(req); err != nil {
+			n.resource.Abort(req.TransactionID)
 		}
-		return err
-	}
-	var newBalance float64
-	switch req.Operation {
-	case "add":
-		newBalance = bal + req.Amount
-	case "multiply":
-		newBalance = bal * req.Amount
-	case "subtract":
-		newBalance = bal - req.Amount
-	}
-	if newBalance >= 0 {
+	}
+	if err == nil {
 		n.Print(fmt.Sprintf(colorGreen + "Response: VoteCommit" + colorReset))
-		n.transactionNewBalance = newBalance
 		res.Response = "VoteCommit"
 		n.LogTransaction("VoteCommit", req.TransactionID)
 
 		// Monitor log file to check for transaction completion
-
-		n.stopMonitoring = make(chan bool)
-		go n.monitorTransactionStatus(req.TransactionID, req.Transactions)
+		n.startMonitor(req.TransactionID, req.Transactions)
 
 		if n.sleepAfterRespondingToCoordinator {
 			go func() {
@@ -111,8 +88,7 @@ func (n *Node) ReceivePrepare(req *ReceivePrepareRequest, res *ReceivePrepareRes
 		}
 		return nil
 	}
-	n.promisedCommit = false
-	n.Print(fmt.Sprintf(colorRed + "Response: VoteAbort (insufficient balance)" + colorReset))
+	n.Print(fmt.Sprintf(colorRed+"Response: VoteAbort (%v)"+colorReset, err))
 	res.Response = "VoteAbort"
 	n.LogTransaction("VoteAbort", req.TransactionID)
 	if n.sleepAfterRespondingToCoordinator {
@@ -123,12 +99,39 @@ func (n *Node) ReceivePrepare(req *ReceivePrepareRequest, res *ReceivePrepareRes
 			n.rejectIncoming = false
 		}()
 	}
-	return errors.New("insufficient balance")
+	return err
+}
+
+// Prepare every operation of the transaction on the resource, or none of them
+func (n *Node) prepareOperations(transactionID uuid.UUID, operations []ResourceOperation) error {
+	if len(operations) == 0 {
+		operations = []ResourceOperation{{}}
+	}
+	for _, op := range operations {
+		if err := n.resource.Prepare(transactionID, op); err != nil {
+			n.resource.Abort(transactionID)
+			return err
+		}
+	}
+	return nil
+}
+
+// Names of the other participants in transactions
+func (n *Node) counterparts(transactions []Transaction) []string {
+	var names []string
+	seen := map[string]bool{n.Name: true}
+	for _, tx := range transactions {
+		if !seen[tx.Name] {
+			seen[tx.Name] = true
+			names = append(names, tx.Name)
+		}
+	}
+	return names
 }
 
 // RPC: Process received DoCommit request
 type ReceiveCommitRequest struct {
-	TransactionID uuid.UUID
+	TransactionID uuid.UUID `json:"transactionId"`
 }
 
 type ReceiveCommitResponse struct {
@@ -138,22 +141,37 @@ func (n *Node) ReceiveCommit(req *ReceiveCommitRequest, res *ReceiveCommitRespon
 	if n.rejectIncoming {
 		return fmt.Errorf("rejected, simulating crash")
 	}
-	n.commitMutex.Lock()
+	n.lockForDecision()
 	defer n.commitMutex.Unlock()
-	n.LogTransaction("COMMIT", req.TransactionID)
+	// Decisions may be resent by a newly elected coordinator
+	if !n.isPrepared(req.TransactionID) {
+		if heuristic, ok := n.heuristicDecision(req.TransactionID); ok {
+			n.stopMonitor(req.TransactionID)
+			n.resolveHeuristic(req.TransactionID, heuristic, "COMMIT")
+			n.finishSubtree(req.TransactionID, "COMMIT")
+			return nil
+		}
+		n.Print(fmt.Sprintf("Ignoring commit for %s, not promised", req.TransactionID))
+		// Unless committed before a restart that came before the subtree was told
+		n.finishSubtree(req.TransactionID, "COMMIT")
+		return nil
+	}
 	n.Print(fmt.Sprintf(colorGreen + "Committing" + colorReset))
-
-	err := n.WriteBalance(n.transactionNewBalance)
-	if err != nil {
-		fmt.Printf("Error writing balance: %v\n", err)
+	// Logged only once committed, the promise staying in the resource until
+	// then for the monitor and the coordinator's resends to retry
+	if err := n.resource.Commit(req.TransactionID); err != nil {
+		n.Print(fmt.Sprintf("Error committing %s: %v", req.TransactionID, err))
+		return fmt.Errorf("commit of %s failed: %v", req.TransactionID, err)
 	}
-	n.promisedCommit = false
+	n.LogTransaction("COMMIT", req.TransactionID)
+	n.stopMonitor(req.TransactionID)
+	n.finishSubtree(req.TransactionID, "COMMIT")
 	return nil
 }
 
 // RPC: Process received DoAbort request
 type ReceiveAbortRequest struct {
-	TransactionID uuid.UUID
+	TransactionID uuid.UUID `json:"transactionId"`
 }
 
 type ReceiveAbortResponse struct {
@@ -163,19 +181,34 @@ func (n *Node) ReceiveAbort(req *ReceiveAbortRequest, res *ReceiveAbortResponse)
 	if n.rejectIncoming {
 		return fmt.Errorf("rejected, simulating crash")
 	}
-	n.commitMutex.Lock()
+	n.lockForDecision()
 	defer n.commitMutex.Unlock()
-	n.LogTransaction("ABORT", req.TransactionID)
+	if !n.isPrepared(req.TransactionID) {
+		if heuristic, ok := n.heuristicDecision(req.TransactionID); ok {
+			n.stopMonitor(req.TransactionID)
+			n.resolveHeuristic(req.TransactionID, heuristic, "ABORT")
+			n.finishSubtree(req.TransactionID, "ABORT")
+			return nil
+		}
+		n.Print(fmt.Sprintf("Ignoring abort for %s, not promised", req.TransactionID))
+		n.finishSubtree(req.TransactionID, "ABORT")
+		return nil
+	}
 	n.Print(fmt.Sprintf(colorRed + "Aborting" + colorReset))
-	n.promisedCommit = false
-	n.stopMonitoring <- true
+	if err := n.resource.Abort(req.TransactionID); err != nil {
+		n.Print(fmt.Sprintf("Error aborting %s: %v", req.TransactionID, err))
+		return fmt.Errorf("abort of %s failed: %v", req.TransactionID, err)
+	}
+	n.LogTransaction("ABORT", req.TransactionID)
+	n.stopMonitor(req.TransactionID)
+	n.finishSubtree(req.TransactionID, "ABORT")
 	return nil
 }
 
 // TODO: Client rpc call
 type SimulateDelayRequest struct {
-	SleepBefore bool
-	SleepAfter  bool
+	SleepBefore bool `json:"sleepBefore"`
+	SleepAfter  bool `json:"sleepAfter"`
 }
 
 type SimulateDelayResponse struct{}
@@ -193,8 +226,8 @@ func (n *Node) SimulateDelay(req *SimulateDelayRequest, res *SimulateDelayRespon
 
 // RPC: Other participant doesn't have info, querying this node
 type P2PQueryTransactionStatusRequest struct {
-	RequesterAddr string
-	TransactionID uuid.UUID
+	RequesterAddr string    `json:"requesterAddr"`
+	TransactionID uuid.UUID `json:"transactionId"`
 }
 type P2PQueryTranactionStatusResponse struct {
 }
@@ -212,17 +245,13 @@ func (n *Node) P2PQueryTransactionStatus(req *P2PQueryTransactionStatusRequest,
 	}
 
 	n.Print(fmt.Sprintf("Transaction %s status: %s", req.TransactionID, status))
-	client, err := rpc.Dial("tcp", req.RequesterAddr)
-	if err != nil {
-		fmt.Printf("Error starting p2p rpc client: %v", err)
-		return fmt.Errorf("error starting p2p rpc client: %v", err)
-	}
+	requester := req.RequesterAddr
 	if status == "ABORT" {
 		var req ReceiveAbortRequest = ReceiveAbortRequest{
 			TransactionID: req.TransactionID,
 		}
 		var res ReceiveCommitResponse
-		err := client.Call("Node.ReceiveAbort", &req, &res)
+		err := n.call(requester, "Node.ReceiveAbort", &req, &res)
 		if err != nil {
 			return fmt.Errorf("error sending abort: %v", err)
 		}
@@ -231,7 +260,7 @@ func (n *Node) P2PQueryTransactionStatus(req *P2PQueryTransactionStatusRequest,
 			TransactionID: req.TransactionID,
 		}
 		var res ReceiveCommitResponse
-		err := client.Call("Node.ReceiveCommit", &req, &res)
+		err := n.call(requester, "Node.ReceiveCommit", &req, &res)
 		if err != nil {
 			n.Print(fmt.Sprintf("Error sending commit: %v", err))
 		}
@@ -241,7 +270,30 @@ func (n *Node) P2PQueryTransactionStatus(req *P2PQueryTransactionStatusRequest,
 	return nil
 }
 
-func (n *Node) monitorTransactionStatus(transactionID uuid.UUID, transactions []Transaction) {
+// Monitor transactionID until it is decided. Must hold commitMutex.
+func (n *Node) startMonitor(transactionID uuid.UUID, transactions []Transaction) {
+	if n.p_monitors == nil {
+		n.p_monitors = make(map[uuid.UUID]chan struct{})
+	}
+	if _, ok := n.p_monitors[transactionID]; ok {
+		return
+	}
+	stop := make(chan struct{})
+	n.p_monitors[transactionID] = stop
+	go n.monitorTransactionStatus(transactionID, transactions, stop)
+}
+
+// Stop monitoring transactionID once decided. Must hold commitMutex.
+func (n *Node) stopMonitor(transactionID uuid.UUID) {
+	if stop, ok := n.p_monitors[transactionID]; ok {
+		close(stop)
+		delete(n.p_monitors, transactionID)
+	}
+}
+
+// Learn the decision on transactionID from the coordinators, or from the
+// participants in transactions, which are looked up when unknown
+func (n *Node) monitorTransactionStatus(transactionID uuid.UUID, transactions []Transaction, stop <-chan struct{}) {
 	n.Print("Starting thread to monitor transaction status")
 	for {
 		if n.rejectIncoming {
@@ -251,50 +303,123 @@ func (n *Node) monitorTransactionStatus(transactionID uuid.UUID, transactions []
 		}
 		time.Sleep(500 * time.Millisecond) // Delay between queries
 		select {
-		case <-n.stopMonitoring:
+		case <-stop:
 			n.Print("Monitoring stopped")
 			return
 		default:
-			for _, transaction := range transactions {
-				// Don't query self
-				if transaction.Addr == n.Addr {
-					continue
-				}
-
-				// First, check the local log file
-				if status, found := n.checkLocalLogForStatus(transactionID); found {
-					n.Print(fmt.Sprintf("(check) Transaction %s status in local log: %s", transactionID, status))
-					return // Exit the function if status is found
-				}
-
-				// Query other participants if not found in local log
-				req := P2PQueryTransactionStatusRequest{TransactionID: transactionID, RequesterAddr: n.Addr}
-				var res P2PQueryTranactionStatusResponse
-
-				n.Print(fmt.Sprintf("Requesting info from participant %s", transaction.Name))
-				client, err := rpc.Dial("tcp", transaction.Addr)
-				if err != nil {
-					n.Print(fmt.Sprintf("Error dialing %s: %v", transaction.Name, err))
-					continue // Try the next participant
-				}
-
-				err = client.Call("Node.P2PQueryTransactionStatus", &req, &res)
-				client.Close() // Close the client after the call
-				if err != nil {
-					n.Print(fmt.Sprintf("Error querying transaction status from %s: %v", transaction.Name, err))
-					continue // Try the next participant
-				}
-
-				// Check if status has been updated in the local log
-				if updatedStatus, updated := n.checkLocalLogForStatus(transactionID); updated {
-					n.Print(fmt.Sprintf("Updated transaction %s status in local log: %s", transactionID, updatedStatus))
-					break // Break out of the inner loop if status is updated
-				}
-
-				time.Sleep(5 * time.Second) // Delay between queries
+		}
+
+		// First, whether the resource still holds the promise
+		if !n.awaitingDecision(transactionID) {
+			n.Print(fmt.Sprintf("(check) Transaction %s no longer in doubt", transactionID))
+			return
+		}
+
+		// Then the coordinators, which also name the participants when unknown
+		if n.learnDecision(transactionID, &transactions) {
+			continue
+		}
+
+		for _, transaction := range transactions {
+			// Don't query self
+			if transaction.Addr == n.Addr {
+				continue
 			}
+
+			// Query other participants if the coordinators do not know yet
+			req := P2PQueryTransactionStatusRequest{TransactionID: transactionID, RequesterAddr: n.Addr}
+			var res P2PQueryTranactionStatusResponse
+
+			n.Print(fmt.Sprintf("Requesting info from participant %s", transaction.Name))
+			err := n.call(transaction.Addr, "Node.P2PQueryTransactionStatus", &req, &res)
+			if err != nil {
+				n.Print(fmt.Sprintf("Error querying transaction status from %s: %v", transaction.Name, err))
+				continue // Try the next participant
+			}
+
+			// Check if the participant's answer decided it
+			if !n.awaitingDecision(transactionID) {
+				n.Print(fmt.Sprintf("Transaction %s decided through %s", transactionID, transaction.Name))
+				break
+			}
+		}
+		time.Sleep(5 * time.Second) // Delay between queries
+	}
+}
+
+// Apply the decision on transactionID if the coordinators have one, filling
+// in transactions when empty
+func (n *Node) learnDecision(transactionID uuid.UUID, transactions *[]Transaction) bool {
+	req := GetTransactionStatusRequest{TransactionID: transactionID}
+	var res GetTransactionStatusResponse
+	if err := n.callCoordinator("Node.GetTransactionStatus", &req, &res); err != nil {
+		n.Print(fmt.Sprintf("Error querying transaction status from coordinator: %v", err))
+		return false
+	}
+	if len(*transactions) == 0 {
+		*transactions = res.Transactions
+	}
+	var err error
+	switch res.Status {
+	case "COMMIT":
+		err = n.ReceiveCommit(&ReceiveCommitRequest{TransactionID: transactionID}, &ReceiveCommitResponse{})
+	case "ABORT":
+		err = n.ReceiveAbort(&ReceiveAbortRequest{TransactionID: transactionID}, &ReceiveAbortResponse{})
+	default:
+		return false
+	}
+	if err != nil {
+		n.Print(fmt.Sprintf("Error applying %s of %s: %v", res.Status, transactionID, err))
+		return false
+	}
+	n.Print(fmt.Sprintf("Learned %s of %s from coordinator", res.Status, transactionID))
+	return true
+}
+
+// Whether the resource holds a promise for transactionID
+func (n *Node) isPrepared(transactionID uuid.UUID) bool {
+	prepared, err := n.resource.Recover()
+	if err != nil {
+		n.Print(fmt.Sprintf("Error listing prepared transactions: %v", err))
+		return false
+	}
+	for _, id := range prepared {
+		if id == transactionID {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether transactionID is still prepared, or was decided heuristically and
+// the real decision is still to be learned
+func (n *Node) awaitingDecision(transactionID uuid.UUID) bool {
+	n.commitMutex.Lock()
+	defer n.commitMutex.Unlock()
+	if n.isPrepared(transactionID) {
+		return true
+	}
+	_, heuristic := n.heuristicDecision(transactionID)
+	return heuristic && !n.hasLogEntry(transactionID, "COMMIT") && !n.hasLogEntry(transactionID, "ABORT")
+}
+
+// Whether the local log has an entry for transactionID in phase
+func (n *Node) hasLogEntry(transactionID uuid.UUID, phase string) bool {
+	logFile := filepath.Join("node_log", fmt.Sprintf("%s-%s.log", n.Type, n.Name))
+	file, err := os.Open(logFile)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	entry := fmt.Sprintf("%s - %s", transactionID, phase)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if scanner.Text() == entry {
+			return true
 		}
 	}
+	return false
 }
 
 func (n *Node) checkLocalLogForStatus(transactionID uuid.UUID) (string, bool) {
@@ -0,0 +1,303 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// A participant with its own downstream participants acts as a sub-coordinator:
+// it forwards prepare to its subtree, votes once upward for the whole subtree and
+// passes the decision back down. The participants prepared below it are kept
+// with the decision until each acknowledged it, across restarts too, and the
+// decision is sent again to those that did not.
+
+const prepareTimeout = 5 * time.Second
+
+// Margin a sub-coordinator keeps for itself so its vote reaches the parent in time
+const subtreeVoteMargin = 500 * time.Millisecond
+
+// Transactions handled by one direct participant: its own and those of its descendants
+type subtreeTransactions struct {
+	Own       []Transaction
+	Delegated []Transaction
+}
+
+// Direct participants a transaction was prepared with through this node
+type subtreeState struct {
+	Pending  []string // Yet to acknowledge the decision
+	Decision string   // COMMIT or ABORT, empty until the coordinator's arrives
+}
+
+type RouteData struct {
+	Name     string
+	Address  string
+	Via      string // Direct participant the node is reached through
+	Version  int    // Protocol version and features the node announced
+	Features []string
+}
+
+// Group transactions by the direct participant responsible for them
+func (n *Node) groupBySubtree(transactions []Transaction) (map[string]*subtreeTransactions, []string, error) {
+	subtrees := make(map[string]*subtreeTransactions)
+	var order []string
+	add := func(name string) *subtreeTransactions {
+		if _, ok := subtrees[name]; !ok {
+			subtrees[name] = &subtreeTransactions{}
+			order = append(order, name)
+		}
+		return subtrees[name]
+	}
+	n.c_membershipMutex.RLock()
+	defer n.c_membershipMutex.RUnlock()
+	for _, tx := range transactions {
+		if _, ok := n.c_participantClients[tx.Name]; ok {
+			subtree := add(tx.Name)
+			subtree.Own = append(subtree.Own, tx)
+		} else if route, ok := n.c_routes[tx.Name]; ok {
+			subtree := add(route.Via)
+			subtree.Delegated = append(subtree.Delegated, tx)
+		} else {
+			return nil, nil, fmt.Errorf("unknown participant %s", tx.Name)
+		}
+	}
+	return subtrees, order, nil
+}
+
+// Forward prepare to the subtree below this participant, aborting the ones
+// that already voted if any of them refuses
+func (n *Node) prepareSubtree(req *ReceivePrepareRequest) error {
+	subtrees, order, err := n.groupBySubtree(req.Delegated)
+	if err != nil {
+		return err
+	}
+	if err := n.checkFeatures(req.Delegated, false); err != nil {
+		return err
+	}
+	if err := n.checkLiveness(order); err != nil {
+		return err
+	}
+	deadline := req.Deadline.Add(-subtreeVoteMargin)
+	if req.Deadline.IsZero() {
+		deadline = time.Now().Add(prepareTimeout)
+	}
+
+	// Recorded before any of them can promise, so none is forgotten
+	n.c_subtreesMutex.Lock()
+	n.c_subtrees[req.TransactionID] = &subtreeState{Pending: order}
+	err = n.persistSubtrees()
+	n.c_subtreesMutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	n.Print(fmt.Sprintf("Forwarding prepare to %d downstream participants", len(order)))
+	var prepareErr error
+	for _, name := range order {
+		if err := n.sendPrepare(name, subtrees[name], req.TransactionID, req.Transactions, deadline); err != nil {
+			prepareErr = fmt.Errorf("downstream %s: %v", name, err)
+			break
+		}
+	}
+	if prepareErr != nil {
+		// This node votes abort, so that is the decision
+		n.finishSubtree(req.TransactionID, "ABORT")
+		return prepareErr
+	}
+	return nil
+}
+
+// Pass the decision for transactionID down to the subtree in the background,
+// sending it again until every participant there acknowledged it. Not waited
+// for, as the caller may hold commitMutex and a participant below may be
+// holding decisions back for an audit.
+func (n *Node) finishSubtree(transactionID uuid.UUID, decision string) {
+	n.c_subtreesMutex.Lock()
+	subtree, ok := n.c_subtrees[transactionID]
+	if ok && subtree.Decision == "" {
+		subtree.Decision = decision
+		if err := n.persistSubtrees(); err != nil {
+			n.Print(fmt.Sprintf("Error recording decision for subtree of %s: %v", transactionID, err))
+		}
+	}
+	n.c_subtreesMutex.Unlock()
+	if ok {
+		go n.resendSubtreeDecision(transactionID)
+	}
+}
+
+// Send the subtree of transactionID the decision, reporting whether all
+// acknowledged it
+func (n *Node) forwardDecision(transactionID uuid.UUID) bool {
+	n.c_subtreesMutex.Lock()
+	subtree, ok := n.c_subtrees[transactionID]
+	if !ok {
+		n.c_subtreesMutex.Unlock()
+		return true
+	}
+	decision, pending := subtree.Decision, slices.Clone(subtree.Pending)
+	n.c_subtreesMutex.Unlock()
+
+	// Nor can participants removed since
+	pending = slices.DeleteFunc(pending, func(name string) bool {
+		_, ok := n.member(name)
+		return !ok
+	})
+	unacknowledged := n.sendDecision(decision, transactionID, pending)
+
+	n.c_subtreesMutex.Lock()
+	defer n.c_subtreesMutex.Unlock()
+	if len(unacknowledged) == 0 {
+		delete(n.c_subtrees, transactionID)
+	} else {
+		subtree.Pending = unacknowledged
+	}
+	if err := n.persistSubtrees(); err != nil {
+		n.Print(fmt.Sprintf("Error recording acknowledgements of %s: %v", transactionID, err))
+	}
+	return len(unacknowledged) == 0
+}
+
+func (n *Node) resendSubtreeDecision(transactionID uuid.UUID) {
+	for !n.forwardDecision(transactionID) {
+		time.Sleep(decisionResendInterval)
+	}
+}
+
+const subtreesKey = "subtrees"
+
+// Must hold c_subtreesMutex
+func (n *Node) persistSubtrees() error {
+	data, err := json.Marshal(n.c_subtrees)
+	if err != nil {
+		return err
+	}
+	return n.state.Put(subtreesKey, data)
+}
+
+// Restore the subtrees prepared before a restart, sending again the decisions
+// some participants have not acknowledged. The others wait for theirs.
+func (n *Node) loadSubtrees() {
+	data, ok, err := n.state.Get(subtreesKey)
+	if err != nil || !ok {
+		return
+	}
+	subtrees := make(map[uuid.UUID]*subtreeState)
+	if err := json.Unmarshal(data, &subtrees); err != nil {
+		n.Print(fmt.Sprintf("Error decoding subtrees: %v", err))
+		return
+	}
+	n.c_subtreesMutex.Lock()
+	n.c_subtrees = subtrees
+	n.c_subtreesMutex.Unlock()
+	for transactionID, subtree := range subtrees {
+		if subtree.Decision != "" {
+			go n.resendSubtreeDecision(transactionID)
+		}
+	}
+	if len(subtrees) > 0 {
+		n.Print(fmt.Sprintf("Restored %d subtrees awaiting acknowledgement", len(subtrees)))
+	}
+}
+
+// RPC: Make a participant below the sender reachable through it
+type AddRouteRequest struct {
+	Name     string   `json:"name"`
+	Addr     string   `json:"addr"`
+	Via      string   `json:"via"`
+	Version  int      `json:"version"` // Protocol version and features the participant announced
+	Features []string `json:"features"`
+	callerIdentity
+}
+
+type AddRouteResponse struct{}
+
+func (n *Node) AddRoute(req *AddRouteRequest, res *AddRouteResponse) error {
+	if err := req.checkCaller(req.Via); err != nil {
+		return err
+	}
+	n.c_membershipMutex.Lock()
+	if _, member := n.c_participantClients[req.Via]; !member {
+		n.c_membershipMutex.Unlock()
+		return fmt.Errorf("%s is not a direct participant", req.Via)
+	}
+	if _, direct := n.c_participantClients[req.Name]; direct {
+		n.c_membershipMutex.Unlock()
+		return fmt.Errorf("%s is already a direct participant", req.Name)
+	}
+	route := &RouteData{Name: req.Name, Address: req.Addr, Via: req.Via, Version: req.Version, Features: req.Features}
+	n.c_routes[req.Name] = route
+	n.persistMembership()
+	n.c_membershipMutex.Unlock()
+	n.Print(fmt.Sprintf("Added route to %s via %s", req.Name, req.Via))
+	if n.Type == "Participant" {
+		n.announceRoute(*route)
+	}
+	return nil
+}
+
+// Tell the parent coordinators that the participant of route is reachable
+// through this one
+func (n *Node) announceRoute(route RouteData) {
+	n.p_coordinatorMutex.Lock()
+	parents := n.p_coordinatorAddrs
+	protocols := maps.Clone(n.p_coordinatorProtocols)
+	n.p_coordinatorMutex.Unlock()
+	for _, parent := range parents {
+		if protocol, ok := protocols[parent]; ok && !supportsFeature(protocol.Version, protocol.Features, FeatureSubtrees) {
+			continue
+		}
+		var req = AddRouteRequest{Name: route.Name, Addr: route.Address, Via: n.Name, Version: route.Version, Features: route.Features}
+		var res AddRouteResponse
+		if err := n.call(parent, "Node.AddRoute", &req, &res); err != nil {
+			n.Print(fmt.Sprintf("Error announcing %s to %s: %v", route.Name, parent, err))
+		}
+	}
+}
+
+// RPC: Forget a participant the sender no longer reaches
+type RemoveRouteRequest struct {
+	Name string `json:"name"`
+	Via  string `json:"via"`
+	callerIdentity
+}
+
+type RemoveRouteResponse struct{}
+
+func (n *Node) RemoveRoute(req *RemoveRouteRequest, res *RemoveRouteResponse) error {
+	if err := req.checkCaller(req.Via); err != nil {
+		return err
+	}
+	n.c_membershipMutex.Lock()
+	route, ok := n.c_routes[req.Name]
+	if !ok || route.Via != req.Via {
+		n.c_membershipMutex.Unlock()
+		return nil
+	}
+	delete(n.c_routes, req.Name)
+	n.persistMembership()
+	n.c_membershipMutex.Unlock()
+	n.Print(fmt.Sprintf("Removed route to %s via %s", req.Name, req.Via))
+	if n.Type == "Participant" {
+		n.withdrawRoute(req.Name)
+	}
+	return nil
+}
+
+// Tell the parent coordinators that name is no longer reachable through this participant
+func (n *Node) withdrawRoute(name string) {
+	n.p_coordinatorMutex.Lock()
+	parents := n.p_coordinatorAddrs
+	n.p_coordinatorMutex.Unlock()
+	for _, parent := range parents {
+		var req = RemoveRouteRequest{Name: name, Via: n.Name}
+		var res RemoveRouteResponse
+		if err := n.call(parent, "Node.RemoveRoute", &req, &res); err != nil {
+			n.Print(fmt.Sprintf("Error withdrawing %s from %s: %v", name, parent, err))
+		}
+	}
+}
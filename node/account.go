@@ -0,0 +1,162 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Accounts are opened, may be frozen and unfrozen, and are finally closed. A
+// frozen account takes credits but no debits, a closed one takes nothing.
+// Each change is written to the ledger before it is applied, and the last one
+// for each account is applied again on startup.
+
+type AccountStatus string
+
+const (
+	AccountOpen   AccountStatus = "open"
+	AccountFrozen AccountStatus = "frozen"
+	AccountClosed AccountStatus = "closed"
+)
+
+// Ledger operations recording lifecycle changes. They are not registered
+// operations, so transactions cannot use them.
+const (
+	OpOpen     OperationType = "open"
+	OpFreeze   OperationType = "freeze"
+	OpUnfreeze OperationType = "unfreeze"
+	OpClose    OperationType = "close"
+)
+
+// Reasons a participant votes to abort because of an account's status
+const (
+	ReasonAccountFrozen = "ACCOUNT_FROZEN"
+	ReasonAccountClosed = "ACCOUNT_CLOSED"
+)
+
+// Details of an account besides its balance
+type AccountDetails struct {
+	Currency string        `json:"currency"`
+	Owner    string        `json:"owner"`
+	Status   AccountStatus `json:"status"`
+}
+
+// Details of accounts that predate them
+var defaultAccountDetails = AccountDetails{Currency: defaultCurrency, Status: AccountOpen}
+
+// Check a change of account from before to after is allowed in its status
+func (d AccountDetails) check(account string, before Decimal, after Decimal) error {
+	switch {
+	case d.Status == AccountClosed:
+		return &PolicyViolation{ReasonAccountClosed, fmt.Sprintf("account %s is closed", account)}
+	case d.Status == AccountFrozen && after.Cmp(before) < 0:
+		return &PolicyViolation{ReasonAccountFrozen, fmt.Sprintf("account %s is frozen", account)}
+	}
+	return nil
+}
+
+// Status an account moves to with a lifecycle operation, from the statuses it may be in
+func statusTransition(operation OperationType, from AccountStatus) (AccountStatus, error) {
+	var to AccountStatus
+	var allowed bool
+	switch operation {
+	case OpFreeze:
+		to, allowed = AccountFrozen, from == AccountOpen
+	case OpUnfreeze:
+		to, allowed = AccountOpen, from == AccountFrozen
+	case OpClose:
+		to, allowed = AccountClosed, from != AccountClosed
+	default:
+		return "", fmt.Errorf("unknown account change %q", operation)
+	}
+	if !allowed {
+		return "", fmt.Errorf("cannot %s an account that is %s", operation, from)
+	}
+	return to, nil
+}
+
+// Ledger entry recording a lifecycle change, details being those after it
+func lifecycleEntry(account string, operation OperationType, before Decimal, after Decimal, details AccountDetails) LedgerEntry {
+	amount := NewDecimal(0, after.Scale)
+	if operation == OpOpen {
+		amount = after
+	}
+	return LedgerEntry{
+		EntryID:       uuid.New(),
+		TransactionID: uuid.New(),
+		Account:       account,
+		Operation:     operation,
+		Amount:        amount,
+		Before:        before,
+		After:         after,
+		External:      operation == OpOpen, // The initial balance enters the cluster
+		Currency:      details.Currency,
+		Details:       &details,
+		Time:          time.Now(),
+	}
+}
+
+// RPC: Open an account on this participant
+type OpenAccountRequest struct {
+	Account        string  `json:"account"`
+	Currency       string  `json:"currency"` // Default currency when empty
+	Scale          int     `json:"scale"`    // Decimal places of the account's currency
+	Owner          string  `json:"owner"`
+	InitialBalance Decimal `json:"initialBalance"`
+}
+
+type OpenAccountResponse struct{}
+
+func (n *Node) OpenAccount(req *OpenAccountRequest, res *OpenAccountResponse) error {
+	accounts, err := n.accountManager()
+	if err != nil {
+		return err
+	}
+	details := AccountDetails{Currency: req.Currency, Owner: req.Owner, Status: AccountOpen}
+	if details.Currency == "" {
+		details.Currency = defaultCurrency
+	}
+	if err := accounts.OpenAccount(req.Account, details, req.Scale, req.InitialBalance); err != nil {
+		return err
+	}
+	n.Print(fmt.Sprintf("Opened %s account %s for %q with %s", details.Currency, req.Account, req.Owner, req.InitialBalance))
+	return nil
+}
+
+// RPC: Freeze, unfreeze or close an account on this participant
+type ChangeAccountRequest struct {
+	Account   string        `json:"account"`
+	Operation OperationType `json:"operation"` // OpFreeze, OpUnfreeze or OpClose
+}
+
+type ChangeAccountResponse struct {
+	Status AccountStatus `json:"status"`
+}
+
+func (n *Node) ChangeAccount(req *ChangeAccountRequest, res *ChangeAccountResponse) error {
+	accounts, err := n.accountManager()
+	if err != nil {
+		return err
+	}
+	account := accountOrDefault(req.Account)
+	status, err := accounts.ChangeAccount(account, req.Operation)
+	if err != nil {
+		n.Print(fmt.Sprintf(colorRed+"Cannot %s %s: %v"+colorReset, req.Operation, account, err))
+		return err
+	}
+	res.Status = status
+	n.Print(fmt.Sprintf("Account %s is now %s", account, status))
+	return nil
+}
+
+func (n *Node) accountManager() (AccountManager, error) {
+	if n.Type != "Participant" {
+		return nil, fmt.Errorf("this node is not a participant")
+	}
+	accounts, ok := n.resource.(AccountManager)
+	if !ok {
+		return nil, fmt.Errorf("resource does not support multiple accounts")
+	}
+	return accounts, nil
+}
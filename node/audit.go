@@ -0,0 +1,421 @@
+package node
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The auditor checks that money is conserved. With new transactions held off
+// on the leader it snapshots every participant's balances and ledger, then
+// compares the total with the baseline plus deposits and minus withdrawals made
+// since, pointing out the transactions behind any difference. Participants
+// also hold back decisions resent, learned or forwarded to them until every
+// snapshot is taken, so together the snapshots are of a single moment.
+
+type ResourceSnapshot struct {
+	Balances   map[string]Decimal `json:"balances"`
+	Currencies map[string]string  `json:"currencies"` // Currency of each account
+	Ledger     []LedgerEntry      `json:"ledger"`
+	InDoubt    []uuid.UUID        `json:"inDoubt"` // Prepared, decision not applied yet
+}
+
+// Optional interface for resources that can be audited
+type Auditable interface {
+	// Balances, ledger and prepared transactions as of one moment
+	Snapshot() (ResourceSnapshot, error)
+}
+
+type AuditIssue struct {
+	TransactionID uuid.UUID `json:"transactionId"` // Nil when not about a single transaction
+	Participant   string    `json:"participant"`
+	Account       string    `json:"account"`
+	Description   string    `json:"description"`
+}
+
+// Totals of one currency. Conversions move money between currencies, so each
+// is only conserved once exchanged amounts are counted.
+type CurrencyTotals struct {
+	Currency      string  `json:"currency"`
+	BaselineTotal Decimal `json:"baselineTotal"`
+	Deposits      Decimal `json:"deposits"`
+	Withdrawals   Decimal `json:"withdrawals"`
+	Exchanged     Decimal `json:"exchanged"`     // Converted into this currency, negative when converted out
+	ExpectedTotal Decimal `json:"expectedTotal"` // Baseline plus deposits, minus withdrawals, plus exchanged
+	ActualTotal   Decimal `json:"actualTotal"`
+	Discrepancy   Decimal `json:"discrepancy"` // Actual minus expected
+}
+
+type AuditReport struct {
+	Baseline     time.Time        `json:"baseline"` // Zero when auditing from the beginning
+	Cut          time.Time        `json:"cut"`
+	Participants []string         `json:"participants"`
+	Totals       []CurrencyTotals `json:"totals"` // One per currency, by code
+	Issues       []AuditIssue     `json:"issues"`
+	Consistent   bool             `json:"consistent"`
+}
+
+// RPC: Snapshot of this participant for an audit
+type GetAuditSnapshotRequest struct{}
+
+type GetAuditSnapshotResponse struct {
+	Snapshot ResourceSnapshot `json:"snapshot"`
+}
+
+func (n *Node) GetAuditSnapshot(req *GetAuditSnapshotRequest, res *GetAuditSnapshotResponse) error {
+	if n.Type != "Participant" {
+		return fmt.Errorf("this node is not a participant")
+	}
+	auditable, ok := n.resource.(Auditable)
+	if !ok {
+		return fmt.Errorf("resource cannot be audited")
+	}
+	// Not in the middle of applying a decision
+	n.commitMutex.Lock()
+	defer n.commitMutex.Unlock()
+	snapshot, err := auditable.Snapshot()
+	if err != nil {
+		return err
+	}
+	res.Snapshot = snapshot
+	return nil
+}
+
+// Held decisions are let through after this even if the audit never ends
+const auditHoldTimeout = 10 * time.Second
+
+// RPC: Hold back decisions until released, for an audit's snapshots
+type HoldDecisionsRequest struct {
+	Release bool `json:"release"`
+}
+
+type HoldDecisionsResponse struct{}
+
+func (n *Node) HoldDecisions(req *HoldDecisionsRequest, res *HoldDecisionsResponse) error {
+	if n.Type != "Participant" {
+		return fmt.Errorf("this node is not a participant")
+	}
+	n.commitMutex.Lock()
+	defer n.commitMutex.Unlock()
+	if req.Release {
+		if n.p_decisionsHeld == nil {
+			return fmt.Errorf("decisions were let through after %s, before the audit finished", auditHoldTimeout)
+		}
+		close(n.p_decisionsHeld)
+		n.p_decisionsHeld = nil
+		return nil
+	}
+	if n.p_decisionsHeld != nil {
+		return nil
+	}
+	held := make(chan struct{})
+	n.p_decisionsHeld = held
+	time.AfterFunc(auditHoldTimeout, func() {
+		n.commitMutex.Lock()
+		defer n.commitMutex.Unlock()
+		if n.p_decisionsHeld == held {
+			close(held)
+			n.p_decisionsHeld = nil
+			n.Print("Audit did not release decisions, letting them through")
+		}
+	})
+	return nil
+}
+
+// Lock commitMutex to apply a decision, waiting while an audit holds them back
+func (n *Node) lockForDecision() {
+	for {
+		n.commitMutex.Lock()
+		held := n.p_decisionsHeld
+		if held == nil {
+			return
+		}
+		n.commitMutex.Unlock()
+		<-held
+	}
+}
+
+// RPC: Audit money conservation across all participants
+type AuditRequest struct {
+	Since time.Time `json:"since"` // Baseline, zero to audit from the beginning
+}
+
+type AuditResponse struct {
+	Report AuditReport `json:"report"`
+}
+
+func (n *Node) Audit(req *AuditRequest, res *AuditResponse) error {
+	if n.Type != "Coordinator" {
+		return n.callCoordinator("Node.Audit", req, res)
+	}
+	if !n.isLeader() {
+		return n.notLeaderError()
+	}
+
+	// Wait for running transactions and hold off new ones so the snapshots form a consistent cut
+	n.c_auditMutex.Lock()
+	snapshots, err := n.collectSnapshots()
+	cut := time.Now()
+	n.c_auditMutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("audit failed: %v", err)
+	}
+
+	res.Report = buildAuditReport(req.Since, cut, snapshots)
+	for _, totals := range res.Report.Totals {
+		if totals.Discrepancy.IsZero() {
+			n.Print(fmt.Sprintf(colorGreen+"Audit: total %s %s as expected"+colorReset, totals.ActualTotal, totals.Currency))
+		} else {
+			n.Print(fmt.Sprintf(colorRed+"Audit: total %s %s, expected %s"+colorReset, totals.ActualTotal, totals.Currency, totals.ExpectedTotal))
+		}
+	}
+	if !res.Report.Consistent {
+		n.Print(fmt.Sprintf(colorRed+"Audit: %d issues"+colorReset, len(res.Report.Issues)))
+	}
+	return nil
+}
+
+// Snapshot every participant, direct or below a sub-coordinator. Each is
+// made to hold back decisions before the first snapshot, so none is applied
+// from the last hold until they are released.
+func (n *Node) collectSnapshots() (snapshots map[string]ResourceSnapshot, err error) {
+	addrs := make(map[string]string)
+	holds := make(map[string]bool)
+	n.c_membershipMutex.RLock()
+	for name, data := range n.c_participantClients {
+		addrs[name] = data.Address
+		holds[name] = supportsFeature(data.Version, data.Features, FeatureAuditHold)
+	}
+	for name, route := range n.c_routes {
+		addrs[name] = route.Address
+		holds[name] = supportsFeature(route.Version, route.Features, FeatureAuditHold)
+	}
+	n.c_membershipMutex.RUnlock()
+
+	var held []string
+	defer func() {
+		for _, name := range held {
+			releaseErr := n.call(addrs[name], "Node.HoldDecisions", &HoldDecisionsRequest{Release: true}, &HoldDecisionsResponse{})
+			if releaseErr != nil && err == nil {
+				snapshots, err = nil, fmt.Errorf("releasing %s: %v", name, releaseErr)
+			}
+		}
+	}()
+	for name, addr := range addrs {
+		if !holds[name] {
+			n.Print(fmt.Sprintf("Audit: %s cannot hold decisions back, its snapshot may not be of the same moment", name))
+			continue
+		}
+		err := n.call(addr, "Node.HoldDecisions", &HoldDecisionsRequest{}, &HoldDecisionsResponse{})
+		if notSent(err) {
+			return nil, fmt.Errorf("participant %s unreachable: %v", name, err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("holding decisions on %s: %v", name, err)
+		}
+		held = append(held, name)
+	}
+
+	snapshots = make(map[string]ResourceSnapshot)
+	for name, addr := range addrs {
+		var res GetAuditSnapshotResponse
+		err := n.call(addr, "Node.GetAuditSnapshot", &GetAuditSnapshotRequest{}, &res)
+		if notSent(err) {
+			return nil, fmt.Errorf("participant %s unreachable: %v", name, err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("snapshot of %s: %v", name, err)
+		}
+		snapshots[name] = res.Snapshot
+	}
+	return snapshots, nil
+}
+
+// Effect of one transaction across the cluster
+type auditedTransaction struct {
+	net          map[string]Decimal // By currency
+	operations   []string
+	participants map[string]bool
+	counterparts map[string]bool
+}
+
+func buildAuditReport(since time.Time, cut time.Time, snapshots map[string]ResourceSnapshot) AuditReport {
+	zero := NewDecimal(0, 0)
+	report := AuditReport{Baseline: since, Cut: cut}
+	issue := func(transactionID uuid.UUID, participant string, account string, format string, args ...any) {
+		report.Issues = append(report.Issues, AuditIssue{transactionID, participant, account, fmt.Sprintf(format, args...)})
+	}
+	add := func(total *Decimal, amount Decimal) {
+		sum, err := total.Add(amount)
+		if err != nil {
+			issue(uuid.Nil, "", "", "total out of range")
+			return
+		}
+		*total = sum
+	}
+	totals := make(map[string]*CurrencyTotals)
+	totalsOf := func(currency string) *CurrencyTotals {
+		if _, ok := totals[currency]; !ok {
+			totals[currency] = &CurrencyTotals{Currency: currency, BaselineTotal: zero, Deposits: zero, Withdrawals: zero, Exchanged: zero, ActualTotal: zero}
+		}
+		return totals[currency]
+	}
+	addNet := func(tx *auditedTransaction, currency string, amount Decimal) {
+		net, ok := tx.net[currency]
+		if !ok {
+			net = zero
+		}
+		add(&net, amount)
+		tx.net[currency] = net
+	}
+
+	transactions := make(map[uuid.UUID]*auditedTransaction)
+	var order []uuid.UUID
+	for name := range snapshots {
+		report.Participants = append(report.Participants, name)
+	}
+	sort.Strings(report.Participants)
+
+	for _, name := range report.Participants {
+		snapshot := snapshots[name]
+		ledgers := make(map[string][]LedgerEntry)
+		for _, entry := range snapshot.Ledger {
+			ledgers[entry.Account] = append(ledgers[entry.Account], entry)
+			if _, ok := snapshot.Balances[entry.Account]; !ok && len(ledgers[entry.Account]) == 1 {
+				issue(entry.TransactionID, name, entry.Account, "ledger has entries for an account that does not exist")
+			}
+		}
+		accounts := make([]string, 0, len(snapshot.Balances))
+		for account := range snapshot.Balances {
+			accounts = append(accounts, account)
+		}
+		sort.Strings(accounts)
+
+		for _, account := range accounts {
+			balance := snapshot.Balances[account]
+			currency := snapshot.Currencies[account]
+			if currency == "" {
+				currency = defaultCurrency
+			}
+			total := totalsOf(currency)
+			// Accounts start empty, each entry must pick up where the previous one left off
+			previous := zero
+			baseline, baselineFound := balance, false
+			for _, entry := range ledgers[account] {
+				if entry.Before.Cmp(previous) != 0 {
+					issue(entry.TransactionID, name, account, "entry starts from %s but the ledger was at %s", entry.Before, previous)
+				}
+				previous = entry.After
+				if entry.Currency != "" && entry.Currency != currency {
+					issue(entry.TransactionID, name, account, "entry is in %s but the account holds %s", entry.Currency, currency)
+				}
+				if entry.Time.Before(since) {
+					continue
+				}
+				if !baselineFound {
+					baseline, baselineFound = entry.Before, true
+				}
+				change, err := entry.After.Sub(entry.Before)
+				if err != nil {
+					issue(entry.TransactionID, name, account, "change out of range")
+					continue
+				}
+				if entry.Details != nil && change.IsZero() {
+					// Lifecycle change moving no money
+					continue
+				}
+				if entry.External {
+					if change.Sign() > 0 {
+						add(&total.Deposits, change)
+					} else {
+						add(&total.Withdrawals, change.Neg())
+					}
+					continue
+				}
+				tx, ok := transactions[entry.TransactionID]
+				if !ok {
+					tx = &auditedTransaction{net: make(map[string]Decimal), participants: make(map[string]bool), counterparts: make(map[string]bool)}
+					transactions[entry.TransactionID] = tx
+					order = append(order, entry.TransactionID)
+				}
+				if original, ok := exchangedAmount(entry); ok {
+					// Counted in the currency it was given in, the difference was exchanged
+					add(&total.Exchanged, change)
+					add(&totalsOf(entry.Conversion.From).Exchanged, original.Neg())
+					addNet(tx, entry.Conversion.From, original)
+				} else {
+					addNet(tx, currency, change)
+				}
+				tx.operations = append(tx.operations, fmt.Sprintf("%s/%s %s %s", name, account, entry.Operation, entry.Amount))
+				tx.participants[name] = true
+				for _, counterpart := range entry.Counterparts {
+					tx.counterparts[counterpart] = true
+				}
+			}
+			if previous.Cmp(balance) != 0 {
+				issue(uuid.Nil, name, account, "balance %s does not match the ledger's %s", balance, previous)
+			}
+			add(&total.BaselineTotal, baseline)
+			add(&total.ActualTotal, balance)
+		}
+		for _, transactionID := range snapshot.InDoubt {
+			issue(transactionID, name, "", "prepared but the decision has not been applied")
+		}
+	}
+
+	for _, transactionID := range order {
+		tx := transactions[transactionID]
+		var counterparts []string
+		for counterpart := range tx.counterparts {
+			counterparts = append(counterparts, counterpart)
+		}
+		sort.Strings(counterparts)
+		for _, counterpart := range counterparts {
+			if tx.participants[counterpart] {
+				continue
+			}
+			if _, audited := snapshots[counterpart]; !audited {
+				issue(transactionID, counterpart, "", "took part but is not known to the coordinator")
+			} else {
+				issue(transactionID, counterpart, "", "took part but has no ledger entry, the transaction was only partly committed")
+			}
+		}
+		for _, currency := range sortedKeys(tx.net) {
+			if net := tx.net[currency]; !net.IsZero() {
+				issue(transactionID, "", "", "changed the total by %s %s (%s)", net, currency, strings.Join(tx.operations, ", "))
+			}
+		}
+	}
+
+	report.Consistent = len(report.Issues) == 0
+	for _, currency := range sortedKeys(totals) {
+		total := totals[currency]
+		total.ExpectedTotal = total.BaselineTotal
+		add(&total.ExpectedTotal, total.Deposits)
+		add(&total.ExpectedTotal, total.Withdrawals.Neg())
+		add(&total.ExpectedTotal, total.Exchanged)
+		total.Discrepancy = total.ActualTotal
+		add(&total.Discrepancy, total.ExpectedTotal.Neg())
+		report.Consistent = report.Consistent && total.Discrepancy.IsZero()
+		report.Totals = append(report.Totals, *total)
+	}
+	return report
+}
+
+// Amount a converted add or subtract moved, signed and in the currency it was
+// given in. Other operations set balances rather than move money.
+func exchangedAmount(entry LedgerEntry) (Decimal, bool) {
+	if entry.Conversion == nil || entry.Conversion.From == entry.Conversion.To {
+		return Decimal{}, false
+	}
+	switch entry.Operation {
+	case OpAdd:
+		return entry.Conversion.Original, true
+	case OpSubtract:
+		return entry.Conversion.Original.Neg(), true
+	}
+	return Decimal{}, false
+}
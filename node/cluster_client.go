@@ -0,0 +1,293 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Programs outside the cluster, such as the client, call an entry node of
+// their choosing through a ClusterClient. It keeps the cluster's member list
+// and the entry node's name, so a restarted entry node is reached again, at
+// its new address if it moved. While the entry node stays down, requests any
+// participant serves alike, such as transactions, are submitted through
+// another one. Transactions name the participants they apply to, so the
+// session keeps acting as the entry node.
+
+// Trying the entry node again before failing over
+const clusterReconnectFor = 3 * time.Second
+
+// Served by every participant alike, forwarded to the coordinators
+var failoverMethods = map[string]bool{
+	"Node.ClientParticipantTransaction": true,
+	"Node.ClientParticipantSaga":        true,
+	"Node.GetTransactionStatus":         true,
+	"Node.GetSagaStatus":                true,
+	"Node.ListParticipants":             true,
+	"Node.ListNodes":                    true,
+	"Node.GetLeader":                    true,
+	"Node.Audit":                        true,
+}
+
+type ClusterClient struct {
+	Log func(message string) // Told of reconnections and failovers when set
+
+	transport Transport
+	seeds     []string // Addresses first listed from
+
+	mutex  sync.Mutex
+	nodes  []NodeInfo
+	entry  NodeInfo
+	client Client // To the entry node, nil while disconnected
+	down   bool   // Entry node not reached again since calls failed over
+}
+
+// Client of the cluster the nodes at addrs belong to, listing its nodes
+// from the first one that answers
+func DialCluster(transport string, creds *Credentials, addrs ...string) (*ClusterClient, error) {
+	t, err := NewTransport(transport, creds)
+	if err != nil {
+		return nil, err
+	}
+	c := &ClusterClient{transport: t, seeds: addrs}
+	if err := c.Refresh(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Nodes of the cluster, as last listed
+func (c *ClusterClient) Nodes() []NodeInfo {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]NodeInfo(nil), c.nodes...)
+}
+
+// Node calls go to, zero until connected
+func (c *ClusterClient) Entry() NodeInfo {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.entry
+}
+
+// List the cluster's nodes again, asking the entry node, the nodes listed
+// before and the addresses dialled with in turn
+func (c *ClusterClient) Refresh() error {
+	c.mutex.Lock()
+	addrs := []string{c.entry.Addr}
+	for _, node := range c.nodes {
+		addrs = append(addrs, node.Addr)
+	}
+	addrs = append(addrs, c.seeds...)
+	c.mutex.Unlock()
+
+	err := fmt.Errorf("no nodes to list the cluster from")
+	tried := make(map[string]bool)
+	for _, addr := range addrs {
+		if addr == "" || tried[addr] {
+			continue
+		}
+		tried[addr] = true
+		var nodes []NodeInfo
+		if nodes, err = c.listNodes(addr); err == nil {
+			c.mutex.Lock()
+			c.nodes = nodes
+			c.mutex.Unlock()
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot list nodes: %v", err)
+}
+
+func (c *ClusterClient) listNodes(addr string) ([]NodeInfo, error) {
+	client, err := c.transport.Dial(addr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	defer client.Close()
+	var res ListNodesResponse
+	if err := client.Call("Node.ListNodes", &ListNodesRequest{}, &res); err != nil {
+		return nil, err
+	}
+	return res.Nodes, nil
+}
+
+// Make the node at addr the entry node
+func (c *ClusterClient) Connect(addr string) (NodeInfo, error) {
+	return c.connect(addr, "")
+}
+
+// Connect to the node at addr, which must be name unless it is empty
+func (c *ClusterClient) connect(addr string, name string) (NodeInfo, error) {
+	client, err := c.transport.DialNode(addr, name)
+	if err != nil {
+		return NodeInfo{}, fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	var info GetInfoResponse
+	if err := client.Call("Node.GetInfo", &GetInfoRequest{}, &info); err != nil {
+		client.Close()
+		return NodeInfo{}, err
+	}
+	if name != "" && info.Name != name {
+		client.Close()
+		return NodeInfo{}, fmt.Errorf("%w: %s is now %s, not %s", ErrUnreachable, addr, info.Name, name)
+	}
+	entry := NodeInfo{Name: info.Name, Addr: info.Addr, Type: info.Type}
+	c.mutex.Lock()
+	if c.client != nil {
+		c.client.Close()
+	}
+	c.client = client
+	c.entry = entry
+	c.mutex.Unlock()
+	return entry, nil
+}
+
+// Connect to the entry node again, looking up its address in case it moved
+func (c *ClusterClient) reconnect() error {
+	entry := c.Entry()
+	_, err := c.connect(entry.Addr, entry.Name)
+	if err == nil {
+		return nil
+	}
+	if c.Refresh() != nil {
+		return err
+	}
+	for _, node := range c.Nodes() {
+		if node.Name == entry.Name && node.Addr != entry.Addr {
+			_, err = c.connect(node.Addr, node.Name)
+			break
+		}
+	}
+	return err
+}
+
+// Close the connection to the entry node if it is still client
+func (c *ClusterClient) disconnect(client Client) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.client == client {
+		c.client.Close()
+		c.client = nil
+	}
+}
+
+func (c *ClusterClient) Call(method string, req any, res any) error {
+	return c.callWith(method, func(client Client) error {
+		return client.Call(method, req, res)
+	})
+}
+
+func (c *ClusterClient) CallTimeout(method string, req any, res any, timeout time.Duration) error {
+	return c.callWith(method, func(client Client) error {
+		return client.CallTimeout(method, req, res, timeout)
+	})
+}
+
+func (c *ClusterClient) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.client == nil {
+		return nil
+	}
+	err := c.client.Close()
+	c.client = nil
+	return err
+}
+
+// Call the entry node, reconnecting to it when the call fails, and failing
+// over to another participant if it stays unreachable
+func (c *ClusterClient) callWith(method string, call func(Client) error) error {
+	err := c.callEntry(call)
+	if !retryable(method, err) {
+		return err
+	}
+	c.mutex.Lock()
+	entry, down := c.entry, c.down
+	c.mutex.Unlock()
+	// Tried once when it was down at the last call already
+	deadline := time.Now()
+	if !down {
+		deadline = deadline.Add(clusterReconnectFor)
+	}
+	for backoff := reconnectBackoffMin; ; backoff = min(backoff*2, reconnectBackoffMax) {
+		if reconnectErr := c.reconnect(); reconnectErr == nil {
+			if moved := c.Entry(); moved.Addr != entry.Addr || down {
+				c.log(fmt.Sprintf("Reconnected to %s-%s at %s", moved.Type, moved.Name, moved.Addr))
+			}
+			c.mutex.Lock()
+			c.down = false
+			c.mutex.Unlock()
+			if err = c.callEntry(call); !retryable(method, err) {
+				return err
+			}
+		} else {
+			err = reconnectErr
+		}
+		if time.Now().Add(backoff).After(deadline) {
+			break
+		}
+		time.Sleep(backoff)
+	}
+	c.mutex.Lock()
+	c.down = true
+	c.mutex.Unlock()
+	if !failoverMethods[method] {
+		return fmt.Errorf("%s-%s unreachable: %v", entry.Type, entry.Name, err)
+	}
+
+	for _, node := range c.Nodes() {
+		if node.Type != "Participant" || node.Name == entry.Name || node.Name == "" {
+			continue
+		}
+		client, dialErr := c.transport.DialNode(node.Addr, node.Name)
+		if dialErr != nil {
+			continue
+		}
+		c.log(fmt.Sprintf("%s-%s unreachable, calling %s through %s-%s", entry.Type, entry.Name, method, node.Type, node.Name))
+		err = call(client)
+		client.Close()
+		if !retryable(method, err) {
+			return err
+		}
+	}
+	return fmt.Errorf("%s-%s and every other participant unreachable: %v", entry.Type, entry.Name, err)
+}
+
+func (c *ClusterClient) callEntry(call func(Client) error) error {
+	c.mutex.Lock()
+	client, entry := c.client, c.entry
+	c.mutex.Unlock()
+	if client == nil {
+		return fmt.Errorf("%w: not connected to %s", ErrUnreachable, entry.Addr)
+	}
+	err := call(client)
+	if _, isServerError := err.(ServerError); err != nil && !isServerError {
+		c.disconnect(client)
+	}
+	return err
+}
+
+// Whether method can be called again after failing with err: when the
+// request never got to the node, or when calling it twice does no harm
+func retryable(method string, err error) bool {
+	if _, isServerError := err.(ServerError); err == nil || isServerError {
+		return false
+	}
+	if notSent(err) {
+		return true
+	}
+	for _, prefix := range []string{"Node.Get", "Node.List", "Node.Ping", "Node.Audit"} {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ClusterClient) log(message string) {
+	if c.Log != nil {
+		c.Log(message)
+	}
+}
@@ -0,0 +1,197 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Connections to other nodes are kept open and shared rather than dialled for
+// every call. A connection that fails is closed and dialled again on its next
+// use, waiting longer after each failed attempt, so nodes that restart are
+// reached again without rebuilding the cluster.
+
+const (
+	ConnectionConnected    = "CONNECTED"
+	ConnectionReconnecting = "RECONNECTING" // Dialled again on next use
+	ConnectionUnreachable  = "UNREACHABLE"  // Last dial failed, waiting before the next
+
+	reconnectBackoffMin = 100 * time.Millisecond
+	reconnectBackoffMax = 2 * time.Second
+)
+
+// Returned instead of calling a node that cannot be dialled, so the request
+// was not sent
+var ErrUnreachable = errors.New("node unreachable")
+
+type ConnectionState struct {
+	Name      string    `json:"name"` // Empty until known
+	Address   string    `json:"address"`
+	State     string    `json:"state"`
+	Failures  int       `json:"failures"` // Failed dials since last connected
+	LastError string    `json:"lastError"`
+	Since     time.Time `json:"since"` // When it entered State
+}
+
+type connection struct {
+	ConnectionState
+	client      Client
+	nextAttempt time.Time
+}
+
+type connectionManager struct {
+	mutex       sync.Mutex
+	connections map[string]*connection
+}
+
+// Must hold mutex
+func (m *connectionManager) get(addr string) *connection {
+	if m.connections == nil {
+		m.connections = make(map[string]*connection)
+	}
+	conn, ok := m.connections[addr]
+	if !ok {
+		conn = &connection{ConnectionState: ConnectionState{Address: addr, State: ConnectionReconnecting, Since: time.Now()}}
+		m.connections[addr] = conn
+	}
+	return conn
+}
+
+func (c *connection) setState(state string, err error) {
+	if c.State != state {
+		c.State = state
+		c.Since = time.Now()
+	}
+	if err != nil {
+		c.LastError = err.Error()
+	}
+}
+
+// Open connection to addr, dialling it if there is none
+func (n *Node) connect(addr string) (Client, error) {
+	m := &n.connections
+	m.mutex.Lock()
+	conn := m.get(addr)
+	if conn.client != nil {
+		defer m.mutex.Unlock()
+		return conn.client, nil
+	}
+	if wait := time.Until(conn.nextAttempt); wait > 0 {
+		m.mutex.Unlock()
+		return nil, fmt.Errorf("%w: %s, retrying in %s: %s", ErrUnreachable, addr, wait.Round(time.Millisecond), conn.LastError)
+	}
+	name := conn.Name
+	m.mutex.Unlock()
+
+	client, err := n.transport.DialNode(addr, name)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if err != nil {
+		conn.Failures++
+		backoff := reconnectBackoffMin << min(conn.Failures-1, 10)
+		conn.nextAttempt = time.Now().Add(min(backoff, reconnectBackoffMax))
+		conn.setState(ConnectionUnreachable, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	if conn.client != nil {
+		// Dialled at the same time by another call
+		client.Close()
+		return conn.client, nil
+	}
+	conn.client = client
+	conn.Failures = 0
+	conn.setState(ConnectionConnected, nil)
+	return client, nil
+}
+
+// Connect to the node name at addr, which has just asked to join. Over TLS
+// it must have a certificate for name, also whenever it is redialled.
+func (n *Node) connectNode(addr string, name string) (Client, error) {
+	m := &n.connections
+	m.mutex.Lock()
+	conn := m.get(addr)
+	if conn.Name != name {
+		conn.Name = name
+		if conn.client != nil {
+			conn.client.Close()
+			conn.client = nil
+		}
+	}
+	// Up again after a restart, whatever the dials before it found
+	conn.nextAttempt = time.Time{}
+	m.mutex.Unlock()
+	return n.connect(addr)
+}
+
+// Close a connection that failed with err, if still the one in use
+func (n *Node) disconnect(addr string, client Client, err error) {
+	m := &n.connections
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	conn := m.get(addr)
+	if conn.client != client {
+		return
+	}
+	conn.client.Close()
+	conn.client = nil
+	conn.setState(ConnectionReconnecting, err)
+}
+
+// Call method on the node at addr. A connection found broken before the
+// request went out is replaced and the call made again.
+func (n *Node) call(addr string, method string, req any, res any) error {
+	return n.callWith(addr, func(client Client) error {
+		return client.Call(method, req, res)
+	})
+}
+
+func (n *Node) callTimeout(addr string, method string, req any, res any, timeout time.Duration) error {
+	return n.callWith(addr, func(client Client) error {
+		return client.CallTimeout(method, req, res, timeout)
+	})
+}
+
+func (n *Node) callWith(addr string, call func(Client) error) error {
+	for attempt := 0; ; attempt++ {
+		client, err := n.connect(addr)
+		if err != nil {
+			return err
+		}
+		err = call(client)
+		if _, isServerError := err.(ServerError); err == nil || isServerError {
+			return err
+		}
+		n.disconnect(addr, client, err)
+		if !errors.Is(err, ErrClientClosed) || attempt > 0 {
+			return err
+		}
+	}
+}
+
+// Whether a call failed without the request reaching the node
+func notSent(err error) bool {
+	return errors.Is(err, ErrClientClosed) || errors.Is(err, ErrUnreachable)
+}
+
+// RPC: State of this node's connections to others
+type GetConnectionsRequest struct{}
+
+type GetConnectionsResponse struct {
+	Connections []ConnectionState `json:"connections"` // By address
+}
+
+func (n *Node) GetConnections(req *GetConnectionsRequest, res *GetConnectionsResponse) error {
+	m := &n.connections
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, conn := range m.connections {
+		res.Connections = append(res.Connections, conn.ConnectionState)
+	}
+	sort.Slice(res.Connections, func(i, j int) bool {
+		return res.Connections[i].Address < res.Connections[j].Address
+	})
+	return nil
+}
@@ -2,36 +2,67 @@ package node
 
 import (
 	"fmt"
-	"net/rpc"
+
+	"github.com/google/uuid"
 )
 
 type AddParticipantRequest struct {
-	Name string
-	Addr string
+	Name       string    `json:"name"`
+	Addr       string    `json:"addr"`
+	ID         uuid.UUID `json:"id"`         // Issued when it first joined, nil when joining for the first time
+	Version    int       `json:"version"`    // Protocol version the participant speaks
+	MinVersion int       `json:"minVersion"` // Oldest protocol version it accepts
+	Features   []string  `json:"features"`
+	callerIdentity
 }
 
 type AddParticipantResponse struct {
+	ID         uuid.UUID `json:"id"` // To present when joining again
+	Version    int       `json:"version"`
+	MinVersion int       `json:"minVersion"`
+	Features   []string  `json:"features"`
 }
 
+// Participants accept registrations too, becoming sub-coordinators for their downstream participants
 func (n *Node) AddParticipant(req *AddParticipantRequest, res *AddParticipantResponse) error {
-	if n.Type != "Coordinator" {
-		return fmt.Errorf("this node is not a coordinator")
+	if err := checkProtocol(req.Version, req.MinVersion); err != nil {
+		return fmt.Errorf("cannot admit %s: %v", req.Name, err)
+	}
+	if err := req.checkCaller(req.Name); err != nil {
+		return err
 	}
+	// Over TLS the node at Addr must have a certificate for the name it registers
+	if _, err := n.connectNode(req.Addr, req.Name); err != nil {
+		return fmt.Errorf("cannot connect to %s as %s: %v", req.Addr, req.Name, err)
+	}
+
+	n.c_membershipMutex.Lock()
 	if n.c_participantClients == nil {
 		n.c_participantClients = make(map[string]*ConnectionData)
 	}
-
-	client, err := rpc.Dial("tcp", req.Addr)
+	data, err := n.admit(req)
 	if err != nil {
+		n.c_membershipMutex.Unlock()
 		return err
 	}
-	var data = ConnectionData{
-		Name:    req.Name,
-		Address: req.Addr,
-		Client:  client,
+	if data.Version != req.Version {
+		n.Print(fmt.Sprintf("%s speaks protocol version %d", req.Name, req.Version))
+	}
+	data.Version, data.Features = req.Version, req.Features
+	route := RouteData{Name: data.Name, Address: data.Address, Version: data.Version, Features: data.Features}
+	n.persistMembership()
+	n.c_membershipMutex.Unlock()
+	res.ID = data.ID
+	res.Version, res.MinVersion, res.Features = ProtocolVersion, MinProtocolVersion, n.features()
+
+	// Nodes from before heartbeats would never answer one
+	if supportsFeature(req.Version, req.Features, FeatureHeartbeat) {
+		n.watch(req.Name, req.Addr)
+	} else {
+		n.unwatch(req.Name)
+	}
+	if n.Type == "Participant" {
+		n.announceRoute(route)
 	}
-	n.c_participantClients[req.Name] = &data
-	n.Print(fmt.Sprintf("Added %v to participant list", req.Name))
-	// TODO: use uuid for key, or check for repeat names
 	return nil
 }
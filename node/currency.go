@@ -0,0 +1,240 @@
+package node
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Currency of accounts created without one
+const defaultCurrency = "USD"
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Conversion records how an amount was converted into the account's currency,
+// so the transaction can be audited and replayed with the same rate.
+type Conversion struct {
+	From     string  `json:"from"`
+	To       string  `json:"to"`
+	Rate     Decimal `json:"rate"`     // Units of To for one unit of From
+	Original Decimal `json:"original"` // Amount in From
+}
+
+// RateTable holds exchange rates loaded from a file with one "FROM TO RATE"
+// line per rate, e.g. "USD EUR 0.92". A rate also gives its inverse unless that
+// is listed itself.
+type RateTable struct {
+	rates map[string]Decimal
+}
+
+// One rate of a table, as replicated in the decision log
+type ExchangeRate struct {
+	From string  `json:"from"`
+	To   string  `json:"to"`
+	Rate Decimal `json:"rate"`
+}
+
+func NewRateTable() *RateTable {
+	return &RateTable{rates: make(map[string]Decimal)}
+}
+
+// Table of the rates listed, which were checked when first set
+func rateTableOf(rates []ExchangeRate) *RateTable {
+	table := NewRateTable()
+	for _, rate := range rates {
+		table.rates[rate.From+"/"+rate.To] = rate.Rate
+	}
+	return table
+}
+
+func LoadRateTable(path string) (*RateTable, error) {
+	table := NewRateTable()
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%s:%d: expected FROM TO RATE", path, lineNumber)
+		}
+		rate, err := ParseDecimal(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, lineNumber, err)
+		}
+		if err := table.Set(fields[0], fields[1], rate); err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, lineNumber, err)
+		}
+	}
+	return table, scanner.Err()
+}
+
+func (t *RateTable) Set(from string, to string, rate Decimal) error {
+	if !currencyPattern.MatchString(from) || !currencyPattern.MatchString(to) {
+		return fmt.Errorf("invalid currency pair %s/%s", from, to)
+	}
+	if rate.Sign() <= 0 {
+		return fmt.Errorf("rate must be positive")
+	}
+	t.rates[from+"/"+to] = rate
+	return nil
+}
+
+// Rates set, by currency pair
+func (t *RateTable) List() []ExchangeRate {
+	var rates []ExchangeRate
+	for pair, rate := range t.rates {
+		from, to, _ := strings.Cut(pair, "/")
+		rates = append(rates, ExchangeRate{From: from, To: to, Rate: rate})
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].From != rates[j].From {
+			return rates[i].From < rates[j].From
+		}
+		return rates[i].To < rates[j].To
+	})
+	return rates
+}
+
+func (t *RateTable) Rate(from string, to string) (Decimal, error) {
+	if from == to {
+		return NewDecimal(1, 0), nil
+	}
+	if rate, ok := t.rates[from+"/"+to]; ok {
+		return rate, nil
+	}
+	if inverse, ok := t.rates[to+"/"+from]; ok {
+		return NewDecimal(1, 0).Div(inverse, maxDecimalScale)
+	}
+	return Decimal{}, fmt.Errorf("no exchange rate from %s to %s", from, to)
+}
+
+// Load the rate table from a file. Transfers are converted at the rates in the
+// decision log, so every coordinator uses the same ones; the file only seeds
+// the log when a leader finds no rates there.
+func (n *Node) LoadRates(path string) error {
+	table, err := LoadRateTable(path)
+	if os.IsNotExist(err) {
+		n.c_rates = NewRateTable()
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error loading rates: %v", err)
+	}
+	n.c_rates = table
+	n.Print(fmt.Sprintf("Loaded %d exchange rates from %s", len(table.rates), path))
+	return nil
+}
+
+// Convert transaction amounts given in another currency into the currency of
+// the account they apply to
+func (n *Node) convertCurrencies(transactions []Transaction) ([]Transaction, error) {
+	converted := make([]Transaction, len(transactions))
+	for i, tx := range transactions {
+		converted[i] = tx
+		if tx.Currency == "" {
+			continue
+		}
+		currency, balance, err := n.currencyOf(tx)
+		if err != nil {
+			return nil, fmt.Errorf("currency of %s/%s: %v", tx.Name, accountOrDefault(tx.Account), err)
+		}
+		if currency == tx.Currency {
+			continue
+		}
+		// A converted expected balance would be rounded, so could never match
+		if spec, err := LookupOperation(tx.Operation); err == nil && spec.UsesExpected {
+			return nil, invalidOperation(fmt.Errorf("%s on %s/%s must be in the account's currency %s", tx.Operation, tx.Name, accountOrDefault(tx.Account), currency))
+		}
+		rate, err := n.rates().Rate(tx.Currency, currency)
+		if err != nil {
+			return nil, err
+		}
+		conversion := &Conversion{From: tx.Currency, To: currency, Rate: rate, Original: tx.Amount}
+		if converted[i].Amount, err = tx.Amount.Mul(rate, balance.Scale); err != nil {
+			return nil, err
+		}
+		converted[i].Currency = currency
+		converted[i].Conversion = conversion
+		n.Print(fmt.Sprintf("Converted %s %s to %s %s for %s at %s", tx.Amount, tx.Currency, converted[i].Amount, currency, tx.Name, rate))
+	}
+	return converted, nil
+}
+
+// Rates in the latest committed RATES entry, none before the first
+func (n *Node) rates() *RateTable {
+	entries := n.committedEntries()
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Type == "RATES" {
+			return rateTableOf(entries[i].Rates)
+		}
+	}
+	return NewRateTable()
+}
+
+// Replicate the rates loaded from the file if the log has none yet. Called by
+// a new leader.
+func (n *Node) seedRates() {
+	if n.c_rates == nil || len(n.c_rates.rates) == 0 {
+		return
+	}
+	for _, entry := range n.committedEntries() {
+		if entry.Type == "RATES" {
+			return
+		}
+	}
+	if err := n.replicateDecision(DecisionEntry{Type: "RATES", Rates: n.c_rates.List()}); err != nil {
+		n.Print(fmt.Sprintf("Error replicating exchange rates: %v", err))
+		return
+	}
+	n.Print(fmt.Sprintf("Replicated %d exchange rates from the rates file", len(n.c_rates.rates)))
+}
+
+// RPC: Client setting an exchange rate on the leader, for every coordinator to
+// convert at
+type SetExchangeRateRequest struct {
+	Rate ExchangeRate `json:"rate"`
+}
+
+type SetExchangeRateResponse struct{}
+
+func (n *Node) SetExchangeRate(req *SetExchangeRateRequest, res *SetExchangeRateResponse) error {
+	if n.Type != "Coordinator" {
+		return fmt.Errorf("this node is not a coordinator")
+	}
+	if !n.isLeader() {
+		return n.notLeaderError()
+	}
+	// One change at a time, each replicating the whole table
+	n.c_ratesMutex.Lock()
+	defer n.c_ratesMutex.Unlock()
+	table := n.rates()
+	rate := req.Rate
+	if err := table.Set(rate.From, rate.To, rate.Rate); err != nil {
+		return err
+	}
+	if err := n.replicateDecision(DecisionEntry{Type: "RATES", Rates: table.List()}); err != nil {
+		return fmt.Errorf("rate not set: %v", err)
+	}
+	n.Print(fmt.Sprintf("Exchange rate %s/%s set to %s", rate.From, rate.To, rate.Rate))
+	return nil
+}
+
+// Currency and balance of the account tx applies to
+func (n *Node) currencyOf(tx Transaction) (string, Decimal, error) {
+	var res GetBalanceResponse
+	if err := n.call(tx.Addr, "Node.GetBalance", &GetBalanceRequest{Account: tx.Account}, &res); err != nil {
+		return "", Decimal{}, err
+	}
+	return res.Currency, res.Balance, nil
+}
@@ -0,0 +1,209 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number, Units * 10^-Scale. Balances are kept at
+// the scale of their account's currency, e.g. 1234 at scale 2 is 12.34.
+//
+// Rounding rules: adding or subtracting an amount with more decimal places
+// than the account's scale is rejected. Multiplying or dividing rounds the
+// result half to even at the account's scale.
+type Decimal struct {
+	Units int64
+	Scale int
+}
+
+// Most decimal places accepted for amounts and account scales
+const maxDecimalScale = 8
+
+const defaultScale = 2
+
+func NewDecimal(units int64, scale int) Decimal {
+	return Decimal{Units: units, Scale: scale}
+}
+
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	digits := strings.TrimLeft(s, "+-")
+	if len(s)-len(digits) > 1 {
+		return Decimal{}, fmt.Errorf("invalid amount %q", s)
+	}
+	intPart, fracPart, _ := strings.Cut(digits, ".")
+	if intPart == "" && fracPart == "" {
+		return Decimal{}, fmt.Errorf("invalid amount %q", s)
+	}
+	for _, c := range intPart + fracPart {
+		if c < '0' || c > '9' {
+			return Decimal{}, fmt.Errorf("invalid amount %q", s)
+		}
+	}
+	if len(fracPart) > maxDecimalScale {
+		return Decimal{}, fmt.Errorf("amount %q has more than %d decimal places", s, maxDecimalScale)
+	}
+	units, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("amount %q out of range", s)
+	}
+	if strings.HasPrefix(s, "-") {
+		units = -units
+	}
+	return Decimal{Units: units, Scale: len(fracPart)}, nil
+}
+
+func (d Decimal) String() string {
+	units := d.Units
+	sign := ""
+	if units < 0 {
+		sign = "-"
+	}
+	digits := strconv.FormatUint(absUnits(units), 10)
+	if d.Scale <= 0 {
+		return sign + digits
+	}
+	if len(digits) <= d.Scale {
+		digits = strings.Repeat("0", d.Scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-d.Scale] + "." + digits[len(digits)-d.Scale:]
+}
+
+// Stored and sent as its string form, which keeps the scale
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalText(text []byte) error {
+	parsed, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func (d Decimal) Sign() int {
+	switch {
+	case d.Units < 0:
+		return -1
+	case d.Units > 0:
+		return 1
+	}
+	return 0
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Units == 0
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{Units: -d.Units, Scale: d.Scale}
+}
+
+func (d Decimal) Cmp(other Decimal) int {
+	scale := max(d.Scale, other.Scale)
+	return d.scaledBig(scale).Cmp(other.scaledBig(scale))
+}
+
+// Same value at scale, failing if digits would be lost
+func (d Decimal) Rescale(scale int) (Decimal, error) {
+	if scale >= d.Scale {
+		return fromBig(d.scaledBig(scale), scale)
+	}
+	divisor := pow10(d.Scale - scale)
+	quotient, remainder := new(big.Int).QuoRem(big.NewInt(d.Units), divisor, new(big.Int))
+	if remainder.Sign() != 0 {
+		return Decimal{}, fmt.Errorf("amount %s has more than %d decimal places", d, scale)
+	}
+	return fromBig(quotient, scale)
+}
+
+// Value at scale, rounding half to even
+func (d Decimal) Round(scale int) Decimal {
+	if scale >= d.Scale {
+		rounded, err := fromBig(d.scaledBig(scale), scale)
+		if err != nil {
+			return d
+		}
+		return rounded
+	}
+	rounded, _ := fromBig(roundQuo(big.NewInt(d.Units), pow10(d.Scale-scale)), scale)
+	return rounded
+}
+
+func (d Decimal) Add(other Decimal) (Decimal, error) {
+	scale := max(d.Scale, other.Scale)
+	return fromBig(new(big.Int).Add(d.scaledBig(scale), other.scaledBig(scale)), scale)
+}
+
+func (d Decimal) Sub(other Decimal) (Decimal, error) {
+	return d.Add(other.Neg())
+}
+
+// Product rounded half to even at scale
+func (d Decimal) Mul(other Decimal, scale int) (Decimal, error) {
+	product := new(big.Int).Mul(big.NewInt(d.Units), big.NewInt(other.Units))
+	productScale := d.Scale + other.Scale
+	if scale >= productScale {
+		return fromBig(product.Mul(product, pow10(scale-productScale)), scale)
+	}
+	return fromBig(roundQuo(product, pow10(productScale-scale)), scale)
+}
+
+// Quotient rounded half to even at scale
+func (d Decimal) Div(other Decimal, scale int) (Decimal, error) {
+	if other.Units == 0 {
+		return Decimal{}, fmt.Errorf("division by zero")
+	}
+	// d/other = d.Units * 10^(other.Scale+scale) / (other.Units * 10^d.Scale) units at scale
+	numerator := new(big.Int).Mul(big.NewInt(d.Units), pow10(other.Scale+scale))
+	denominator := new(big.Int).Mul(big.NewInt(other.Units), pow10(d.Scale))
+	return fromBig(roundQuo(numerator, denominator), scale)
+}
+
+func (d Decimal) scaledBig(scale int) *big.Int {
+	return new(big.Int).Mul(big.NewInt(d.Units), pow10(scale-d.Scale))
+}
+
+func fromBig(units *big.Int, scale int) (Decimal, error) {
+	if !units.IsInt64() {
+		return Decimal{}, fmt.Errorf("amount out of range")
+	}
+	return Decimal{Units: units.Int64(), Scale: scale}, nil
+}
+
+// numerator/denominator rounded half to even
+func roundQuo(numerator *big.Int, denominator *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+	twiceRemainder := new(big.Int).Abs(remainder)
+	twiceRemainder.Lsh(twiceRemainder, 1)
+	cmp := twiceRemainder.Cmp(new(big.Int).Abs(denominator))
+	if cmp > 0 || (cmp == 0 && quotient.Bit(0) == 1) {
+		if (numerator.Sign() < 0) != (denominator.Sign() < 0) {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return quotient
+}
+
+func pow10(exponent int) *big.Int {
+	if exponent < 0 {
+		exponent = 0
+	}
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
+
+func absUnits(units int64) uint64 {
+	if units < 0 {
+		return uint64(-(units + 1)) + 1
+	}
+	return uint64(units)
+}
@@ -0,0 +1,173 @@
+package node
+
+import "testing"
+
+func mustDecimal(t *testing.T, s string) Decimal {
+	t.Helper()
+	d, err := ParseDecimal(s)
+	if err != nil {
+		t.Fatalf("ParseDecimal(%q): %v", s, err)
+	}
+	return d
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Decimal
+		wantErr bool
+	}{
+		{input: "12.34", want: NewDecimal(1234, 2)},
+		{input: "-0.5", want: NewDecimal(-5, 1)},
+		{input: "+7", want: NewDecimal(7, 0)},
+		{input: ".25", want: NewDecimal(25, 2)},
+		{input: " 3. ", want: NewDecimal(3, 0)},
+		{input: "0.12345678", want: NewDecimal(12345678, 8)},
+		{input: "0.123456789", wantErr: true},
+		{input: "", wantErr: true},
+		{input: ".", wantErr: true},
+		{input: "--1", wantErr: true},
+		{input: "1e3", wantErr: true},
+		{input: "1.2.3", wantErr: true},
+		{input: "99999999999999999999", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDecimal(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalString(t *testing.T) {
+	tests := []struct {
+		d    Decimal
+		want string
+	}{
+		{NewDecimal(1234, 2), "12.34"},
+		{NewDecimal(5, 2), "0.05"},
+		{NewDecimal(-5, 2), "-0.05"},
+		{NewDecimal(0, 2), "0.00"},
+		{NewDecimal(42, 0), "42"},
+		{NewDecimal(-9223372036854775808, 0), "-9223372036854775808"},
+	}
+	for _, tt := range tests {
+		if got := tt.d.String(); got != tt.want {
+			t.Errorf("%#v.String() = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	tests := []struct {
+		input string
+		scale int
+		want  string
+	}{
+		{"0.125", 2, "0.12"}, // Half rounds to the even neighbour
+		{"0.135", 2, "0.14"},
+		{"-0.125", 2, "-0.12"},
+		{"-0.135", 2, "-0.14"},
+		{"2.5", 0, "2"},
+		{"3.5", 0, "4"},
+		{"-2.5", 0, "-2"},
+		{"0.126", 2, "0.13"}, // Above half rounds away from zero
+		{"-0.126", 2, "-0.13"},
+		{"0.124", 2, "0.12"},
+		{"1.2", 3, "1.200"}, // Larger scales only pad
+		{"7", 0, "7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := mustDecimal(t, tt.input).Round(tt.scale).String(); got != tt.want {
+				t.Fatalf("Round(%d) = %s, want %s", tt.scale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalRescale(t *testing.T) {
+	tests := []struct {
+		input   string
+		scale   int
+		want    string
+		wantErr bool
+	}{
+		{input: "1.5", scale: 2, want: "1.50"},
+		{input: "1.50", scale: 1, want: "1.5"},
+		{input: "1.55", scale: 1, wantErr: true},
+		{input: "92233720368547758.07", scale: 3, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := mustDecimal(t, tt.input).Rescale(tt.scale)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %s, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalMulDiv(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		div     bool
+		scale   int
+		want    string
+		wantErr bool
+	}{
+		{name: "product", a: "10.00", b: "1.5", scale: 2, want: "15.00"},
+		{name: "product half to even", a: "0.25", b: "0.5", scale: 2, want: "0.12"},
+		{name: "negative product", a: "-0.25", b: "0.5", scale: 2, want: "-0.12"},
+		{name: "quotient", a: "10.00", b: "4", div: true, scale: 2, want: "2.50"},
+		{name: "quotient rounded", a: "10.00", b: "3", div: true, scale: 2, want: "3.33"},
+		{name: "quotient half to even", a: "0.05", b: "2", div: true, scale: 2, want: "0.02"},
+		{name: "negative divisor", a: "1.00", b: "-8", div: true, scale: 2, want: "-0.12"},
+		{name: "division by zero", a: "1", b: "0", div: true, scale: 2, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := mustDecimal(t, tt.a), mustDecimal(t, tt.b)
+			var got Decimal
+			var err error
+			if tt.div {
+				got, err = a.Div(b, tt.scale)
+			} else {
+				got, err = a.Mul(b, tt.scale)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %s, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
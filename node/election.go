@@ -0,0 +1,543 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Raft-style leader election between coordinators. The leader replicates every
+// transaction decision to a majority before acting on it, so a newly elected
+// leader can finish whatever the previous one left undecided.
+
+const (
+	roleFollower  = "Follower"
+	roleCandidate = "Candidate"
+	roleLeader    = "Leader"
+
+	heartbeatInterval  = 500 * time.Millisecond
+	electionTimeoutMin = 1500 * time.Millisecond
+	electionTimeoutMax = 3000 * time.Millisecond
+	peerCallTimeout    = 300 * time.Millisecond
+
+	errNotLeader = "not leader"
+)
+
+// Entry in the replicated decision log
+type DecisionEntry struct {
+	Term          int           `json:"term"`
+	Type          string        `json:"type"`          // NOOP, PREPARE, COMMIT, ABORT, ACK, SAGA, HEURISTIC or RATES
+	TransactionID uuid.UUID     `json:"transactionId"` // Saga ID for SAGA
+	Transactions  []Transaction `json:"transactions"`
+	// ACK: direct participants yet to acknowledge the decision, none once all have
+	Unacknowledged []string          `json:"unacknowledged"`
+	Saga           *SagaState        `json:"saga"`    // SAGA: state after the latest change
+	Outcome        *HeuristicOutcome `json:"outcome"` // HEURISTIC: as reported by the participant
+	Rates          []ExchangeRate    `json:"rates"`   // RATES: the whole table after the change
+}
+
+type raftState struct {
+	Term     int
+	VotedFor string
+	Snapshot DecisionSnapshot // Compacted start of the log
+	Log      []DecisionEntry  // Entries after the snapshot
+}
+
+func (n *Node) resetElectionTimer() {
+	n.c_lastHeartbeat = time.Now()
+	n.c_electionTimeout = electionTimeoutMin + time.Duration(rand.Int63n(int64(electionTimeoutMax-electionTimeoutMin)))
+}
+
+func (n *Node) runElectionTimer() {
+	n.c_raftMutex.Lock()
+	n.c_role = roleFollower
+	n.resetElectionTimer()
+	n.c_raftMutex.Unlock()
+	for {
+		time.Sleep(100 * time.Millisecond)
+		n.c_raftMutex.Lock()
+		expired := n.c_role != roleLeader && time.Since(n.c_lastHeartbeat) > n.c_electionTimeout
+		n.c_raftMutex.Unlock()
+		if expired {
+			n.startElection()
+		}
+	}
+}
+
+func (n *Node) startElection() {
+	n.c_raftMutex.Lock()
+	n.c_role = roleCandidate
+	n.c_term++
+	n.c_votedFor = n.Addr
+	n.c_leaderAddr = ""
+	n.resetElectionTimer()
+	term := n.c_term
+	lastIndex, lastTerm := n.lastLogInfo()
+	n.persistRaftState()
+	votes := 1
+	if votes > (len(n.c_peers)+1)/2 {
+		n.becomeLeader()
+		n.c_raftMutex.Unlock()
+		return
+	}
+	n.c_raftMutex.Unlock()
+	n.Print(fmt.Sprintf("Starting election for term %d", term))
+
+	for _, peer := range n.c_peers {
+		go func(peer string) {
+			req := RequestVoteRequest{
+				Term:          term,
+				CandidateAddr: n.Addr,
+				LastLogIndex:  lastIndex,
+				LastLogTerm:   lastTerm,
+			}
+			var res RequestVoteResponse
+			if err := n.callPeer(peer, "Node.RequestVote", &req, &res); err != nil {
+				return
+			}
+			n.c_raftMutex.Lock()
+			defer n.c_raftMutex.Unlock()
+			if res.Term > n.c_term {
+				n.stepDown(res.Term)
+				return
+			}
+			if !res.VoteGranted || n.c_role != roleCandidate || n.c_term != term {
+				return
+			}
+			votes++
+			if votes > (len(n.c_peers)+1)/2 {
+				n.becomeLeader()
+			}
+		}(peer)
+	}
+}
+
+// Must hold c_raftMutex
+func (n *Node) becomeLeader() {
+	n.c_role = roleLeader
+	n.c_leaderAddr = n.Addr
+	n.c_nextIndex = make(map[string]int)
+	n.c_matchIndex = make(map[string]int)
+	for _, peer := range n.c_peers {
+		n.c_nextIndex[peer] = n.logLength() + 1
+		n.c_matchIndex[peer] = 0
+	}
+	n.Print(fmt.Sprintf(colorGreen+"Elected leader for term %d"+colorReset, n.c_term))
+	go n.runLeader(n.c_term)
+	go n.recoverUndecidedTransactions()
+	go n.runDecisionResend(n.c_term)
+}
+
+// Must hold c_raftMutex
+func (n *Node) stepDown(term int) {
+	if term > n.c_term {
+		n.c_term = term
+		n.c_votedFor = ""
+	}
+	if n.c_role == roleLeader {
+		n.Print(fmt.Sprintf("Stepping down as leader in term %d", n.c_term))
+	}
+	n.c_role = roleFollower
+	n.persistRaftState()
+}
+
+// Must hold c_raftMutex
+func (n *Node) lastLogInfo() (int, int) {
+	last := n.logLength()
+	return last, n.termAt(last)
+}
+
+// Index of the last entry, counting those compacted into the snapshot. Must
+// hold c_raftMutex.
+func (n *Node) logLength() int {
+	return n.c_snapshot.Index + len(n.c_log)
+}
+
+// Term of the entry at index, which must not be before the snapshot's last
+// one. Must hold c_raftMutex.
+func (n *Node) termAt(index int) int {
+	if index == n.c_snapshot.Index {
+		return n.c_snapshot.Term
+	}
+	return n.c_log[index-n.c_snapshot.Index-1].Term
+}
+
+// Entries from index on, which must be after the snapshot. Must hold c_raftMutex.
+func (n *Node) entriesFrom(index int) []DecisionEntry {
+	return append([]DecisionEntry(nil), n.c_log[index-n.c_snapshot.Index-1:]...)
+}
+
+// Entries still of interest, committed or not: those kept by the snapshot and
+// the ones after it
+func (n *Node) logEntries() []DecisionEntry {
+	n.c_raftMutex.Lock()
+	defer n.c_raftMutex.Unlock()
+	return append(append([]DecisionEntry(nil), n.c_snapshot.Entries...), n.c_log...)
+}
+
+// Committed entries still of interest
+func (n *Node) committedEntries() []DecisionEntry {
+	n.c_raftMutex.Lock()
+	defer n.c_raftMutex.Unlock()
+	return append(append([]DecisionEntry(nil), n.c_snapshot.Entries...), n.c_log[:n.c_commitIndex-n.c_snapshot.Index]...)
+}
+
+func (n *Node) isLeader() bool {
+	n.c_raftMutex.Lock()
+	defer n.c_raftMutex.Unlock()
+	return n.c_role == roleLeader
+}
+
+func (n *Node) notLeaderError() error {
+	n.c_raftMutex.Lock()
+	defer n.c_raftMutex.Unlock()
+	return fmt.Errorf("%s, leader is %q", errNotLeader, n.c_leaderAddr)
+}
+
+// Send heartbeats and replicate the log while leader of term
+func (n *Node) runLeader(term int) {
+	for {
+		n.c_raftMutex.Lock()
+		stillLeader := n.c_role == roleLeader && n.c_term == term
+		n.c_raftMutex.Unlock()
+		if !stillLeader {
+			return
+		}
+		for _, peer := range n.c_peers {
+			go n.replicateTo(peer, term)
+		}
+		time.Sleep(heartbeatInterval)
+	}
+}
+
+func (n *Node) replicateTo(peer string, term int) {
+	n.c_raftMutex.Lock()
+	if n.c_role != roleLeader || n.c_term != term {
+		n.c_raftMutex.Unlock()
+		return
+	}
+	next := n.c_nextIndex[peer]
+	// Entries it lacks were compacted away, the snapshot replaces them
+	if next <= n.c_snapshot.Index {
+		n.c_raftMutex.Unlock()
+		n.sendSnapshot(peer, term)
+		return
+	}
+	prevIndex := next - 1
+	req := AppendEntriesRequest{
+		Term:         term,
+		LeaderAddr:   n.Addr,
+		PrevLogIndex: prevIndex,
+		PrevLogTerm:  n.termAt(prevIndex),
+		Entries:      n.entriesFrom(next),
+		LeaderCommit: n.c_commitIndex,
+	}
+	n.c_raftMutex.Unlock()
+
+	var res AppendEntriesResponse
+	if err := n.callPeer(peer, "Node.AppendEntries", &req, &res); err != nil {
+		return
+	}
+
+	n.c_raftMutex.Lock()
+	defer n.c_raftMutex.Unlock()
+	if res.Term > n.c_term {
+		n.stepDown(res.Term)
+		return
+	}
+	if n.c_role != roleLeader || n.c_term != term {
+		return
+	}
+	if res.Success {
+		match := prevIndex + len(req.Entries)
+		if match > n.c_matchIndex[peer] {
+			n.c_matchIndex[peer] = match
+			n.c_nextIndex[peer] = match + 1
+		}
+		n.advanceCommitIndex()
+	} else if n.c_nextIndex[peer] > 1 {
+		n.c_nextIndex[peer]--
+	}
+}
+
+// Must hold c_raftMutex
+func (n *Node) advanceCommitIndex() {
+	for index := n.logLength(); index > n.c_commitIndex; index-- {
+		if n.termAt(index) != n.c_term {
+			break
+		}
+		count := 1
+		for _, peer := range n.c_peers {
+			if n.c_matchIndex[peer] >= index {
+				count++
+			}
+		}
+		if count > (len(n.c_peers)+1)/2 {
+			n.c_commitIndex = index
+			n.compactLog()
+			return
+		}
+	}
+}
+
+// Append entry to the decision log and block until a majority has it
+func (n *Node) replicateDecision(entry DecisionEntry) error {
+	n.c_raftMutex.Lock()
+	if n.c_role != roleLeader {
+		n.c_raftMutex.Unlock()
+		return n.notLeaderError()
+	}
+	entry.Term = n.c_term
+	n.c_log = append(n.c_log, entry)
+	index := n.logLength()
+	term := n.c_term
+	n.advanceCommitIndex()
+	n.persistRaftState()
+	n.c_raftMutex.Unlock()
+
+	for _, peer := range n.c_peers {
+		go n.replicateTo(peer, term)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		n.c_raftMutex.Lock()
+		lost := n.c_role != roleLeader || n.c_term != term
+		// Only committed entries are compacted, and none replaced ours while still leader
+		committed := n.c_commitIndex >= index && (index <= n.c_snapshot.Index && !lost ||
+			index > n.c_snapshot.Index && n.logLength() >= index && n.termAt(index) == term)
+		n.c_raftMutex.Unlock()
+		if committed {
+			return nil
+		}
+		if lost {
+			return fmt.Errorf("lost leadership while replicating %s", entry.Type)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return fmt.Errorf("%s not replicated to a majority of coordinators", entry.Type)
+}
+
+// Finish transactions left undecided by a previous leader
+func (n *Node) recoverUndecidedTransactions() {
+	// Committing an entry from our own term also commits everything before it
+	if err := n.replicateDecision(DecisionEntry{Type: "NOOP"}); err != nil {
+		n.Print(fmt.Sprintf("Error committing leader entry: %v", err))
+		return
+	}
+
+	for _, outcome := range transactionOutcomes(n.committedEntries()) {
+		if outcome.Decision != "" {
+			continue
+		}
+		n.Print(fmt.Sprintf("Aborting undecided transaction %s", outcome.TransactionID))
+		if err := n.replicateDecision(DecisionEntry{Type: "ABORT", TransactionID: outcome.TransactionID}); err != nil {
+			n.Print(fmt.Sprintf("Error replicating abort for %s: %v", outcome.TransactionID, err))
+			return
+		}
+		n.LogTransaction("ABORT", outcome.TransactionID)
+	}
+	n.seedRates()
+	n.resendDecisions()
+	n.resumeSagas()
+}
+
+// Resend decisions participants have not acknowledged, and resume sagas left
+// unfinished, while leader of term
+func (n *Node) runDecisionResend(term int) {
+	for {
+		time.Sleep(decisionResendInterval)
+		n.c_raftMutex.Lock()
+		stillLeader := n.c_role == roleLeader && n.c_term == term
+		n.c_raftMutex.Unlock()
+		if !stillLeader {
+			return
+		}
+		n.resendDecisions()
+		n.resumeSagas()
+	}
+}
+
+// Call another coordinator
+func (n *Node) callPeer(peer string, method string, req interface{}, res interface{}) error {
+	return n.callTimeout(peer, method, req, res, peerCallTimeout)
+}
+
+const raftStateKey = "raft"
+
+// Must hold c_raftMutex
+func (n *Node) persistRaftState() {
+	data, err := json.Marshal(raftState{Term: n.c_term, VotedFor: n.c_votedFor, Snapshot: n.c_snapshot, Log: n.c_log})
+	if err != nil {
+		n.Print(fmt.Sprintf("Error encoding raft state: %v", err))
+		return
+	}
+	if err := n.state.Put(raftStateKey, data); err != nil {
+		n.Print(fmt.Sprintf("Error writing raft state: %v", err))
+	}
+}
+
+func (n *Node) loadRaftState() {
+	data, ok, err := n.state.Get(raftStateKey)
+	if err != nil || !ok {
+		return
+	}
+	var state raftState
+	if err := json.Unmarshal(data, &state); err != nil {
+		n.Print(fmt.Sprintf("Error decoding raft state: %v", err))
+		return
+	}
+	n.c_raftMutex.Lock()
+	defer n.c_raftMutex.Unlock()
+	n.c_term = state.Term
+	n.c_votedFor = state.VotedFor
+	n.c_snapshot = state.Snapshot
+	n.c_log = state.Log
+	// Only committed entries are compacted
+	n.c_commitIndex = n.c_snapshot.Index
+	n.Print(fmt.Sprintf("Restored term %d with %d log entries after a snapshot of %d", n.c_term, len(n.c_log), n.c_snapshot.Index))
+}
+
+// RPC: Candidate requesting a vote from another coordinator
+type RequestVoteRequest struct {
+	Term          int    `json:"term"`
+	CandidateAddr string `json:"candidateAddr"`
+	LastLogIndex  int    `json:"lastLogIndex"`
+	LastLogTerm   int    `json:"lastLogTerm"`
+	callerIdentity
+}
+
+type RequestVoteResponse struct {
+	Term        int  `json:"term"`
+	VoteGranted bool `json:"voteGranted"`
+}
+
+func (n *Node) RequestVote(req *RequestVoteRequest, res *RequestVoteResponse) error {
+	if n.Type != "Coordinator" {
+		return fmt.Errorf("this node is not a coordinator")
+	}
+	if err := n.checkPeerCaller(&req.callerIdentity, req.CandidateAddr); err != nil {
+		return err
+	}
+	n.c_raftMutex.Lock()
+	defer n.c_raftMutex.Unlock()
+	if req.Term > n.c_term {
+		n.stepDown(req.Term)
+	}
+	res.Term = n.c_term
+	if req.Term < n.c_term {
+		return nil
+	}
+	lastIndex, lastTerm := n.lastLogInfo()
+	upToDate := req.LastLogTerm > lastTerm || (req.LastLogTerm == lastTerm && req.LastLogIndex >= lastIndex)
+	if (n.c_votedFor == "" || n.c_votedFor == req.CandidateAddr) && upToDate {
+		n.c_votedFor = req.CandidateAddr
+		n.resetElectionTimer()
+		n.persistRaftState()
+		res.VoteGranted = true
+	}
+	return nil
+}
+
+// RPC: Leader heartbeat and decision log replication
+type AppendEntriesRequest struct {
+	Term         int             `json:"term"`
+	LeaderAddr   string          `json:"leaderAddr"`
+	PrevLogIndex int             `json:"prevLogIndex"`
+	PrevLogTerm  int             `json:"prevLogTerm"`
+	Entries      []DecisionEntry `json:"entries"`
+	LeaderCommit int             `json:"leaderCommit"`
+	callerIdentity
+}
+
+type AppendEntriesResponse struct {
+	Term    int  `json:"term"`
+	Success bool `json:"success"`
+}
+
+func (n *Node) AppendEntries(req *AppendEntriesRequest, res *AppendEntriesResponse) error {
+	if n.Type != "Coordinator" {
+		return fmt.Errorf("this node is not a coordinator")
+	}
+	if err := n.checkPeerCaller(&req.callerIdentity, req.LeaderAddr); err != nil {
+		return err
+	}
+	n.c_raftMutex.Lock()
+	defer n.c_raftMutex.Unlock()
+	res.Term = n.c_term
+	if req.Term < n.c_term {
+		return nil
+	}
+	if req.Term > n.c_term || n.c_role != roleFollower {
+		n.stepDown(req.Term)
+		res.Term = n.c_term
+	}
+	if n.c_leaderAddr != req.LeaderAddr {
+		n.Print(fmt.Sprintf("Following leader %s in term %d", req.LeaderAddr, req.Term))
+	}
+	n.c_leaderAddr = req.LeaderAddr
+	n.resetElectionTimer()
+
+	// Entries up to the snapshot are committed, so they match the leader's
+	prevIndex, entries := req.PrevLogIndex, req.Entries
+	if skip := n.c_snapshot.Index - prevIndex; skip > 0 {
+		entries = entries[min(skip, len(entries)):]
+		prevIndex = n.c_snapshot.Index
+	} else if prevIndex > n.logLength() || n.termAt(prevIndex) != req.PrevLogTerm {
+		return nil
+	}
+	changed := false
+	for i, entry := range entries {
+		index := prevIndex + 1 + i
+		if index <= n.logLength() {
+			if n.termAt(index) == entry.Term {
+				continue
+			}
+			n.c_log = n.c_log[:index-n.c_snapshot.Index-1]
+		}
+		n.c_log = append(n.c_log, entry)
+		changed = true
+	}
+	if changed {
+		n.persistRaftState()
+	}
+	if req.LeaderCommit > n.c_commitIndex {
+		n.c_commitIndex = min(req.LeaderCommit, n.logLength())
+		n.compactLog()
+	}
+	res.Success = true
+	return nil
+}
+
+// RPC: Find the current coordinator leader
+type GetLeaderRequest struct{}
+
+type GetLeaderResponse struct {
+	LeaderAddr string `json:"leaderAddr"`
+	Term       int    `json:"term"`
+}
+
+func (n *Node) GetLeader(req *GetLeaderRequest, res *GetLeaderResponse) error {
+	if n.Type != "Coordinator" {
+		n.p_coordinatorMutex.Lock()
+		known := n.p_coordinatorLeader != ""
+		n.p_coordinatorMutex.Unlock()
+		if !known {
+			if _, err := n.findLeader(); err != nil {
+				return err
+			}
+		}
+		n.p_coordinatorMutex.Lock()
+		defer n.p_coordinatorMutex.Unlock()
+		res.LeaderAddr = n.p_coordinatorLeader
+		return nil
+	}
+	n.c_raftMutex.Lock()
+	defer n.c_raftMutex.Unlock()
+	res.LeaderAddr = n.c_leaderAddr
+	res.Term = n.c_term
+	return nil
+}
@@ -0,0 +1,151 @@
+package node
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Nodes with participants registered to them send each one a heartbeat and
+// suspect the ones that stop answering. A participant silent for long enough
+// is considered down, and new transactions involving it fail at once instead
+// of waiting out the prepare timeout. Heartbeats carry on while it is down, so
+// it is alive again as soon as it answers.
+
+const (
+	LivenessAlive   = "ALIVE"
+	LivenessSuspect = "SUSPECT" // Missed heartbeats, still given transactions
+	LivenessDown    = "DOWN"    // New transactions involving it are refused
+	LivenessUnknown = "UNKNOWN" // Not watched by this node, e.g. behind a sub-coordinator
+
+	livenessHeartbeatInterval = 500 * time.Millisecond
+	livenessSuspectAfter      = 1500 * time.Millisecond
+	livenessDownAfter         = 3 * time.Second // Well within prepareTimeout
+)
+
+type watchedMember struct {
+	address  string
+	lastSeen time.Time
+	liveness string
+}
+
+type failureDetector struct {
+	mutex   sync.Mutex
+	members map[string]*watchedMember // By participant name
+}
+
+// Start sending heartbeats to name at addr, which has just been reached
+func (n *Node) watch(name string, addr string) {
+	d := &n.c_failureDetector
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.members == nil {
+		d.members = make(map[string]*watchedMember)
+	}
+	d.members[name] = &watchedMember{address: addr, lastSeen: time.Now(), liveness: LivenessAlive}
+}
+
+func (n *Node) unwatch(name string) {
+	d := &n.c_failureDetector
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	delete(d.members, name)
+}
+
+// Liveness of participant name and when it last answered
+func (n *Node) liveness(name string) (string, time.Time) {
+	d := &n.c_failureDetector
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	member, ok := d.members[name]
+	if !ok {
+		return LivenessUnknown, time.Time{}
+	}
+	return member.liveness, member.lastSeen
+}
+
+// Error naming the first of the participants considered down, if any
+func (n *Node) checkLiveness(names []string) error {
+	for _, name := range names {
+		liveness, lastSeen := n.liveness(name)
+		if liveness == LivenessDown {
+			return fmt.Errorf("participant %s is down, last seen %s ago", name, time.Since(lastSeen).Round(time.Second))
+		}
+	}
+	return nil
+}
+
+func (n *Node) runFailureDetector() {
+	d := &n.c_failureDetector
+	for {
+		time.Sleep(livenessHeartbeatInterval)
+		d.mutex.Lock()
+		members := make(map[string]string, len(d.members))
+		for name, member := range d.members {
+			members[name] = member.address
+		}
+		d.mutex.Unlock()
+
+		var wg sync.WaitGroup
+		for name, addr := range members {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var res HeartbeatResponse
+				err := n.callTimeout(addr, "Node.Heartbeat", &HeartbeatRequest{From: n.Addr}, &res, livenessHeartbeatInterval)
+				n.recordHeartbeat(name, addr, err)
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+func (n *Node) recordHeartbeat(name string, addr string, err error) {
+	d := &n.c_failureDetector
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	member, ok := d.members[name]
+	if !ok || member.address != addr {
+		// Registered again meanwhile
+		return
+	}
+	liveness := LivenessAlive
+	if err == nil {
+		member.lastSeen = time.Now()
+	} else if silent := time.Since(member.lastSeen); silent > livenessDownAfter {
+		liveness = LivenessDown
+	} else if silent > livenessSuspectAfter {
+		liveness = LivenessSuspect
+	} else {
+		liveness = member.liveness
+	}
+	if liveness == member.liveness {
+		return
+	}
+	switch liveness {
+	case LivenessAlive:
+		n.Print(fmt.Sprintf(colorGreen+"Participant %s is alive again"+colorReset, name))
+	case LivenessSuspect:
+		n.Print(fmt.Sprintf("Participant %s suspected, no heartbeat for %s: %v", name, time.Since(member.lastSeen).Round(time.Millisecond), err))
+	case LivenessDown:
+		n.Print(fmt.Sprintf(colorRed+"Participant %s considered down: %v"+colorReset, name, err))
+	}
+	member.liveness = liveness
+}
+
+// RPC: Heartbeat from a node the receiver has joined
+type HeartbeatRequest struct {
+	From string `json:"from"` // Address of the sender
+}
+
+type HeartbeatResponse struct{}
+
+func (n *Node) Heartbeat(req *HeartbeatRequest, res *HeartbeatResponse) error {
+	n.p_coordinatorMutex.Lock()
+	defer n.p_coordinatorMutex.Unlock()
+	if n.p_coordinatorSeen == nil {
+		n.p_coordinatorSeen = make(map[string]time.Time)
+	}
+	n.p_coordinatorSeen[req.From] = time.Now()
+	return nil
+}
@@ -0,0 +1,414 @@
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The gateway serves client operations as JSON over HTTP, for services and
+// scripts that do not speak the nodes' RPC. Any participant or coordinator can
+// run one, over HTTPS requiring client certificates when the node uses TLS.
+// Like the interactive client it calls the participants involved, reaching
+// them with the node's transport.
+//
+//	GET  /participants
+//	GET  /participants/{participant}/accounts/{account}
+//	POST /participants/{participant}/accounts/{account}/deposit   {"amount": "10.00"}
+//	POST /participants/{participant}/accounts/{account}/withdraw  {"amount": "10.00"}
+//	POST /transfers     {"from": "A/main", "to": "B/main", "amount": "10.00"}
+//	POST /transactions  {"transactions": [{"participant": "B", "account": "main", "operation": "add", "amount": "10.00"}]}
+//	GET  /transactions/{id}
+//
+// Fields are named in lowerCamelCase as over JSON-RPC, and amounts are strings
+// so they keep their decimal places. Errors come back as {"error": "..."},
+// with 400 for malformed requests, 404 for unknown participants and
+// transactions, 422 for operations that cannot apply to the account whatever
+// its balance, 409 when a transaction aborts otherwise or breaks an account
+// policy and 503 when no coordinator leader can be reached or a participant
+// involved is down.
+
+type gateway struct {
+	node *Node
+}
+
+type gatewayParticipant struct {
+	ID       *uuid.UUID `json:"id,omitempty"` // Unset behind a sub-coordinator
+	Name     string     `json:"name"`
+	Address  string     `json:"address"`
+	Liveness string     `json:"liveness"`
+	LastSeen *time.Time `json:"lastSeen,omitempty"`
+	Version  int        `json:"version"` // 0 from before versioning
+}
+
+type gatewayBalance struct {
+	Participant string  `json:"participant"`
+	Account     string  `json:"account"`
+	Balance     Decimal `json:"balance"`
+	Available   Decimal `json:"available"`
+	Currency    string  `json:"currency"`
+}
+
+type gatewayAmount struct {
+	Amount Decimal `json:"amount"`
+}
+
+type gatewayTransfer struct {
+	From     string  `json:"from"` // participant/account, this participant's main account when empty
+	To       string  `json:"to"`
+	Amount   Decimal `json:"amount"`
+	Currency string  `json:"currency"` // The sender's currency when empty
+}
+
+type gatewayTransaction struct {
+	Participant string        `json:"participant"`
+	Account     string        `json:"account,omitempty"`
+	Operation   OperationType `json:"operation"`
+	Amount      Decimal       `json:"amount"`
+	Expected    *Decimal      `json:"expected,omitempty"` // For operations comparing the balance first
+	Currency    string        `json:"currency,omitempty"`
+}
+
+type gatewayTransactionRequest struct {
+	Transactions []gatewayTransaction `json:"transactions"`
+}
+
+type gatewayTransactionStatus struct {
+	TransactionID uuid.UUID            `json:"transactionId"`
+	Status        string               `json:"status"`
+	Transactions  []gatewayTransaction `json:"transactions,omitempty"`
+}
+
+// Statuses of the decision log as the gateway reports them
+var gatewayStatuses = map[string]string{
+	"PREPARE": "pending",
+	"COMMIT":  "committed",
+	"ABORT":   "aborted",
+}
+
+// Serve the gateway on addr until it fails
+func (n *Node) ServeGateway(addr string) error {
+	g := &gateway{node: n}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /participants", g.participants)
+	mux.HandleFunc("GET /participants/{participant}/accounts/{account}", g.balance)
+	mux.HandleFunc("POST /participants/{participant}/accounts/{account}/deposit", g.deposit)
+	mux.HandleFunc("POST /participants/{participant}/accounts/{account}/withdraw", g.withdraw)
+	mux.HandleFunc("POST /transfers", g.transfer)
+	mux.HandleFunc("POST /transactions", g.transaction)
+	mux.HandleFunc("GET /transactions/{id}", g.transactionStatus)
+	if n.credentials == nil {
+		n.Print(fmt.Sprintf("Serving HTTP gateway on %s", addr))
+		return http.ListenAndServe(addr, mux)
+	}
+	// Clients need a certificate signed by the cluster's CA too
+	config := n.credentials.serverConfig()
+	config.NextProtos = []string{"h2", "http/1.1"}
+	server := &http.Server{Addr: addr, Handler: mux, TLSConfig: config}
+	n.Print(fmt.Sprintf("Serving HTTPS gateway on %s", addr))
+	return server.ListenAndServeTLS("", "")
+}
+
+func (g *gateway) participants(w http.ResponseWriter, r *http.Request) {
+	list, err := g.listParticipants()
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	participants := make([]gatewayParticipant, len(list.Names))
+	for i, name := range list.Names {
+		participants[i] = gatewayParticipant{Name: name, Address: list.Addresses[i], Liveness: list.Liveness[i]}
+		if i < len(list.Versions) {
+			participants[i].Version = list.Versions[i]
+		}
+		if id := list.IDs[i]; id != uuid.Nil {
+			participants[i].ID = &id
+		}
+		if lastSeen := list.LastSeen[i]; !lastSeen.IsZero() {
+			participants[i].LastSeen = &lastSeen
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"participants": participants})
+}
+
+func (g *gateway) balance(w http.ResponseWriter, r *http.Request) {
+	addr, err := g.participantAddr(r.PathValue("participant"))
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	g.writeBalance(w, addr, r.PathValue("participant"), r.PathValue("account"))
+}
+
+func (g *gateway) deposit(w http.ResponseWriter, r *http.Request) {
+	g.applyAmount(w, r, "Node.Deposit", func(account string, amount Decimal) any {
+		return &DepositRequest{Account: account, Amount: amount}
+	}, &DepositResponse{})
+}
+
+func (g *gateway) withdraw(w http.ResponseWriter, r *http.Request) {
+	g.applyAmount(w, r, "Node.Withdraw", func(account string, amount Decimal) any {
+		return &WithdrawRequest{Account: account, Amount: amount}
+	}, &WithdrawResponse{})
+}
+
+// Deposit or withdraw the amount in the body, answering with the new balance
+func (g *gateway) applyAmount(w http.ResponseWriter, r *http.Request, method string, request func(string, Decimal) any, res any) {
+	var body gatewayAmount
+	if err := decodeJSON(r, &body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	if body.Amount.Sign() <= 0 {
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("amount must be positive"))
+		return
+	}
+	name, account := r.PathValue("participant"), r.PathValue("account")
+	addr, err := g.participantAddr(name)
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	if err := g.call(addr, method, request(account, body.Amount), res); err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	g.writeBalance(w, addr, name, account)
+}
+
+func (g *gateway) writeBalance(w http.ResponseWriter, addr string, name string, account string) {
+	var res GetBalanceResponse
+	if err := g.call(addr, "Node.GetBalance", &GetBalanceRequest{Account: account}, &res); err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, gatewayBalance{name, account, res.Balance, res.Available, res.Currency})
+}
+
+func (g *gateway) transfer(w http.ResponseWriter, r *http.Request) {
+	var body gatewayTransfer
+	if err := decodeJSON(r, &body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	if body.From == "" && g.node.Type == "Participant" {
+		body.From = g.node.Name
+	}
+	fromName, fromAccount := splitAccountAddress(body.From)
+	toName, toAccount := splitAccountAddress(body.To)
+	switch {
+	case fromName == "" || toName == "":
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("from and to must be given as participant/account"))
+		return
+	case fromName == toName && fromAccount == toAccount:
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("cannot send to the same account"))
+		return
+	case body.Amount.Sign() <= 0:
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("amount must be positive"))
+		return
+	}
+	fromAddr, err := g.participantAddr(fromName)
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	toAddr, err := g.participantAddr(toName)
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	if body.Currency == "" {
+		var res GetBalanceResponse
+		if err := g.call(fromAddr, "Node.GetBalance", &GetBalanceRequest{Account: fromAccount}, &res); err != nil {
+			writeGatewayError(w, err)
+			return
+		}
+		body.Currency = res.Currency
+	}
+	g.submit(w, fromAddr, []Transaction{
+		{Addr: fromAddr, Name: fromName, Account: fromAccount, Operation: OpSubtract, Amount: body.Amount, Currency: body.Currency},
+		{Addr: toAddr, Name: toName, Account: toAccount, Operation: OpAdd, Amount: body.Amount, Currency: body.Currency},
+	})
+}
+
+func (g *gateway) transaction(w http.ResponseWriter, r *http.Request) {
+	var body gatewayTransactionRequest
+	if err := decodeJSON(r, &body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	if len(body.Transactions) == 0 {
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("no transactions given"))
+		return
+	}
+	transactions := make([]Transaction, len(body.Transactions))
+	for i, tx := range body.Transactions {
+		addr, err := g.participantAddr(tx.Participant)
+		if err != nil {
+			writeGatewayError(w, err)
+			return
+		}
+		transactions[i] = Transaction{
+			Addr:      addr,
+			Name:      tx.Participant,
+			Account:   accountOrDefault(tx.Account),
+			Operation: tx.Operation,
+			Amount:    tx.Amount,
+			Currency:  tx.Currency,
+		}
+		if tx.Expected != nil {
+			transactions[i].Expected = *tx.Expected
+		}
+		if err := ValidateTransaction(transactions[i]); err != nil {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("invalid transaction for %s: %v", tx.Participant, err))
+			return
+		}
+	}
+	g.submit(w, transactions[0].Addr, transactions)
+}
+
+// Run transactions with two-phase commit, submitted through the participant at addr
+func (g *gateway) submit(w http.ResponseWriter, addr string, transactions []Transaction) {
+	if g.node.Type == "Participant" {
+		addr = g.node.Addr
+	}
+	var res ClientParticipantTransactionResponse
+	err := g.call(addr, "Node.ClientParticipantTransaction", &ClientParticipantTransactionRequest{Transactions: transactions}, &res)
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, gatewayTransactionStatus{TransactionID: res.TransactionID, Status: gatewayStatuses["COMMIT"]})
+}
+
+func (g *gateway) transactionStatus(w http.ResponseWriter, r *http.Request) {
+	transactionID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("invalid transaction ID: %v", err))
+		return
+	}
+	addr := g.node.Addr
+	if g.node.Type == "Coordinator" {
+		// Only the leader answers, participants find it themselves
+		var leader GetLeaderResponse
+		if err := g.call(addr, "Node.GetLeader", &GetLeaderRequest{}, &leader); err != nil {
+			writeGatewayError(w, err)
+			return
+		}
+		if leader.LeaderAddr != "" {
+			addr = leader.LeaderAddr
+		}
+	}
+	var res GetTransactionStatusResponse
+	if err := g.call(addr, "Node.GetTransactionStatus", &GetTransactionStatusRequest{TransactionID: transactionID}, &res); err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	status := gatewayTransactionStatus{TransactionID: transactionID, Status: gatewayStatuses[res.Status]}
+	for _, tx := range res.Transactions {
+		entry := gatewayTransaction{
+			Participant: tx.Name,
+			Account:     tx.Account,
+			Operation:   tx.Operation,
+			Amount:      tx.Amount,
+			Currency:    tx.Currency,
+		}
+		if tx.Expected != (Decimal{}) {
+			entry.Expected = &tx.Expected
+		}
+		status.Transactions = append(status.Transactions, entry)
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+func (g *gateway) call(addr string, method string, req any, res any) error {
+	return g.node.call(addr, method, req, res)
+}
+
+func (g *gateway) listParticipants() (ListParticipantsResponse, error) {
+	var res ListParticipantsResponse
+	err := g.call(g.node.Addr, "Node.ListParticipants", &ListParticipantsRequest{}, &res)
+	return res, err
+}
+
+type unknownParticipantError string
+
+func (e unknownParticipantError) Error() string {
+	return fmt.Sprintf("unknown participant %s", string(e))
+}
+
+func (g *gateway) participantAddr(name string) (string, error) {
+	if g.node.Type == "Participant" && name == g.node.Name {
+		return g.node.Addr, nil
+	}
+	list, err := g.listParticipants()
+	if err != nil {
+		return "", err
+	}
+	for i, participant := range list.Names {
+		if participant == name {
+			return list.Addresses[i], nil
+		}
+	}
+	return "", unknownParticipantError(name)
+}
+
+// Participant and account of "participant/account", main when no account is given
+func splitAccountAddress(address string) (string, string) {
+	name, account, _ := strings.Cut(address, "/")
+	return name, accountOrDefault(account)
+}
+
+func decodeJSON(r *http.Request, v any) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("invalid request body: %v", err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// Status code for an error from a node
+func writeGatewayError(w http.ResponseWriter, err error) {
+	message := err.Error()
+	var status int
+	var serverError ServerError
+	var unknownParticipant unknownParticipantError
+	switch {
+	case errors.As(err, &unknownParticipant), strings.Contains(message, "not found"):
+		status = http.StatusNotFound
+	case strings.Contains(message, errNotLeader), strings.Contains(message, "no coordinator leader available"), strings.Contains(message, "is down"), errors.Is(err, ErrClientClosed):
+		status = http.StatusServiceUnavailable
+	case isInvalidOperation(err):
+		// Could never apply, whatever the balances
+		status = http.StatusUnprocessableEntity
+	case strings.Contains(message, "transaction aborted"):
+		status = http.StatusConflict
+	default:
+		if _, ok := violationReason(err); ok {
+			status = http.StatusConflict
+		} else if errors.As(err, &serverError) {
+			// Refused by the node, e.g. an operation the account does not allow
+			status = http.StatusUnprocessableEntity
+		} else {
+			// The node could not be reached
+			status = http.StatusBadGateway
+		}
+	}
+	writeJSONError(w, status, err)
+}
@@ -0,0 +1,216 @@
+package node
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Heuristic decisions let an operator resolve a transaction a participant is
+// stuck in doubt about. The real decision is still awaited, and if it turns
+// out to differ the outcome is heuristic-mixed and reported to the coordinator.
+// A heuristic decision stays local: participants below a sub-coordinator are
+// only sent the real one.
+
+const (
+	heuristicPrefix    = "HEURISTIC_"
+	heuristicMixed     = "HEURISTIC_MIXED"
+	heuristicConfirmed = "HEURISTIC_CONFIRMED"
+)
+
+type HeuristicOutcome struct {
+	TransactionID uuid.UUID `json:"transactionId"`
+	Participant   string    `json:"participant"`
+	Heuristic     string    `json:"heuristic"` // COMMIT or ABORT, as forced by the operator
+	Actual        string    `json:"actual"`    // Decision learned afterwards, empty while still unknown
+	Mixed         bool      `json:"mixed"`
+	Reported      time.Time `json:"reported"`
+}
+
+// RPC: Operator forcing a decision on an in-doubt transaction
+type ForceHeuristicDecisionRequest struct {
+	TransactionID uuid.UUID `json:"transactionId"`
+	Decision      string    `json:"decision"` // COMMIT or ABORT
+}
+
+type ForceHeuristicDecisionResponse struct{}
+
+func (n *Node) ForceHeuristicDecision(req *ForceHeuristicDecisionRequest, res *ForceHeuristicDecisionResponse) error {
+	if n.Type != "Participant" {
+		return fmt.Errorf("this node is not a participant")
+	}
+	if req.Decision != "COMMIT" && req.Decision != "ABORT" {
+		return fmt.Errorf("decision must be COMMIT or ABORT")
+	}
+	n.lockForDecision()
+	defer n.commitMutex.Unlock()
+	if !n.isPrepared(req.TransactionID) {
+		return fmt.Errorf("transaction %s is not in doubt", req.TransactionID)
+	}
+
+	var err error
+	if req.Decision == "COMMIT" {
+		err = n.resource.Commit(req.TransactionID)
+	} else {
+		err = n.resource.Abort(req.TransactionID)
+	}
+	if err != nil {
+		return fmt.Errorf("error applying heuristic decision: %v", err)
+	}
+	// The monitor keeps running so the real decision is still learned
+	n.LogTransaction(heuristicPrefix+req.Decision, req.TransactionID)
+	n.Print(fmt.Sprintf(colorRed+"Heuristic %s of %s forced by operator"+colorReset, req.Decision, req.TransactionID))
+	// Participants below still await the real decision, forwarded once it comes
+	return nil
+}
+
+// Heuristic decision taken for transactionID, if any
+func (n *Node) heuristicDecision(transactionID uuid.UUID) (string, bool) {
+	for _, decision := range []string{"COMMIT", "ABORT"} {
+		if n.hasLogEntry(transactionID, heuristicPrefix+decision) {
+			return decision, true
+		}
+	}
+	return "", false
+}
+
+// Compare the real decision with the heuristic one taken earlier. Must hold commitMutex.
+func (n *Node) resolveHeuristic(transactionID uuid.UUID, heuristic string, actual string) {
+	if n.hasLogEntry(transactionID, actual) {
+		return
+	}
+	n.LogTransaction(actual, transactionID)
+	if heuristic == actual {
+		n.LogTransaction(heuristicConfirmed, transactionID)
+		n.Print(fmt.Sprintf(colorGreen+"Heuristic %s of %s matches the decision"+colorReset, heuristic, transactionID))
+		return
+	}
+
+	n.LogTransaction(heuristicMixed, transactionID)
+	n.Print(fmt.Sprintf(colorRed+"HEURISTIC MIXED: %s was heuristically %s but decided %s"+colorReset, transactionID, heuristic, actual))
+	outcome := HeuristicOutcome{
+		TransactionID: transactionID,
+		Participant:   n.Name,
+		Heuristic:     heuristic,
+		Actual:        actual,
+		Mixed:         true,
+	}
+	go func() {
+		var res ReportHeuristicOutcomeResponse
+		if err := n.callCoordinator("Node.ReportHeuristicOutcome", &ReportHeuristicOutcomeRequest{Outcome: outcome}, &res); err != nil {
+			n.Print(fmt.Sprintf("Error reporting heuristic outcome: %v", err))
+		}
+	}()
+}
+
+// RPC: Participant reporting a heuristic-mixed outcome to the coordinator
+type ReportHeuristicOutcomeRequest struct {
+	Outcome HeuristicOutcome `json:"outcome"`
+}
+
+type ReportHeuristicOutcomeResponse struct{}
+
+func (n *Node) ReportHeuristicOutcome(req *ReportHeuristicOutcomeRequest, res *ReportHeuristicOutcomeResponse) error {
+	if n.Type != "Coordinator" {
+		return fmt.Errorf("this node is not a coordinator")
+	}
+	// Kept in the decision log, so whichever coordinator leads next knows of it
+	if !n.isLeader() {
+		return n.notLeaderError()
+	}
+	outcome := req.Outcome
+	outcome.Reported = time.Now()
+	if err := n.replicateDecision(DecisionEntry{Type: "HEURISTIC", TransactionID: outcome.TransactionID, Outcome: &outcome}); err != nil {
+		return fmt.Errorf("heuristic outcome not recorded: %v", err)
+	}
+	n.LogTransaction(heuristicMixed, outcome.TransactionID)
+	n.Print(fmt.Sprintf(colorRed+"HEURISTIC MIXED reported by %s: %s heuristically %s, decided %s"+colorReset,
+		outcome.Participant, outcome.TransactionID, outcome.Heuristic, outcome.Actual))
+	return nil
+}
+
+// RPC: Heuristic outcomes, as reported to the coordinator or recorded by a participant
+type GetHeuristicOutcomesRequest struct{}
+
+type GetHeuristicOutcomesResponse struct {
+	Outcomes []HeuristicOutcome `json:"outcomes"`
+}
+
+func (n *Node) GetHeuristicOutcomes(req *GetHeuristicOutcomesRequest, res *GetHeuristicOutcomesResponse) error {
+	if n.Type == "Coordinator" {
+		// Followers may not have every report yet
+		if !n.isLeader() {
+			return n.notLeaderError()
+		}
+		for _, entry := range n.logEntries() {
+			if entry.Type == "HEURISTIC" && entry.Outcome != nil {
+				res.Outcomes = append(res.Outcomes, *entry.Outcome)
+			}
+		}
+		return nil
+	}
+
+	logFile := filepath.Join("node_log", fmt.Sprintf("%s-%s.log", n.Type, n.Name))
+	file, err := os.Open(logFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error opening log file: %v", err)
+	}
+	defer file.Close()
+
+	outcomes := make(map[uuid.UUID]*HeuristicOutcome)
+	var order []uuid.UUID
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var tidStr, status string
+		if _, err := fmt.Sscanf(scanner.Text(), "%s - %s", &tidStr, &status); err != nil {
+			continue
+		}
+		tid, err := uuid.Parse(tidStr)
+		if err != nil {
+			continue
+		}
+		outcome, tracked := outcomes[tid]
+		switch {
+		case status == heuristicMixed || status == heuristicConfirmed:
+			if tracked {
+				outcome.Mixed = status == heuristicMixed
+			}
+		case strings.HasPrefix(status, heuristicPrefix):
+			outcomes[tid] = &HeuristicOutcome{TransactionID: tid, Participant: n.Name, Heuristic: strings.TrimPrefix(status, heuristicPrefix)}
+			order = append(order, tid)
+		case tracked && (status == "COMMIT" || status == "ABORT"):
+			outcome.Actual = status
+		}
+	}
+	for _, tid := range order {
+		res.Outcomes = append(res.Outcomes, *outcomes[tid])
+	}
+	return scanner.Err()
+}
+
+// RPC: Transactions this participant has voted to commit but not yet learned the decision for
+type ListInDoubtRequest struct{}
+
+type ListInDoubtResponse struct {
+	TransactionIDs []uuid.UUID `json:"transactionIds"`
+}
+
+func (n *Node) ListInDoubt(req *ListInDoubtRequest, res *ListInDoubtResponse) error {
+	if n.Type != "Participant" {
+		return fmt.Errorf("this node is not a participant")
+	}
+	prepared, err := n.resource.Recover()
+	if err != nil {
+		return err
+	}
+	res.TransactionIDs = prepared
+	return nil
+}
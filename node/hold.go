@@ -0,0 +1,163 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// A hold reserves part of an account's balance for a later debit. Held amounts
+// count against the available balance, so other debits cannot spend them, but
+// the ledger balance only changes once the hold is captured by a transaction.
+// Holds not captured or released before they expire are dropped.
+
+type Hold struct {
+	HoldID  uuid.UUID `json:"holdId"`
+	Account string    `json:"account"`
+	Amount  Decimal   `json:"amount"`
+	Created time.Time `json:"created"`
+	Expires time.Time `json:"expires"`
+}
+
+func (h Hold) expired(now time.Time) bool {
+	return !now.Before(h.Expires)
+}
+
+const (
+	defaultHoldTTL = 15 * time.Minute
+	maxHoldTTL     = 7 * 24 * time.Hour
+)
+
+// Optional interface for resources that can hold amounts
+type HoldManager interface {
+	PlaceHold(account string, amount Decimal, ttl time.Duration) (Hold, error)
+	ReleaseHold(holdID uuid.UUID) (Hold, error)
+	// Hold with holdID, if it has not expired
+	FindHold(holdID uuid.UUID) (Hold, error)
+	// Holds on account that have not expired, oldest first
+	Holds(account string) ([]Hold, error)
+	// Balance less the holds on it
+	Available(account string) (Decimal, error)
+}
+
+// RPC: Reserve an amount on an account of this participant
+type PlaceHoldRequest struct {
+	Account string        `json:"account"`
+	Amount  Decimal       `json:"amount"`
+	TTL     time.Duration `json:"ttl"` // Zero for the default
+}
+
+type PlaceHoldResponse struct {
+	Hold Hold `json:"hold"`
+}
+
+func (n *Node) PlaceHold(req *PlaceHoldRequest, res *PlaceHoldResponse) error {
+	holds, err := n.holdManager()
+	if err != nil {
+		return err
+	}
+	ttl := req.TTL
+	if ttl == 0 {
+		ttl = defaultHoldTTL
+	}
+	if ttl < 0 || ttl > maxHoldTTL {
+		return fmt.Errorf("hold TTL must be between 0 and %s", maxHoldTTL)
+	}
+	hold, err := holds.PlaceHold(accountOrDefault(req.Account), req.Amount, ttl)
+	if err != nil {
+		n.Print(fmt.Sprintf(colorRed+"Hold of %s on %s refused (%v)"+colorReset, req.Amount, accountOrDefault(req.Account), err))
+		return err
+	}
+	res.Hold = hold
+	n.Print(fmt.Sprintf("Hold %s of %s on %s until %s", hold.HoldID, hold.Amount, hold.Account, hold.Expires.Format(time.TimeOnly)))
+	return nil
+}
+
+// RPC: Drop a hold without debiting the account
+type ReleaseHoldRequest struct {
+	HoldID uuid.UUID `json:"holdId"`
+}
+
+type ReleaseHoldResponse struct{}
+
+func (n *Node) ReleaseHold(req *ReleaseHoldRequest, res *ReleaseHoldResponse) error {
+	holds, err := n.holdManager()
+	if err != nil {
+		return err
+	}
+	hold, err := holds.ReleaseHold(req.HoldID)
+	if err != nil {
+		return err
+	}
+	n.Print(fmt.Sprintf("Released hold %s of %s on %s", hold.HoldID, hold.Amount, hold.Account))
+	return nil
+}
+
+// RPC: Holds on an account of this participant
+type ListHoldsRequest struct {
+	Account string `json:"account"`
+}
+
+type ListHoldsResponse struct {
+	Holds []Hold `json:"holds"`
+}
+
+func (n *Node) ListHolds(req *ListHoldsRequest, res *ListHoldsResponse) error {
+	holds, err := n.holdManager()
+	if err != nil {
+		return err
+	}
+	res.Holds, err = holds.Holds(accountOrDefault(req.Account))
+	return err
+}
+
+// RPC: Capture a hold, transferring its amount to another account with two-phase commit
+type CaptureHoldRequest struct {
+	HoldID uuid.UUID   `json:"holdId"`
+	Amount Decimal     `json:"amount"` // Zero for the whole hold, any remainder is released
+	To     Transaction `json:"to"`
+}
+
+type CaptureHoldResponse struct{}
+
+func (n *Node) CaptureHold(req *CaptureHoldRequest, res *CaptureHoldResponse) error {
+	holds, err := n.holdManager()
+	if err != nil {
+		return err
+	}
+	hold, err := holds.FindHold(req.HoldID)
+	if err != nil {
+		return err
+	}
+	amount := req.Amount
+	if amount.IsZero() {
+		amount = hold.Amount
+	}
+	currency, err := n.accountCurrency(hold.Account)
+	if err != nil {
+		return err
+	}
+	debit := Transaction{Addr: n.Addr, Name: n.Name, Account: hold.Account, Operation: OpSubtract, Amount: amount, Currency: currency, Hold: req.HoldID}
+	credit := req.To
+	credit.Operation, credit.Amount, credit.Currency = OpAdd, amount, currency
+
+	coordReq := ParticipantCoordinatorTransactionRequest{Transactions: []Transaction{debit, credit}}
+	var coordRes ParticipantCoordinatorTransactionResponse
+	if err := n.callCoordinator("Node.ParticipantCoordinatorTransaction", &coordReq, &coordRes); err != nil {
+		return fmt.Errorf("coordinator error: %v", err)
+	}
+	n.Print(fmt.Sprintf("Captured %s of hold %s for %s/%s", amount, req.HoldID, credit.Name, accountOrDefault(credit.Account)))
+	return nil
+}
+
+func (n *Node) holdManager() (HoldManager, error) {
+	if n.Type != "Participant" {
+		return nil, fmt.Errorf("this node is not a participant")
+	}
+	holds, ok := n.resource.(HoldManager)
+	if !ok {
+		return nil, fmt.Errorf("resource does not support holds")
+	}
+	return holds, nil
+}
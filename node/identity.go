@@ -0,0 +1,205 @@
+package node
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// Over TLS every call comes with the name in the caller's verified
+// certificate. Requests that claim to come from a node, or change the
+// cluster's membership, embed callerIdentity so their methods can refuse a
+// caller that is not who it claims to be. The identity is set by the server
+// after decoding and never travels on the wire. Plaintext and in-process calls
+// carry none and are not checked.
+
+type callerIdentity struct {
+	caller string
+	known  bool
+}
+
+type callerSetter interface {
+	setCaller(name string)
+}
+
+func (c *callerIdentity) setCaller(name string) {
+	c.caller, c.known = name, true
+}
+
+// Refuse the call unless it comes from one of names, or its caller is unknown
+func (c *callerIdentity) checkCaller(names ...string) error {
+	if !c.known || slices.Contains(names, c.caller) {
+		return nil
+	}
+	return fmt.Errorf("caller %q may not act as %s", c.caller, strings.Join(names, " or "))
+}
+
+// Refuse a call claiming to come from the coordinator at addr unless addr is
+// a peer and the caller's certificate is the one that node presents
+func (n *Node) checkPeerCaller(c *callerIdentity, addr string) error {
+	if !c.known {
+		return nil
+	}
+	if !slices.Contains(n.c_peers, addr) {
+		return fmt.Errorf("%s is not a coordinator of this cluster", addr)
+	}
+	return n.verifyNodeAt(addr, c.caller)
+}
+
+// Check that the node at addr has a certificate for name. The name is kept
+// with the connection to addr once dialling it proved it, so later calls are
+// checked without dialling and redials must present it too.
+func (n *Node) verifyNodeAt(addr string, name string) error {
+	m := &n.connections
+	m.mutex.Lock()
+	known := m.get(addr).Name
+	m.mutex.Unlock()
+	if known == name {
+		return nil
+	}
+	if known != "" {
+		return fmt.Errorf("%s is %s, not %s", addr, known, name)
+	}
+	client, err := n.transport.DialNode(addr, name)
+	if err != nil {
+		return fmt.Errorf("%s is not %s: %v", addr, name, err)
+	}
+	client.Close()
+	m.mutex.Lock()
+	if conn := m.get(addr); conn.Name == "" {
+		conn.Name = name
+	}
+	m.mutex.Unlock()
+	return nil
+}
+
+// Name in the certificate of the node at the other end of conn, once the TLS
+// handshake is done. Not known on plaintext connections.
+func peerName(conn net.Conn) (string, bool, error) {
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return "", false, nil
+	}
+	if err := tlsConn.Handshake(); err != nil {
+		return "", false, err
+	}
+	certificates := tlsConn.ConnectionState().PeerCertificates
+	if len(certificates) == 0 {
+		return "", false, errors.New("no certificate presented")
+	}
+	return certificates[0].Subject.CommonName, true, nil
+}
+
+// net/rpc codec giving the caller's name to the requests it decodes
+type callerCodec struct {
+	rpc.ServerCodec
+	caller string
+}
+
+func (c callerCodec) ReadRequestBody(body any) error {
+	if err := c.ServerCodec.ReadRequestBody(body); err != nil {
+		return err
+	}
+	if req, ok := body.(callerSetter); ok {
+		req.setCaller(c.caller)
+	}
+	return nil
+}
+
+// The gob codec rpc.ServeConn uses, which net/rpc does not export
+type gobServerCodec struct {
+	rwc    io.ReadWriteCloser
+	dec    *gob.Decoder
+	enc    *gob.Encoder
+	encBuf *bufio.Writer
+	closed bool
+}
+
+func newGobServerCodec(conn io.ReadWriteCloser) *gobServerCodec {
+	buf := bufio.NewWriter(conn)
+	return &gobServerCodec{rwc: conn, dec: gob.NewDecoder(conn), enc: gob.NewEncoder(buf), encBuf: buf}
+}
+
+func (c *gobServerCodec) ReadRequestHeader(r *rpc.Request) error {
+	return c.dec.Decode(r)
+}
+
+func (c *gobServerCodec) ReadRequestBody(body any) error {
+	return c.dec.Decode(body)
+}
+
+func (c *gobServerCodec) WriteResponse(r *rpc.Response, body any) error {
+	if err := c.enc.Encode(r); err != nil {
+		if c.encBuf.Flush() == nil {
+			c.Close()
+		}
+		return err
+	}
+	if err := c.enc.Encode(body); err != nil {
+		if c.encBuf.Flush() == nil {
+			c.Close()
+		}
+		return err
+	}
+	return c.encBuf.Flush()
+}
+
+func (c *gobServerCodec) Close() error {
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	return c.rwc.Close()
+}
+
+// Connection handed to the gRPC server with the caller's name, which
+// callerCredentials passes on to the handlers as the peer's AuthInfo
+type callerConn struct {
+	net.Conn
+	caller string
+}
+
+type callerInfo struct {
+	credentials.CommonAuthInfo
+	caller string
+}
+
+func (callerInfo) AuthType() string {
+	return "tls"
+}
+
+// Server credentials for connections already through TLS, or plaintext ones
+type callerCredentials struct{}
+
+func (callerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if c, ok := conn.(callerConn); ok {
+		return conn, callerInfo{CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity}, caller: c.caller}, nil
+	}
+	return conn, nil, nil
+}
+
+func (callerCredentials) ClientHandshake(context.Context, string, net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("server credentials only")
+}
+
+func (callerCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "tls"}
+}
+
+func (c callerCredentials) Clone() credentials.TransportCredentials {
+	return c
+}
+
+func (callerCredentials) OverrideServerName(string) error {
+	return nil
+}
@@ -0,0 +1,60 @@
+package node
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+)
+
+// Besides gob and gRPC on its address, a node can serve net/rpc's JSON-RPC 1.0
+// codec on a second listener, for programs not written in Go. A call is one
+// JSON object per request on a plain TCP connection, TLS when the node has
+// credentials:
+//
+//	{"method": "Node.GetBalance", "params": [{"account": "main"}], "id": 1}
+//	{"id": 1, "result": {"balance": "10.00", "available": "10.00", "currency": "USD"}, "error": null}
+//
+// The methods, their parameters and results are listed in the schema
+// JSONRPCSchema describes, written to docs/jsonrpc-schema.json. Field names
+// are fixed by json tags, the same as the JSON names of the proto fields.
+
+// Serve JSON-RPC on addr until the listener fails
+func (n *Node) ServeJSONRPC(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("error starting JSON-RPC server: %v", err)
+	}
+	defer listener.Close()
+	if n.credentials != nil {
+		listener = tls.NewListener(listener, n.credentials.serverConfig())
+	}
+	server := rpc.NewServer()
+	if err := server.Register(n); err != nil {
+		return fmt.Errorf("error registering JSON-RPC server: %v", err)
+	}
+	n.Print(fmt.Sprintf("Serving JSON-RPC on %s", addr))
+	for {
+		conn, err := listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		go func() {
+			caller, known, err := peerName(conn)
+			if err != nil {
+				conn.Close()
+				return
+			}
+			if known {
+				server.ServeCodec(callerCodec{ServerCodec: jsonrpc.NewServerCodec(conn), caller: caller})
+			} else {
+				server.ServeCodec(jsonrpc.NewServerCodec(conn))
+			}
+		}()
+	}
+}
@@ -0,0 +1,123 @@
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// One committed change to an account
+type LedgerEntry struct {
+	EntryID       uuid.UUID       `json:"entryId"`
+	TransactionID uuid.UUID       `json:"transactionId"`
+	Account       string          `json:"account"`
+	Operation     OperationType   `json:"operation"`
+	Amount        Decimal         `json:"amount"`
+	Before        Decimal         `json:"before"`
+	After         Decimal         `json:"after"`
+	Counterparts  []string        `json:"counterparts"` // Other participants in the transaction
+	External      bool            `json:"external"`     // Deposit or withdrawal
+	Currency      string          `json:"currency"`
+	Conversion    *Conversion     `json:"conversion"` // Set when the amount was converted from another currency
+	Details       *AccountDetails `json:"details"`    // Set by lifecycle changes, the details after them
+	Hold          uuid.UUID       `json:"hold"`       // Hold captured by a debit, nil if none
+	Time          time.Time       `json:"time"`
+}
+
+// Optional interface for resources keeping a history of committed changes
+type HistoryKeeper interface {
+	// Entries for account, oldest first
+	History(account string) ([]LedgerEntry, error)
+}
+
+const ledgerKey = "ledger.jsonl"
+
+// Add entries to the ledger kept in storage, one JSON record each
+func appendLedger(storage Storage, entries []LedgerEntry) error {
+	for _, entry := range entries {
+		record, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if err := storage.Append(ledgerKey, record); err != nil {
+			return fmt.Errorf("error writing ledger: %v", err)
+		}
+	}
+	return nil
+}
+
+// Every entry in the ledger kept in storage, skipping a record cut short by a crash
+func readLedger(storage Storage) ([]LedgerEntry, error) {
+	data, _, err := storage.Get(ledgerKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reading ledger: %v", err)
+	}
+	var entries []LedgerEntry
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		var entry LedgerEntry
+		if len(line) == 0 || json.Unmarshal(line, &entry) != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+const defaultHistoryLimit = 50
+
+// RPC: Statement of an account, oldest entry first
+type GetHistoryRequest struct {
+	Account string    `json:"account"`
+	Since   time.Time `json:"since"` // Zero for no lower bound
+	Until   time.Time `json:"until"` // Exclusive, zero for no upper bound
+	Offset  int       `json:"offset"`
+	Limit   int       `json:"limit"` // Zero for the default page size
+}
+
+type GetHistoryResponse struct {
+	Entries []LedgerEntry `json:"entries"`
+	Total   int           `json:"total"` // Entries matching the filters over all pages
+}
+
+func (n *Node) GetHistory(req *GetHistoryRequest, res *GetHistoryResponse) error {
+	if n.Type != "Participant" {
+		return fmt.Errorf("this node is not a participant")
+	}
+	keeper, ok := n.resource.(HistoryKeeper)
+	if !ok {
+		return fmt.Errorf("resource does not keep history")
+	}
+	if req.Offset < 0 || req.Limit < 0 {
+		return fmt.Errorf("offset and limit must not be negative")
+	}
+	entries, err := keeper.History(accountOrDefault(req.Account))
+	if err != nil {
+		return err
+	}
+
+	var matching []LedgerEntry
+	for _, entry := range entries {
+		if !req.Since.IsZero() && entry.Time.Before(req.Since) {
+			continue
+		}
+		if !req.Until.IsZero() && !entry.Time.Before(req.Until) {
+			continue
+		}
+		matching = append(matching, entry)
+	}
+	res.Total = len(matching)
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultHistoryLimit
+	}
+	if req.Offset >= len(matching) {
+		return nil
+	}
+	end := min(req.Offset+limit, len(matching))
+	res.Entries = matching[req.Offset:end]
+	return nil
+}
@@ -0,0 +1,390 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// A participant joining for the first time is issued an ID, which it keeps and
+// presents when it joins again, possibly from a new address. Names stay unique:
+// a node asking for a name held by another member is refused. Every node with
+// participants keeps its membership in node_log, as participants keep their
+// ID, so both survive restarts.
+
+type membershipState struct {
+	ID           uuid.UUID // Issued to this participant, nil until it joins
+	Participants []ConnectionData
+	Routes       []RouteData
+}
+
+const membershipKey = "members"
+
+// Must hold c_membershipMutex
+func (n *Node) persistMembership() {
+	state := membershipState{ID: n.p_memberID}
+	for _, data := range n.c_participantClients {
+		state.Participants = append(state.Participants, *data)
+	}
+	for _, route := range n.c_routes {
+		state.Routes = append(state.Routes, *route)
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		n.Print(fmt.Sprintf("Error encoding membership: %v", err))
+		return
+	}
+	if err := n.state.Put(membershipKey, data); err != nil {
+		n.Print(fmt.Sprintf("Error writing membership: %v", err))
+	}
+}
+
+func (n *Node) loadMembership() {
+	data, ok, err := n.state.Get(membershipKey)
+	if err != nil || !ok {
+		return
+	}
+	var state membershipState
+	if err := json.Unmarshal(data, &state); err != nil {
+		n.Print(fmt.Sprintf("Error decoding membership: %v", err))
+		return
+	}
+	n.c_membershipMutex.Lock()
+	defer n.c_membershipMutex.Unlock()
+	n.p_memberID = state.ID
+	n.c_participantClients = make(map[string]*ConnectionData)
+	for _, data := range state.Participants {
+		n.c_participantClients[data.Name] = &data
+		// Down until it answers a heartbeat or joins again
+		if supportsFeature(data.Version, data.Features, FeatureHeartbeat) {
+			n.watch(data.Name, data.Address)
+		}
+	}
+	for _, route := range state.Routes {
+		n.c_routes[route.Name] = &route
+	}
+	n.Print(fmt.Sprintf("Restored %d participants and %d routes", len(state.Participants), len(state.Routes)))
+}
+
+// Member req joins as, issuing it an ID if it is new. Must hold c_membershipMutex.
+func (n *Node) admit(req *AddParticipantRequest) (*ConnectionData, error) {
+	if route, ok := n.c_routes[req.Name]; ok {
+		return nil, fmt.Errorf("%s is already reachable through %s", req.Name, route.Via)
+	}
+	existing, taken := n.c_participantClients[req.Name]
+	if req.ID == uuid.Nil {
+		if taken && existing.Address == req.Addr {
+			// The same node registering again
+			return existing, nil
+		}
+		if taken {
+			return nil, fmt.Errorf("name %s is taken by member %s at %s", req.Name, existing.ID, existing.Address)
+		}
+		data := &ConnectionData{ID: uuid.New(), Name: req.Name, Address: req.Addr, Joined: time.Now()}
+		n.c_participantClients[req.Name] = data
+		n.Print(fmt.Sprintf("Added %s to participant list as %s", req.Name, data.ID))
+		return data, nil
+	}
+
+	if taken && existing.ID != req.ID {
+		return nil, fmt.Errorf("name %s is taken by member %s", req.Name, existing.ID)
+	}
+	for _, data := range n.c_participantClients {
+		if data.ID == req.ID && data.Name != req.Name {
+			return nil, fmt.Errorf("member %s joined as %s, not %s", req.ID, data.Name, req.Name)
+		}
+	}
+	if taken {
+		if existing.Address != req.Addr {
+			n.Print(fmt.Sprintf("%s rejoined at %s, was at %s", req.Name, req.Addr, existing.Address))
+			existing.Address = req.Addr
+		}
+		return existing, nil
+	}
+	// Issued by another coordinator, or joining again after leaving
+	data := &ConnectionData{ID: req.ID, Name: req.Name, Address: req.Addr, Joined: time.Now()}
+	n.c_participantClients[req.Name] = data
+	n.Print(fmt.Sprintf("Added %s to participant list as %s", req.Name, data.ID))
+	return data, nil
+}
+
+// Direct participant name, if it is one
+func (n *Node) member(name string) (ConnectionData, bool) {
+	n.c_membershipMutex.RLock()
+	defer n.c_membershipMutex.RUnlock()
+	data, ok := n.c_participantClients[name]
+	if !ok {
+		return ConnectionData{}, false
+	}
+	return *data, true
+}
+
+// Transactions not yet acknowledged by name and unfinished sagas it takes
+// part in, directly or as the sub-coordinator of a participant involved. Must
+// hold c_membershipMutex.
+func (n *Node) inFlight(name string) []string {
+	below := make(map[string]bool)
+	for routeName, route := range n.c_routes {
+		if route.Via == name {
+			below[routeName] = true
+		}
+	}
+	involves := func(tx Transaction) bool {
+		return tx.Name == name || below[tx.Name]
+	}
+
+	var ids []string
+	for _, outcome := range transactionOutcomes(n.logEntries()) {
+		if outcome.finished() {
+			continue
+		}
+		// Once acknowledged, only the direct participants the decision is
+		// still to reach
+		if outcome.Acknowledged {
+			if slices.Contains(outcome.Unacknowledged, name) {
+				ids = append(ids, outcome.TransactionID.String())
+			}
+			continue
+		}
+		if slices.ContainsFunc(outcome.Transactions, involves) {
+			ids = append(ids, outcome.TransactionID.String())
+		}
+	}
+
+	// Prepared through this node as a sub-coordinator
+	n.c_subtreesMutex.Lock()
+	for transactionID, subtree := range n.c_subtrees {
+		if slices.Contains(subtree.Pending, name) {
+			ids = append(ids, transactionID.String())
+		}
+	}
+	n.c_subtreesMutex.Unlock()
+
+	for _, saga := range sagaStates(n.logEntries()) {
+		if sagaFinished(saga.Status) {
+			continue
+		}
+		for _, step := range saga.Steps {
+			if involves(step.Transaction) {
+				ids = append(ids, "saga "+saga.SagaID.String())
+				break
+			}
+		}
+	}
+	return ids
+}
+
+// RPC: Remove a participant, refused while it takes part in transactions that
+// are not finished
+type RemoveParticipantRequest struct {
+	ID uuid.UUID `json:"id"`
+	callerIdentity
+}
+
+type RemoveParticipantResponse struct{}
+
+func (n *Node) RemoveParticipant(req *RemoveParticipantRequest, res *RemoveParticipantResponse) error {
+	// Held from the check to the removal: a transaction prepared after the
+	// check finds the member gone and aborts
+	n.c_membershipMutex.Lock()
+	var data *ConnectionData
+	for _, member := range n.c_participantClients {
+		if member.ID == req.ID {
+			data = member
+		}
+	}
+	if data == nil {
+		n.c_membershipMutex.Unlock()
+		return fmt.Errorf("member %s not found", req.ID)
+	}
+	// The member leaving, or an operator
+	if err := req.checkCaller(data.Name, ClientCertName); err != nil {
+		n.c_membershipMutex.Unlock()
+		return err
+	}
+	if ids := n.inFlight(data.Name); len(ids) > 0 {
+		n.c_membershipMutex.Unlock()
+		return fmt.Errorf("%s has %d transactions in flight: %v", data.Name, len(ids), ids)
+	}
+	delete(n.c_participantClients, data.Name)
+	// Participants below it cannot be reached any more
+	var unreachable []string
+	for routeName, route := range n.c_routes {
+		if route.Via == data.Name {
+			delete(n.c_routes, routeName)
+			unreachable = append(unreachable, routeName)
+		}
+	}
+	n.persistMembership()
+	n.c_membershipMutex.Unlock()
+	n.unwatch(data.Name)
+	n.Print(fmt.Sprintf("Removed %s (%s) from participant list", data.Name, data.ID))
+
+	if n.Type == "Participant" {
+		n.withdrawRoute(data.Name)
+		for _, routeName := range unreachable {
+			n.withdrawRoute(routeName)
+		}
+	}
+	return nil
+}
+
+// RPC: Leave every coordinator, refused while this participant has
+// transactions in doubt. It keeps its ID and can join again.
+type LeaveRequest struct{}
+
+type LeaveResponse struct{}
+
+func (n *Node) Leave(req *LeaveRequest, res *LeaveResponse) error {
+	if n.Type != "Participant" {
+		return fmt.Errorf("this node is not a participant")
+	}
+	prepared, err := n.resource.Recover()
+	if err != nil {
+		return err
+	}
+	if len(prepared) > 0 {
+		return fmt.Errorf("%d transactions in doubt: %v", len(prepared), prepared)
+	}
+	n.c_membershipMutex.RLock()
+	id := n.p_memberID
+	n.c_membershipMutex.RUnlock()
+	if id == uuid.Nil {
+		return fmt.Errorf("not a member of any coordinator")
+	}
+
+	n.p_coordinatorMutex.Lock()
+	coordinators := n.p_coordinatorAddrs
+	n.p_coordinatorMutex.Unlock()
+	for _, addr := range coordinators {
+		var removeRes RemoveParticipantResponse
+		err := n.call(addr, "Node.RemoveParticipant", &RemoveParticipantRequest{ID: id}, &removeRes)
+		if notSent(err) {
+			n.Print(fmt.Sprintf("Error leaving coordinator %s: %v", addr, err))
+			continue
+		}
+		if err != nil && !strings.Contains(err.Error(), "not found") {
+			return fmt.Errorf("coordinator %s refused: %v", addr, err)
+		}
+	}
+	n.p_coordinatorMutex.Lock()
+	n.p_left = true
+	n.p_coordinatorMutex.Unlock()
+	n.Print(fmt.Sprintf("Left coordinators as %s", id))
+	return nil
+}
+
+// Join the cluster addrs belong to, trying until one of them answers. Given a
+// coordinator the participant joins every coordinator it lists, given a
+// participant it joins that one as its sub-coordinator.
+func (n *Node) Join(addrs []string) error {
+	for {
+		for _, addr := range addrs {
+			var info GetInfoResponse
+			err := n.call(addr, "Node.GetInfo", &GetInfoRequest{}, &info)
+			if notSent(err) {
+				n.Print(fmt.Sprintf("Cannot reach %s to join: %v", addr, err))
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			coordinators := []string{info.Addr}
+			if info.Type == "Coordinator" {
+				var nodes ListNodesResponse
+				if err := n.call(addr, "Node.ListNodes", &ListNodesRequest{}, &nodes); err != nil {
+					return err
+				}
+				coordinators = nil
+				for _, node := range nodes.Nodes {
+					if node.Type == "Coordinator" {
+						coordinators = append(coordinators, node.Addr)
+					}
+				}
+			}
+			return n.ParticipantConnectToCoordinator(&ParticipantConnectToCoordinatorRequest{Addrs: coordinators}, &ParticipantConnectToCoordinatorResponse{})
+		}
+		time.Sleep(rejoinAfter)
+	}
+}
+
+// A coordinator that stops sending heartbeats was unreachable, or restarted
+// without this participant, which then joins it again
+const rejoinAfter = livenessDownAfter
+
+func (n *Node) runRejoin() {
+	for {
+		time.Sleep(livenessHeartbeatInterval)
+		var due []string
+		n.p_coordinatorMutex.Lock()
+		if !n.p_left {
+			for _, addr := range n.p_coordinatorAddrs {
+				// Coordinators from before heartbeats never send one
+				if protocol, ok := n.p_coordinatorProtocols[addr]; ok && !supportsFeature(protocol.Version, protocol.Features, FeatureHeartbeat) {
+					continue
+				}
+				if time.Since(n.p_coordinatorSeen[addr]) > rejoinAfter {
+					due = append(due, addr)
+					// Attempted again after another rejoinAfter at the earliest
+					n.p_coordinatorSeen[addr] = time.Now()
+				}
+			}
+		}
+		n.p_coordinatorMutex.Unlock()
+
+		for _, addr := range due {
+			if err := n.joinCoordinator(addr); err != nil {
+				n.Print(fmt.Sprintf("Error rejoining coordinator %s: %v", addr, err))
+				continue
+			}
+			n.Print(fmt.Sprintf("Rejoined coordinator %s", addr))
+			n.announceRoutes()
+		}
+	}
+}
+
+type NodeInfo struct {
+	Name string `json:"name"` // Empty for a coordinator that cannot be reached
+	Addr string `json:"addr"`
+	Type string `json:"type"`
+}
+
+// RPC: Every node of the cluster, coordinators first
+type ListNodesRequest struct{}
+
+type ListNodesResponse struct {
+	Nodes []NodeInfo `json:"nodes"`
+}
+
+func (n *Node) ListNodes(req *ListNodesRequest, res *ListNodesResponse) error {
+	if n.Type != "Coordinator" {
+		return n.callCoordinator("Node.ListNodes", req, res)
+	}
+	res.Nodes = append(res.Nodes, NodeInfo{Name: n.Name, Addr: n.Addr, Type: n.Type})
+	for _, peer := range n.c_peers {
+		var info GetInfoResponse
+		if err := n.callPeer(peer, "Node.GetInfo", &GetInfoRequest{}, &info); err != nil {
+			info = GetInfoResponse{Addr: peer, Type: "Coordinator"}
+		}
+		res.Nodes = append(res.Nodes, NodeInfo{Name: info.Name, Addr: info.Addr, Type: info.Type})
+	}
+	var participants ListParticipantsResponse
+	if err := n.ListParticipants(&ListParticipantsRequest{}, &participants); err != nil {
+		return err
+	}
+	for i, name := range participants.Names {
+		res.Nodes = append(res.Nodes, NodeInfo{Name: name, Addr: participants.Addresses[i], Type: "Participant"})
+	}
+	sort.SliceStable(res.Nodes, func(i, j int) bool {
+		if res.Nodes[i].Type != res.Nodes[j].Type {
+			return res.Nodes[i].Type == "Coordinator"
+		}
+		return res.Nodes[i].Name < res.Nodes[j].Name
+	})
+	return nil
+}
@@ -0,0 +1,109 @@
+package node
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestAdmit(t *testing.T) {
+	n := &Node{
+		Type:                 "Coordinator",
+		Name:                 "C1",
+		c_participantClients: make(map[string]*ConnectionData),
+		c_routes:             map[string]*RouteData{"D": {Name: "D", Via: "B"}},
+	}
+	issuedElsewhere := uuid.New()
+	ids := make(map[string]uuid.UUID) // Issued to each member so far
+
+	// Run in order against the same membership
+	tests := []struct {
+		name    string
+		req     AddParticipantRequest
+		useID   string // Join with the ID issued to this member
+		wantErr bool
+		wantNew bool // A new ID is issued rather than an existing one kept
+		wantID  uuid.UUID
+	}{
+		{name: "first join", req: AddParticipantRequest{Name: "A", Addr: "a:1"}, wantNew: true},
+		{name: "same node registering again", req: AddParticipantRequest{Name: "A", Addr: "a:1"}},
+		{name: "name taken, no ID", req: AddParticipantRequest{Name: "A", Addr: "a:2"}, wantErr: true},
+		{name: "rejoin at a new address", req: AddParticipantRequest{Name: "A", Addr: "a:2"}, useID: "A"},
+		{name: "name taken by another ID", req: AddParticipantRequest{Name: "A", Addr: "a:3", ID: uuid.New()}, wantErr: true},
+		{name: "second member", req: AddParticipantRequest{Name: "B", Addr: "b:1"}, wantNew: true},
+		{name: "ID reused under another name", req: AddParticipantRequest{Name: "E", Addr: "e:1"}, useID: "A", wantErr: true},
+		{name: "ID reused by another member's name", req: AddParticipantRequest{Name: "B", Addr: "b:1"}, useID: "A", wantErr: true},
+		{name: "issued by another coordinator", req: AddParticipantRequest{Name: "C", Addr: "c:1", ID: issuedElsewhere}, wantID: issuedElsewhere},
+		{name: "reachable through a route", req: AddParticipantRequest{Name: "D", Addr: "d:1"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			if tt.useID != "" {
+				req.ID = ids[tt.useID]
+			}
+			data, err := n.admit(&req)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("admitted as %s, want an error", data.ID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			previous, known := ids[req.Name]
+			switch {
+			case tt.wantNew && (known || data.ID == uuid.Nil):
+				t.Fatalf("kept ID %s, want a new one", data.ID)
+			case tt.wantID != uuid.Nil && data.ID != tt.wantID:
+				t.Fatalf("admitted as %s, want %s", data.ID, tt.wantID)
+			case !tt.wantNew && tt.wantID == uuid.Nil && data.ID != previous:
+				t.Fatalf("admitted as %s, want the ID issued before, %s", data.ID, previous)
+			}
+			if data.Address != req.Addr {
+				t.Fatalf("address %s, want %s", data.Address, req.Addr)
+			}
+			ids[req.Name] = data.ID
+		})
+	}
+	if len(n.c_participantClients) != 3 {
+		t.Fatalf("%d members, want 3", len(n.c_participantClients))
+	}
+}
+
+func TestInFlight(t *testing.T) {
+	undecided, unacknowledged, pending, done := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	n := &Node{
+		Type:     "Coordinator",
+		c_routes: map[string]*RouteData{"D": {Name: "D", Via: "B"}},
+		c_log: []DecisionEntry{
+			{Type: "PREPARE", TransactionID: undecided, Transactions: []Transaction{{Name: "D"}}},
+			{Type: "PREPARE", TransactionID: unacknowledged, Transactions: []Transaction{{Name: "A"}, {Name: "B"}}},
+			{Type: "COMMIT", TransactionID: unacknowledged},
+			{Type: "ACK", TransactionID: unacknowledged, Unacknowledged: []string{"A"}},
+			{Type: "PREPARE", TransactionID: pending, Transactions: []Transaction{{Name: "C"}}},
+			{Type: "ABORT", TransactionID: pending},
+			{Type: "PREPARE", TransactionID: done, Transactions: []Transaction{{Name: "A"}, {Name: "C"}}},
+			{Type: "COMMIT", TransactionID: done},
+			{Type: "ACK", TransactionID: done},
+		},
+	}
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{name: "A", want: []string{unacknowledged.String()}}, // Still owed the decision
+		{name: "B", want: []string{undecided.String()}},      // Sub-coordinator of D
+		{name: "C", want: []string{pending.String()}},        // Decided, not yet sent
+		{name: "E", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := n.inFlight(tt.name); !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,10 +3,10 @@ package node
 import (
 	"fmt"
 	"net"
-	"net/rpc"
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -19,9 +19,12 @@ const (
 )
 
 type ConnectionData struct {
-	Name    string
-	Address string
-	Client  *rpc.Client
+	ID       uuid.UUID // Issued when it first joined
+	Name     string
+	Address  string
+	Joined   time.Time
+	Version  int      // Protocol version announced when joining, 0 from before the handshake
+	Features []string // Announced when joining
 }
 
 type Node struct {
@@ -31,31 +34,105 @@ type Node struct {
 
 	// Coordinator Related
 	c_participantClients map[string]*ConnectionData
+	c_failureDetector    failureDetector
+	c_routes             map[string]*RouteData
+	c_membershipMutex    sync.RWMutex                // Guards c_participantClients, c_routes and p_memberID
+	c_subtrees           map[uuid.UUID]*subtreeState // Participants below prepared through this node, by transaction
+	c_subtreesMutex      sync.Mutex                  // Guards c_subtrees
+	c_sagas              sagaSet
+	c_rates              *RateTable   // From the rates file, to seed the decision log with
+	c_ratesMutex         sync.Mutex   // Held while changing the rates in the decision log
+	c_auditMutex         sync.RWMutex // Read locked by running transactions and sagas, write locked by an audit
+	c_peers              []string
+	c_raftMutex          sync.Mutex
+	c_role               string
+	c_term               int
+	c_votedFor           string
+	c_leaderAddr         string
+	c_snapshot           DecisionSnapshot // Compacted start of the decision log
+	c_log                []DecisionEntry  // Entries after the snapshot
+	c_commitIndex        int
+	c_nextIndex          map[string]int
+	c_matchIndex         map[string]int
+	c_lastHeartbeat      time.Time
+	c_electionTimeout    time.Duration
 
 	// Participant Related
-	p_coordinatorClient                *rpc.Client
+	p_coordinatorAddrs                 []string
+	p_coordinatorLeader                string
+	p_coordinatorMutex                 sync.Mutex
+	p_memberID                         uuid.UUID                         // Issued by the first coordinator joined
+	p_coordinatorSeen                  map[string]time.Time              // Last heartbeat from each coordinator
+	p_coordinatorProtocols             map[string]AddParticipantResponse // What each coordinator announced when joined
+	p_left                             bool                              // Not rejoining coordinators after leaving
+	resource                           ResourceManager
 	commitMutex                        sync.Mutex
-	promisedCommit                     bool
-	transactionNewBalance              float64
+	p_monitors                         map[uuid.UUID]chan struct{} // Stopping each prepared transaction's monitor, guarded by commitMutex
+	p_decisionsHeld                    chan struct{}               // Closed once an audit stops holding decisions back, guarded by commitMutex
 	sleepBeforeRespondingToCoordinator bool
 	sleepAfterRespondingToCoordinator  bool
 	rejectIncoming                     bool
-	stopMonitoring                     chan bool
+	transport                          Transport
+	credentials                        *Credentials // Serving over TLS when set
+	connections                        connectionManager
+	state                              Storage // Raft state and membership, kept in node_log
 }
 
-func NewParticipant(addr string, name string) (*Node, error) {
+// Storage for the state a node keeps across restarts, besides a
+// participant's balances
+func openNodeState(nodeType string, name string) (Storage, error) {
+	return NewFileStorage(filepath.Join("node_log", fmt.Sprintf("%s-%s", nodeType, name)))
+}
+
+// Participant backed by balances kept in the given storage backend, file
+// backed storage living in node_data
+func NewParticipant(addr string, name string, storageBackend string) (*Node, error) {
+	storage, err := OpenStorage(storageBackend, filepath.Join("node_data", fmt.Sprintf("Participant-%s", name)))
+	if err != nil {
+		return nil, err
+	}
+	resource, err := NewBalanceResource(storage)
+	if err != nil {
+		return nil, err
+	}
+	return NewParticipantWithResource(addr, name, resource)
+}
+
+// Participant backed by any transactional resource
+func NewParticipantWithResource(addr string, name string, resource ResourceManager) (*Node, error) {
+	state, err := openNodeState("Participant", name)
+	if err != nil {
+		return nil, err
+	}
 	return &Node{
-		Name: name,
-		Addr: addr,
-		Type: "Participant",
+		Name:      name,
+		Addr:      addr,
+		Type:      "Participant",
+		resource:  resource,
+		transport: rpcTransport{},
+		state:     state,
+
+		c_routes:   make(map[string]*RouteData),
+		c_subtrees: make(map[uuid.UUID]*subtreeState),
 	}, nil
 }
 
-func NewCoordinator(addr string) (*Node, error) {
+// Peers are the addresses of the other coordinators taking part in leader election
+func NewCoordinator(addr string, name string, peers []string) (*Node, error) {
+	state, err := openNodeState("Coordinator", name)
+	if err != nil {
+		return nil, err
+	}
 	return &Node{
-		Name: "C",
-		Addr: addr,
-		Type: "Coordinator",
+		Name:       name,
+		Addr:       addr,
+		Type:       "Coordinator",
+		c_routes:   make(map[string]*RouteData),
+		c_subtrees: make(map[uuid.UUID]*subtreeState),
+		c_sagas:    sagaSet{active: make(map[uuid.UUID]bool)},
+		c_peers:    peers,
+		transport:  rpcTransport{},
+		state:      state,
 	}, nil
 }
 
@@ -63,7 +140,7 @@ func (n *Node) Print(msg string) {
 	if n.Type == "Participant" {
 		fmt.Printf("[%s-%s]: %s\n", n.Type, n.Name, msg)
 	} else if n.Type == "Coordinator" {
-		fmt.Printf(colorBlue+"[%s]: %s\n"+colorReset, n.Type, msg)
+		fmt.Printf(colorBlue+"[%s-%s]: %s\n"+colorReset, n.Type, n.Name, msg)
 	} else {
 		fmt.Printf(colorRed+"[??]: %s\n"+colorReset, msg)
 	}
@@ -82,59 +159,63 @@ func (n *Node) Start() {
 		}
 	}
 
+	var prepared []uuid.UUID
 	if n.Type == "Participant" {
-		// Create a data file for node
-		filename := fmt.Sprintf("%s-%s.data", n.Type, n.Name)
-		filepath := filepath.Join(nodeDataDir, filename)
-		file, err := os.Create(filepath)
-		if err != nil {
-			n.Print(fmt.Sprintf("Error creating data file: %v", err))
+		// Promises left over from before a restart wait for the coordinator to resend its decision
+		var err error
+		if prepared, err = n.resource.Recover(); err != nil {
+			n.Print(fmt.Sprintf("Error recovering prepared transactions: %v", err))
 			return
 		}
-		_, writeErr := file.WriteString("0")
-		if writeErr != nil {
-			n.Print(fmt.Sprintf("Error writing '0' to file: %v", writeErr))
-			file.Close() // Close the file in case of an error
-			return
-		}
-		file.Close()
 	}
-	// Start RPC
+	if n.Type == "Coordinator" {
+		n.loadRaftState()
+		go n.runElectionTimer()
+	}
+	n.loadMembership()
+	n.loadSubtrees()
+	// Or is asked for it, as after voting, once the coordinators are known
+	n.commitMutex.Lock()
+	for _, transactionID := range prepared {
+		n.Print(fmt.Sprintf("In-doubt transaction %s awaiting decision", transactionID))
+		n.startMonitor(transactionID, nil)
+	}
+	n.commitMutex.Unlock()
+	// Watches whichever participants register, also with a sub-coordinator
+	go n.runFailureDetector()
+	if n.Type == "Participant" {
+		go n.runRejoin()
+	}
+	// Start RPC, serving both transports
 	listener, err := net.Listen("tcp", n.Addr)
 	if err != nil {
 		n.Print(fmt.Sprintf("Error starting RPC server: %v", err))
 		return
 	}
 	defer listener.Close()
-	rpcServer := rpc.NewServer()
-	err = rpcServer.Register(n)
-	if err != nil {
-		n.Print(fmt.Sprintf("Error registering RPC server: %v", err))
+	if err := n.serve(listener); err != nil {
+		n.Print(fmt.Sprintf("Error serving RPC: %v", err))
 	}
-	rpcServer.Accept(listener)
 }
 
 type PingRequest struct{}
 
 type PingResponse struct {
-	Message string
-	Name    string
+	Message string `json:"message"`
+	Name    string `json:"name"`
 }
 
 func (n *Node) Ping(req *PingRequest, res *PingResponse) error {
 	n.Print("Pinged")
 	res.Message = fmt.Sprintf("Pong from %s-%s", n.Type, n.Name)
 	res.Name = n.Type + "-" + n.Name
-	if n.Type == "Coordinator" {
-		res.Name = n.Type
-	}
 	return nil
 }
 
 type HealthCheckRequest struct{}
 
 type HealthCheckResponse struct {
-	Status string
+	Status string `json:"status"`
 }
 
 func (n *Node) HealthCheck(req *HealthCheckRequest, res *HealthCheckResponse) error {
@@ -145,15 +226,19 @@ func (n *Node) HealthCheck(req *HealthCheckRequest, res *HealthCheckResponse) er
 type GetInfoRequest struct{}
 
 type GetInfoResponse struct {
-	Name string
-	Addr string
-	Type string
+	Name       string   `json:"name"`
+	Addr       string   `json:"addr"`
+	Type       string   `json:"type"`
+	Version    int      `json:"version"` // Protocol version the node speaks
+	MinVersion int      `json:"minVersion"`
+	Features   []string `json:"features"`
 }
 
 func (n *Node) GetInfo(req *GetInfoRequest, res *GetInfoResponse) error {
 	res.Name = n.Name
 	res.Addr = n.Addr
 	res.Type = n.Type
+	res.Version, res.MinVersion, res.Features = ProtocolVersion, MinProtocolVersion, n.features()
 	return nil
 }
 
@@ -182,29 +267,46 @@ func (n *Node) LogTransaction(phase string, transactionID uuid.UUID) {
 
 type ListParticipantsRequest struct{}
 type ListParticipantsResponse struct {
-	Names     []string
-	Addresses []string
+	IDs       []uuid.UUID `json:"ids"` // Nil for participants behind a sub-coordinator
+	Names     []string    `json:"names"`
+	Addresses []string    `json:"addresses"`
+	Liveness  []string    `json:"liveness"` // ALIVE, SUSPECT, DOWN or UNKNOWN
+	LastSeen  []time.Time `json:"lastSeen"` // Last answered heartbeat, zero if unknown
+	Versions  []int       `json:"versions"` // Protocol version announced, 0 from before the handshake
 }
 
 func (n *Node) ListParticipants(req *ListParticipantsRequest, res *ListParticipantsResponse) error {
 	if n.Type != "Coordinator" {
 		n.Print("Requesting participant list from coordinator")
-		if n.p_coordinatorClient == nil {
-			return fmt.Errorf("coordinator client not set")
-		}
-		err := n.p_coordinatorClient.Call("Node.ListParticipants", &req, &res)
+		err := n.callCoordinator("Node.ListParticipants", req, res)
 		if err != nil {
 			return fmt.Errorf("failed to get participant list from coordinator: %v", err)
 		}
 	} else {
 		n.Print("Listing participants as coordinator")
 
+		n.c_membershipMutex.RLock()
+		defer n.c_membershipMutex.RUnlock()
 		res.Names = make([]string, 0, len(n.c_participantClients))
 		res.Addresses = make([]string, 0, len(n.c_participantClients))
 
 		for _, data := range n.c_participantClients {
+			liveness, lastSeen := n.liveness(data.Name)
+			res.IDs = append(res.IDs, data.ID)
 			res.Names = append(res.Names, data.Name)
 			res.Addresses = append(res.Addresses, data.Address)
+			res.Liveness = append(res.Liveness, liveness)
+			res.LastSeen = append(res.LastSeen, lastSeen)
+			res.Versions = append(res.Versions, data.Version)
+		}
+		// Participants attached beneath sub-coordinators, watched by those
+		for _, route := range n.c_routes {
+			res.IDs = append(res.IDs, uuid.Nil)
+			res.Names = append(res.Names, route.Name)
+			res.Addresses = append(res.Addresses, route.Address)
+			res.Liveness = append(res.Liveness, LivenessUnknown)
+			res.LastSeen = append(res.LastSeen, time.Time{})
+			res.Versions = append(res.Versions, route.Version)
 		}
 	}
 	return nil
@@ -0,0 +1,342 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// OperationType names an operation a transaction applies to a balance
+type OperationType string
+
+const (
+	OpAdd           OperationType = "add"
+	OpSubtract      OperationType = "subtract"
+	OpMultiply      OperationType = "multiply"
+	OpDivide        OperationType = "divide"
+	OpSet           OperationType = "set"
+	OpCompareAndSet OperationType = "compare-and-set"
+	OpAssertMin     OperationType = "assert-min"
+)
+
+// OperationSpec defines an operation. The built-in operations are registered
+// at startup, RegisterOperation adds new ones.
+type OperationSpec struct {
+	Type   OperationType
+	Symbol string // Client shorthand, e.g. "+" for add
+	// Whether the operation takes an expected balance, written "<symbol><expected>:<amount>"
+	UsesExpected bool
+	// Whether Amount is a factor rather than money, so it has no currency
+	FactorAmount bool
+	// Check the arguments before any balance is involved
+	Validate func(op ResourceOperation) error
+	// Balance after the operation, or an error if its precondition does not hold
+	Apply func(balance Decimal, op ResourceOperation) (Decimal, error)
+	// Operation undoing op, given the balance before it or nil when that is
+	// unknown. Nil for operations that never change the balance.
+	Inverse func(op ResourceOperation, before *Decimal) (ResourceOperation, error)
+}
+
+var operationRegistry = struct {
+	mutex sync.RWMutex
+	specs map[OperationType]*OperationSpec
+	order []OperationType
+}{specs: make(map[OperationType]*OperationSpec)}
+
+var errPriorBalanceNeeded = errors.New("undoing it needs the balance before it was applied")
+
+// InvalidOperation is an operation that cannot apply whatever the balance,
+// as opposed to one whose precondition does not hold: an unknown operation,
+// arguments it does not take or an amount more precise than the account.
+type InvalidOperation struct {
+	Err error
+}
+
+const codeInvalidOperation = "INVALID_OPERATION"
+
+func (e *InvalidOperation) Error() string {
+	return fmt.Sprintf("%s: %v", codeInvalidOperation, e.Err)
+}
+
+func (e *InvalidOperation) Unwrap() error {
+	return e.Err
+}
+
+func invalidOperation(err error) error {
+	if err == nil || isInvalidOperation(err) {
+		return err
+	}
+	return &InvalidOperation{Err: err}
+}
+
+// Whether err is an invalid operation, having possibly come from another node
+// as text
+func isInvalidOperation(err error) bool {
+	var invalid *InvalidOperation
+	return errors.As(err, &invalid) || strings.Contains(err.Error(), codeInvalidOperation+": ")
+}
+
+func RegisterOperation(spec OperationSpec) error {
+	if spec.Type == "" || spec.Symbol == "" || spec.Apply == nil {
+		return fmt.Errorf("operation needs a type, a symbol and Apply")
+	}
+	operationRegistry.mutex.Lock()
+	defer operationRegistry.mutex.Unlock()
+	for _, other := range operationRegistry.specs {
+		if other.Type == spec.Type || other.Symbol == spec.Symbol {
+			return fmt.Errorf("operation %s or symbol %q already registered", spec.Type, spec.Symbol)
+		}
+	}
+	operationRegistry.specs[spec.Type] = &spec
+	operationRegistry.order = append(operationRegistry.order, spec.Type)
+	return nil
+}
+
+func LookupOperation(operation OperationType) (*OperationSpec, error) {
+	operationRegistry.mutex.RLock()
+	defer operationRegistry.mutex.RUnlock()
+	spec, ok := operationRegistry.specs[operation]
+	if !ok {
+		return nil, fmt.Errorf("unknown operation %q", operation)
+	}
+	return spec, nil
+}
+
+// Registered operations, in registration order
+func Operations() []OperationSpec {
+	operationRegistry.mutex.RLock()
+	defer operationRegistry.mutex.RUnlock()
+	var specs []OperationSpec
+	for _, operation := range operationRegistry.order {
+		specs = append(specs, *operationRegistry.specs[operation])
+	}
+	return specs
+}
+
+// Parse client shorthand such as "+50" or "?=50:100"
+func ParseOperation(input string) (OperationType, Decimal, Decimal, error) {
+	specs := Operations()
+	// Longest symbol first so ">=" is not taken for a shorter one
+	sort.SliceStable(specs, func(i, j int) bool { return len(specs[i].Symbol) > len(specs[j].Symbol) })
+	for _, spec := range specs {
+		args, found := strings.CutPrefix(input, spec.Symbol)
+		if !found {
+			continue
+		}
+		var expected Decimal
+		if spec.UsesExpected {
+			expectedArg, amountArg, found := strings.Cut(args, ":")
+			if !found {
+				return "", Decimal{}, Decimal{}, fmt.Errorf("%s takes <expected>:<amount>", spec.Type)
+			}
+			var err error
+			if expected, err = ParseDecimal(expectedArg); err != nil {
+				return "", Decimal{}, Decimal{}, err
+			}
+			args = amountArg
+		}
+		amount, err := ParseDecimal(args)
+		if err != nil {
+			return "", Decimal{}, Decimal{}, err
+		}
+		return spec.Type, amount, expected, nil
+	}
+	return "", Decimal{}, Decimal{}, fmt.Errorf("invalid operation format")
+}
+
+// Check the operation of tx is known and its arguments are acceptable
+func ValidateTransaction(tx Transaction) error {
+	if err := validateOperation(tx.resourceOperation()); err != nil {
+		return err
+	}
+	if tx.Hold != uuid.Nil && tx.Operation != OpSubtract {
+		return invalidOperation(fmt.Errorf("only a subtract can capture a hold"))
+	}
+	if tx.Currency == "" {
+		return nil
+	}
+	if !currencyPattern.MatchString(tx.Currency) {
+		return invalidOperation(fmt.Errorf("invalid currency %q", tx.Currency))
+	}
+	if spec, _ := LookupOperation(tx.Operation); spec.FactorAmount {
+		return invalidOperation(fmt.Errorf("%s takes a factor, not an amount in %s", tx.Operation, tx.Currency))
+	}
+	return nil
+}
+
+func validateOperation(op ResourceOperation) error {
+	spec, err := LookupOperation(op.Operation)
+	if err != nil {
+		return invalidOperation(err)
+	}
+	if spec.Validate != nil {
+		return invalidOperation(spec.Validate(op))
+	}
+	return nil
+}
+
+// Balance after applying op
+func applyOperation(balance Decimal, op ResourceOperation) (Decimal, error) {
+	if err := validateOperation(op); err != nil {
+		return Decimal{}, err
+	}
+	spec, _ := LookupOperation(op.Operation)
+	return spec.Apply(balance, op)
+}
+
+func (tx Transaction) resourceOperation() ResourceOperation {
+	return ResourceOperation{
+		Key:        tx.Account,
+		Operation:  tx.Operation,
+		Amount:     tx.Amount,
+		Expected:   tx.Expected,
+		Currency:   tx.Currency,
+		Conversion: tx.Conversion,
+		Hold:       tx.Hold,
+	}
+}
+
+func nonNegativeAmount(op ResourceOperation) error {
+	if op.Amount.Sign() < 0 {
+		return fmt.Errorf("%s amount must not be negative", op.Operation)
+	}
+	return nil
+}
+
+// Amount at the scale of balance, rejecting amounts with more decimal places
+func exactAmount(balance Decimal, amount Decimal) (Decimal, error) {
+	exact, err := amount.Rescale(balance.Scale)
+	return exact, invalidOperation(err)
+}
+
+// Undo by setting the balance back, unless it changed since
+func restoreBalance(op ResourceOperation, before *Decimal) (ResourceOperation, error) {
+	if before == nil {
+		return ResourceOperation{}, errPriorBalanceNeeded
+	}
+	return ResourceOperation{Key: op.Key, Operation: OpCompareAndSet, Expected: op.Amount, Amount: *before}, nil
+}
+
+// Products and quotients are rounded half to even at the scale of the balance,
+// so undoing them may be off by a minor unit.
+var builtinOperations = []OperationSpec{
+	{
+		Type:     OpAdd,
+		Symbol:   "+",
+		Validate: nonNegativeAmount,
+		Apply: func(balance Decimal, op ResourceOperation) (Decimal, error) {
+			amount, err := exactAmount(balance, op.Amount)
+			if err != nil {
+				return Decimal{}, err
+			}
+			return balance.Add(amount)
+		},
+		Inverse: func(op ResourceOperation, before *Decimal) (ResourceOperation, error) {
+			op.Operation = OpSubtract
+			return op, nil
+		},
+	},
+	{
+		Type:     OpSubtract,
+		Symbol:   "-",
+		Validate: nonNegativeAmount,
+		Apply: func(balance Decimal, op ResourceOperation) (Decimal, error) {
+			amount, err := exactAmount(balance, op.Amount)
+			if err != nil {
+				return Decimal{}, err
+			}
+			return balance.Sub(amount)
+		},
+		Inverse: func(op ResourceOperation, before *Decimal) (ResourceOperation, error) {
+			op.Operation = OpAdd
+			return op, nil
+		},
+	},
+	{
+		Type:         OpMultiply,
+		Symbol:       "*",
+		FactorAmount: true,
+		Validate:     nonNegativeAmount,
+		Apply: func(balance Decimal, op ResourceOperation) (Decimal, error) {
+			return balance.Mul(op.Amount, balance.Scale)
+		},
+		Inverse: func(op ResourceOperation, before *Decimal) (ResourceOperation, error) {
+			if op.Amount.IsZero() {
+				if before == nil {
+					return ResourceOperation{}, errPriorBalanceNeeded
+				}
+				return ResourceOperation{Key: op.Key, Operation: OpCompareAndSet, Expected: NewDecimal(0, 0), Amount: *before}, nil
+			}
+			op.Operation = OpDivide
+			return op, nil
+		},
+	},
+	{
+		Type:         OpDivide,
+		Symbol:       "/",
+		FactorAmount: true,
+		Validate: func(op ResourceOperation) error {
+			if op.Amount.Sign() <= 0 {
+				return fmt.Errorf("divide amount must be positive")
+			}
+			return nil
+		},
+		Apply: func(balance Decimal, op ResourceOperation) (Decimal, error) {
+			return balance.Div(op.Amount, balance.Scale)
+		},
+		Inverse: func(op ResourceOperation, before *Decimal) (ResourceOperation, error) {
+			op.Operation = OpMultiply
+			return op, nil
+		},
+	},
+	{
+		Type:     OpSet,
+		Symbol:   "=",
+		Validate: nonNegativeAmount,
+		Apply: func(balance Decimal, op ResourceOperation) (Decimal, error) {
+			return exactAmount(balance, op.Amount)
+		},
+		Inverse: restoreBalance,
+	},
+	{
+		Type:         OpCompareAndSet,
+		Symbol:       "?=",
+		UsesExpected: true,
+		Validate: func(op ResourceOperation) error {
+			if op.Amount.Sign() < 0 || op.Expected.Sign() < 0 {
+				return fmt.Errorf("compare-and-set amounts must not be negative")
+			}
+			return nil
+		},
+		Apply: func(balance Decimal, op ResourceOperation) (Decimal, error) {
+			if balance.Cmp(op.Expected) != 0 {
+				return Decimal{}, fmt.Errorf("balance %s is not the expected %s", balance, op.Expected)
+			}
+			return exactAmount(balance, op.Amount)
+		},
+		Inverse: restoreBalance,
+	},
+	{
+		Type:     OpAssertMin,
+		Symbol:   ">=",
+		Validate: nonNegativeAmount,
+		Apply: func(balance Decimal, op ResourceOperation) (Decimal, error) {
+			if balance.Cmp(op.Amount) < 0 {
+				return Decimal{}, fmt.Errorf("balance %s is below the minimum %s", balance, op.Amount)
+			}
+			return balance, nil
+		},
+	},
+}
+
+func init() {
+	for _, spec := range builtinOperations {
+		if err := RegisterOperation(spec); err != nil {
+			panic(err)
+		}
+	}
+}
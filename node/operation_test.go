@@ -0,0 +1,202 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParseOperation(t *testing.T) {
+	tests := []struct {
+		input        string
+		wantOp       OperationType
+		wantAmount   string
+		wantExpected string
+		wantErr      bool
+	}{
+		{input: "+50", wantOp: OpAdd, wantAmount: "50"},
+		{input: "-12.5", wantOp: OpSubtract, wantAmount: "12.5"},
+		{input: "*1.1", wantOp: OpMultiply, wantAmount: "1.1"},
+		{input: "/4", wantOp: OpDivide, wantAmount: "4"},
+		{input: "=100", wantOp: OpSet, wantAmount: "100"},
+		{input: "?=50:100", wantOp: OpCompareAndSet, wantAmount: "100", wantExpected: "50"},
+		{input: ">=10", wantOp: OpAssertMin, wantAmount: "10"}, // Not taken for "=" after ">"
+		{input: "?=50", wantErr: true},
+		{input: "+x", wantErr: true},
+		{input: "50", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			op, amount, expected, err := ParseOperation(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %s %s, want an error", op, amount)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if op != tt.wantOp || amount.String() != tt.wantAmount {
+				t.Fatalf("got %s %s, want %s %s", op, amount, tt.wantOp, tt.wantAmount)
+			}
+			if tt.wantExpected != "" && expected.String() != tt.wantExpected {
+				t.Fatalf("expected %s, want %s", expected, tt.wantExpected)
+			}
+		})
+	}
+}
+
+func TestApplyOperation(t *testing.T) {
+	tests := []struct {
+		name     string
+		balance  string
+		op       OperationType
+		amount   string
+		expected string
+		want     string
+		wantErr  bool
+		invalid  bool // Whether the error is an invalid operation
+	}{
+		{name: "add", balance: "10.00", op: OpAdd, amount: "2.5", want: "12.50"},
+		{name: "add too precise", balance: "10.00", op: OpAdd, amount: "0.005", wantErr: true, invalid: true},
+		{name: "add negative", balance: "10.00", op: OpAdd, amount: "-1", wantErr: true, invalid: true},
+		{name: "subtract", balance: "10.00", op: OpSubtract, amount: "12", want: "-2.00"},
+		{name: "multiply rounds half to even", balance: "0.25", op: OpMultiply, amount: "0.5", want: "0.12"},
+		{name: "divide", balance: "10.00", op: OpDivide, amount: "3", want: "3.33"},
+		{name: "divide by zero", balance: "10.00", op: OpDivide, amount: "0", wantErr: true, invalid: true},
+		{name: "set", balance: "10.00", op: OpSet, amount: "7", want: "7.00"},
+		{name: "compare-and-set", balance: "10.00", op: OpCompareAndSet, expected: "10", amount: "3", want: "3.00"},
+		{name: "compare-and-set mismatch", balance: "10.00", op: OpCompareAndSet, expected: "9", amount: "3", wantErr: true},
+		{name: "assert-min", balance: "10.00", op: OpAssertMin, amount: "10", want: "10.00"},
+		{name: "assert-min below", balance: "10.00", op: OpAssertMin, amount: "10.01", wantErr: true},
+		{name: "unknown", balance: "10.00", op: "steal", amount: "1", wantErr: true, invalid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := ResourceOperation{Operation: tt.op, Amount: mustDecimal(t, tt.amount)}
+			if tt.expected != "" {
+				op.Expected = mustDecimal(t, tt.expected)
+			}
+			got, err := applyOperation(mustDecimal(t, tt.balance), op)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %s, want an error", got)
+				}
+				if isInvalidOperation(err) != tt.invalid {
+					t.Fatalf("%v: invalid operation %v, want %v", err, !tt.invalid, tt.invalid)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOperationInverse(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      OperationType
+		amount  string
+		before  string // Balance before op, unknown if empty
+		wantErr bool
+	}{
+		{name: "add", op: OpAdd, amount: "2.5"},
+		{name: "subtract", op: OpSubtract, amount: "2.5"},
+		{name: "multiply", op: OpMultiply, amount: "2"},
+		{name: "multiply by zero", op: OpMultiply, amount: "0", before: "10.00"},
+		{name: "multiply by zero, balance unknown", op: OpMultiply, amount: "0", wantErr: true},
+		{name: "divide", op: OpDivide, amount: "4"},
+		{name: "set", op: OpSet, amount: "3", before: "10.00"},
+		{name: "set, balance unknown", op: OpSet, amount: "3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := LookupOperation(tt.op)
+			if err != nil {
+				t.Fatal(err)
+			}
+			op := ResourceOperation{Operation: tt.op, Amount: mustDecimal(t, tt.amount)}
+			var before *Decimal
+			start := mustDecimal(t, "10.00")
+			if tt.before != "" {
+				b := mustDecimal(t, tt.before)
+				before, start = &b, b
+			}
+			inverse, err := spec.Inverse(op, before)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %s, want an error", inverse.Operation)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			after, err := applyOperation(start, op)
+			if err != nil {
+				t.Fatal(err)
+			}
+			undone, err := applyOperation(after, inverse)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if undone.Cmp(start) != 0 {
+				t.Fatalf("undoing %s gave %s, want %s", tt.op, undone, start)
+			}
+		})
+	}
+}
+
+func TestValidateTransaction(t *testing.T) {
+	tests := []struct {
+		name    string
+		tx      Transaction
+		wantErr bool
+	}{
+		{name: "add", tx: Transaction{Operation: OpAdd, Amount: NewDecimal(1, 0)}},
+		{name: "negative add", tx: Transaction{Operation: OpAdd, Amount: NewDecimal(-1, 0)}, wantErr: true},
+		{name: "add in a currency", tx: Transaction{Operation: OpAdd, Amount: NewDecimal(1, 0), Currency: "EUR"}},
+		{name: "invalid currency", tx: Transaction{Operation: OpAdd, Amount: NewDecimal(1, 0), Currency: "eur"}, wantErr: true},
+		{name: "factor in a currency", tx: Transaction{Operation: OpMultiply, Amount: NewDecimal(2, 0), Currency: "EUR"}, wantErr: true},
+		{name: "capture by subtract", tx: Transaction{Operation: OpSubtract, Amount: NewDecimal(1, 0), Hold: uuid.New()}},
+		{name: "capture by add", tx: Transaction{Operation: OpAdd, Amount: NewDecimal(1, 0), Hold: uuid.New()}, wantErr: true},
+		{name: "unknown operation", tx: Transaction{Operation: "steal", Amount: NewDecimal(1, 0)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTransaction(tt.tx)
+			if tt.wantErr && err == nil {
+				t.Fatal("valid, want an error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestRegisterOperationConflicts(t *testing.T) {
+	apply := func(balance Decimal, op ResourceOperation) (Decimal, error) { return balance, nil }
+	tests := []struct {
+		name string
+		spec OperationSpec
+	}{
+		{name: "type taken", spec: OperationSpec{Type: OpAdd, Symbol: "~", Apply: apply}},
+		{name: "symbol taken", spec: OperationSpec{Type: "noop", Symbol: "+", Apply: apply}},
+		{name: "no symbol", spec: OperationSpec{Type: "noop", Apply: apply}},
+		{name: "no apply", spec: OperationSpec{Type: "noop", Symbol: "~"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := RegisterOperation(tt.spec); err == nil {
+				t.Fatal("registered, want an error")
+			}
+		})
+	}
+}
@@ -2,191 +2,296 @@ package node
 
 import (
 	"fmt"
-	"net/rpc"
-	"os"
-	"path/filepath"
-	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type ParticipantConnectToCoordinatorRequest struct {
-	Addr string
+	Addrs []string `json:"addrs"` // The coordinators joined before when empty
+}
+type ParticipantConnectToCoordinatorResponse struct {
+	ID uuid.UUID `json:"id"` // Identity of this participant
 }
-type ParticipantConnectToCoordinatorResponse struct{}
 
+// Register with every coordinator so whichever one is elected leader can reach this participant
 func (n *Node) ParticipantConnectToCoordinator(req *ParticipantConnectToCoordinatorRequest, res *ParticipantConnectToCoordinatorResponse) error {
-	coordinatorClient, err := rpc.Dial("tcp", req.Addr)
-	if err != nil {
-		n.Print(fmt.Sprintf("Error connecting to coordinator: %v", err))
+	addrs := req.Addrs
+	if len(addrs) == 0 {
+		n.p_coordinatorMutex.Lock()
+		addrs = n.p_coordinatorAddrs
+		n.p_coordinatorMutex.Unlock()
+		if len(addrs) == 0 {
+			return fmt.Errorf("no coordinators to join")
+		}
 	}
-	n.Print("Connected to coordinator")
-	var addReq = AddParticipantRequest{Name: n.Name, Addr: n.Addr}
-	var addRes AddParticipantResponse
-	if err := coordinatorClient.Call("Node.AddParticipant", &addReq, &addRes); err != nil {
-		n.Print(fmt.Sprintf("Error AddParticipant RPC: %v\n", err))
-		return fmt.Errorf("error callingAddParticipant RPC: %v", err)
+	for _, addr := range addrs {
+		err := n.joinCoordinator(addr)
+		if notSent(err) {
+			// Joined later, when it answers
+			n.Print(fmt.Sprintf("Error connecting to coordinator %s: %v", addr, err))
+			continue
+		}
+		if err != nil {
+			n.Print(fmt.Sprintf("Error AddParticipant RPC: %v\n", err))
+			return fmt.Errorf("error callingAddParticipant RPC: %v", err)
+		}
 	}
-	n.p_coordinatorClient = coordinatorClient
+	n.Print("Connected to coordinators")
+
+	n.p_coordinatorMutex.Lock()
+	n.p_coordinatorAddrs = addrs
+	n.p_left = false
+	n.p_coordinatorMutex.Unlock()
+	n.c_membershipMutex.RLock()
+	res.ID = n.p_memberID
+	n.c_membershipMutex.RUnlock()
 
+	// Downstream participants that registered before we joined
+	n.announceRoutes()
 	return nil
 }
 
-type GetBalanceRequest struct {
-	AccountAddr string
+// Register with the coordinator at addr, presenting the ID issued by the
+// first one joined so that every coordinator knows this participant by it
+func (n *Node) joinCoordinator(addr string) error {
+	n.c_membershipMutex.RLock()
+	id := n.p_memberID
+	n.c_membershipMutex.RUnlock()
+	var req = AddParticipantRequest{
+		Name:       n.Name,
+		Addr:       n.Addr,
+		ID:         id,
+		Version:    ProtocolVersion,
+		MinVersion: MinProtocolVersion,
+		Features:   n.features(),
+	}
+	var res AddParticipantResponse
+	if err := n.call(addr, "Node.AddParticipant", &req, &res); err != nil {
+		return err
+	}
+	// Checked by coordinators too, unless they are from before the handshake
+	if err := checkProtocol(res.Version, res.MinVersion); err != nil {
+		return fmt.Errorf("coordinator %s: %v", addr, err)
+	}
+	if id == uuid.Nil {
+		n.c_membershipMutex.Lock()
+		n.p_memberID = res.ID
+		n.persistMembership()
+		n.c_membershipMutex.Unlock()
+		n.Print(fmt.Sprintf("Joined as %s", res.ID))
+	}
+	n.p_coordinatorMutex.Lock()
+	if n.p_coordinatorSeen == nil {
+		n.p_coordinatorSeen = make(map[string]time.Time)
+	}
+	n.p_coordinatorSeen[addr] = time.Now()
+	if n.p_coordinatorProtocols == nil {
+		n.p_coordinatorProtocols = make(map[string]AddParticipantResponse)
+	}
+	n.p_coordinatorProtocols[addr] = res
+	n.p_coordinatorMutex.Unlock()
+	return nil
 }
 
-type GetBalanceResponse struct {
-	Balance float64
+// Announce every participant below this one to the parent coordinators
+func (n *Node) announceRoutes() {
+	n.c_membershipMutex.RLock()
+	var routes []RouteData
+	for _, data := range n.c_participantClients {
+		routes = append(routes, RouteData{Name: data.Name, Address: data.Address, Version: data.Version, Features: data.Features})
+	}
+	for _, route := range n.c_routes {
+		routes = append(routes, *route)
+	}
+	n.c_membershipMutex.RUnlock()
+	for _, route := range routes {
+		n.announceRoute(route)
+	}
 }
 
-func (n *Node) GetBalance(req *GetBalanceRequest, res *GetBalanceResponse) error {
-	balance, err := n.getBalance()
-	if err != nil {
-		return fmt.Errorf("error retrieving balance: %v", err)
+// Ask the coordinators who the leader is
+func (n *Node) findLeader() (string, error) {
+	n.p_coordinatorMutex.Lock()
+	defer n.p_coordinatorMutex.Unlock()
+	for _, addr := range n.p_coordinatorAddrs {
+		var req GetLeaderRequest
+		var res GetLeaderResponse
+		if err := n.call(addr, "Node.GetLeader", &req, &res); err != nil || res.LeaderAddr == "" {
+			continue
+		}
+		if n.p_coordinatorLeader != res.LeaderAddr {
+			n.Print(fmt.Sprintf("Following coordinator leader %s", res.LeaderAddr))
+		}
+		n.p_coordinatorLeader = res.LeaderAddr
+		return res.LeaderAddr, nil
 	}
-	res.Balance = balance
-	return nil
+	return "", fmt.Errorf("no coordinator leader available")
 }
 
-// TODO: Replace with mini-cloud
-func (n *Node) getBalance() (float64, error) {
-	// Check if the data file exists
-	nodeDataDir := "node_data"
-	filename := fmt.Sprintf("%s-%s.data", n.Type, n.Name)
-	filepath := filepath.Join(nodeDataDir, filename)
+// Call the current coordinator leader, following leadership changes
+func (n *Node) callCoordinator(method string, req interface{}, res interface{}) error {
+	var err error
+	for attempt := 0; attempt < 10; attempt++ {
+		n.p_coordinatorMutex.Lock()
+		leader := n.p_coordinatorLeader
+		n.p_coordinatorMutex.Unlock()
+		// Asked again after a failed attempt, the leader may have changed
+		if leader == "" || attempt > 0 {
+			if leader, err = n.findLeader(); err != nil {
+				time.Sleep(heartbeatInterval)
+				continue
+			}
+		}
 
-	_, statErr := os.Stat(filepath)
-	if os.IsNotExist(statErr) {
-		return 0.0, fmt.Errorf("data file not found")
+		err = n.call(leader, method, req, res)
+		if err == nil {
+			return nil
+		}
+		// Only retry when the request cannot have been processed
+		if !notSent(err) && !strings.HasPrefix(err.Error(), errNotLeader) {
+			return err
+		}
+		n.Print(fmt.Sprintf("Coordinator unavailable (%v), looking for leader", err))
+		time.Sleep(heartbeatInterval)
 	}
+	return err
+}
 
-	// Open the data file
-	file, openErr := os.Open(filepath)
-	if openErr != nil {
-		n.Print(fmt.Sprintf("Error opening data file: %v", openErr))
-		return 0.0, openErr
-	}
-	defer file.Close()
+type GetBalanceRequest struct {
+	Account string `json:"account"`
+}
 
-	// Read the content from the file
-	var content string
-	_, readErr := fmt.Fscanf(file, "%s", &content)
-	if readErr != nil {
-		n.Print(fmt.Sprintf("Error reading data from file: %v", readErr))
-		return 0.0, readErr
-	}
+type GetBalanceResponse struct {
+	Balance   Decimal `json:"balance"`   // Ledger balance
+	Available Decimal `json:"available"` // Ledger balance less holds
+	Currency  string  `json:"currency"`
+}
 
-	// Parse the content as a decimal number (float64)
-	balance, parseErr := strconv.ParseFloat(content, 64)
-	if parseErr != nil {
-		n.Print(fmt.Sprintf("Error parsing balance from file: %v", parseErr))
-		return 0.0, parseErr
+func (n *Node) GetBalance(req *GetBalanceRequest, res *GetBalanceResponse) error {
+	if n.Type != "Participant" {
+		return fmt.Errorf("this node is not a participant")
 	}
+	balance, err := n.resource.Read(req.Account)
+	if err != nil {
+		return fmt.Errorf("error retrieving balance: %v", err)
+	}
+	res.Balance = balance
+	res.Available = balance
+	if holds, ok := n.resource.(HoldManager); ok {
+		if res.Available, err = holds.Available(accountOrDefault(req.Account)); err != nil {
+			return fmt.Errorf("error retrieving balance: %v", err)
+		}
+	}
+	res.Currency, err = n.accountCurrency(req.Account)
+	return err
+}
 
-	return balance, nil
+// Currency of an account on this participant
+func (n *Node) accountCurrency(account string) (string, error) {
+	accounts, ok := n.resource.(AccountManager)
+	if !ok {
+		return defaultCurrency, nil
+	}
+	details, err := accounts.AccountDetails(accountOrDefault(account))
+	return details.Currency, err
 }
 
 type DepositRequest struct {
-	Amount float64
+	Account string  `json:"account"`
+	Amount  Decimal `json:"amount"`
 }
 
 type DepositResponse struct {
 }
 
-// TODO: Replace with mini-cloud
 func (n *Node) Deposit(req *DepositRequest, res *DepositResponse) error {
-	if req.Amount < 0 {
-		return fmt.Errorf("cannot deposit negative")
+	if n.Type != "Participant" {
+		return fmt.Errorf("this node is not a participant")
 	}
-
-	// TODO: Read/write lock
-	currentBalance, err := n.getBalance()
-	if err != nil {
-		n.Print(fmt.Sprintf("Error reading current balance: %v", err))
-		return err
+	if req.Amount.Sign() < 0 {
+		return fmt.Errorf("cannot deposit negative")
 	}
-	newBalance := currentBalance + req.Amount
 
-	err = n.WriteBalance(newBalance)
+	err := applyNow(n.resource, ResourceOperation{Key: req.Account, Operation: OpAdd, Amount: req.Amount, External: true})
 	if err != nil {
 		n.Print(fmt.Sprintf("Error updating balance: %v", err))
 		return err
 	}
 
-	n.Print(fmt.Sprintf("Deposit %.2f successful. New balance: %.2f", req.Amount, newBalance))
+	newBalance, _ := n.resource.Read(req.Account)
+	n.Print(fmt.Sprintf("Deposit %s to %s successful. New balance: %s", req.Amount, accountOrDefault(req.Account), newBalance))
 	return nil
 }
 
 type WithdrawRequest struct {
-	Amount float64
+	Account string  `json:"account"`
+	Amount  Decimal `json:"amount"`
 }
 
 type WithdrawResponse struct {
 }
 
-// TODO: Replace with mini-cloud
 func (n *Node) Withdraw(req *WithdrawRequest, res *WithdrawResponse) error {
-	bal, err := n.getBalance()
-	if err != nil {
-		return err
+	if n.Type != "Participant" {
+		return fmt.Errorf("this node is not a participant")
 	}
-	if bal-req.Amount < 0 {
-		return fmt.Errorf("insufficient funds")
+	// Account policies are checked when the debit is prepared
+	err := applyNow(n.resource, ResourceOperation{Key: req.Account, Operation: OpSubtract, Amount: req.Amount, External: true})
+	if violation, ok := err.(*PolicyViolation); ok {
+		n.Print(fmt.Sprintf(colorRed+"Withdraw refused (%v)"+colorReset, violation))
+		return violation
 	}
-
-	// TODO: Read/write lock
-	currentBalance, err := n.getBalance()
-	if err != nil {
-		n.Print(fmt.Sprintf("Error reading current balance: %v", err))
-		return err
-	}
-
-	newBalance := currentBalance - req.Amount
-
-	err = n.WriteBalance(newBalance)
 	if err != nil {
 		n.Print(fmt.Sprintf("Error updating balance: %v", err))
 		return err
 	}
 
-	n.Print(fmt.Sprintf("Deposit %.2f successful. New balance: %.2f", req.Amount, newBalance))
+	newBalance, _ := n.resource.Read(req.Account)
+	n.Print(fmt.Sprintf("Withdraw %s from %s successful. New balance: %s", req.Amount, accountOrDefault(req.Account), newBalance))
 	return nil
 }
 
-// TODO: Replace with mini-cloud
-func (n *Node) WriteBalance(balance float64) error {
-	// Check and create node_data directory
-	nodeDataDir := "node_data"
-	if _, err := os.Stat(nodeDataDir); os.IsNotExist(err) {
-		err := os.Mkdir(nodeDataDir, 0755)
-		if err != nil {
-			n.Print(fmt.Sprintf("Error creating data directory: %v", err))
-			return err
-		}
-	}
+type AccountInfo struct {
+	Account  string        `json:"account"`
+	Balance  Decimal       `json:"balance"`
+	Currency string        `json:"currency"`
+	Owner    string        `json:"owner"`
+	Status   AccountStatus `json:"status"`
+}
+
+type ListAccountsRequest struct{}
 
-	// Create a data file for node
-	filename := fmt.Sprintf("%s-%s.data", n.Type, n.Name)
-	filepath := filepath.Join(nodeDataDir, filename)
-	file, err := os.Create(filepath)
+type ListAccountsResponse struct {
+	Accounts []AccountInfo `json:"accounts"`
+}
+
+func (n *Node) ListAccounts(req *ListAccountsRequest, res *ListAccountsResponse) error {
+	accounts, err := n.accountManager()
 	if err != nil {
-		n.Print(fmt.Sprintf("Error creating data file: %v", err))
 		return err
 	}
-
-	// Write the balance as a decimal number to the file
-	_, writeErr := fmt.Fprintf(file, "%.2f", balance)
-	if writeErr != nil {
-		n.Print(fmt.Sprintf("Error writing balance to file: %v", writeErr))
-		file.Close()
-		return writeErr
+	names, err := accounts.ListAccounts()
+	if err != nil {
+		return err
 	}
-
-	// Close file
-	closeErr := file.Close()
-	if closeErr != nil {
-		n.Print(fmt.Sprintf("Error closing data file: %v", closeErr))
-		return closeErr
+	for _, name := range names {
+		balance, err := n.resource.Read(name)
+		if err != nil {
+			return err
+		}
+		details, err := accounts.AccountDetails(name)
+		if err != nil {
+			return err
+		}
+		res.Accounts = append(res.Accounts, AccountInfo{
+			Account:  name,
+			Balance:  balance,
+			Currency: details.Currency,
+			Owner:    details.Owner,
+			Status:   details.Status,
+		})
 	}
-
 	return nil
 }
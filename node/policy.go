@@ -0,0 +1,203 @@
+package node
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// AccountPolicy limits how an account may be debited. A debit is any operation
+// lowering the balance. Zero limits are not enforced.
+type AccountPolicy struct {
+	Overdraft       Decimal `json:"overdraft"`       // How far below zero the balance may go
+	MinBalance      Decimal `json:"minBalance"`      // Balance a debit may not go below
+	MaxDebit        Decimal `json:"maxDebit"`        // Largest single debit
+	DailyDebitLimit Decimal `json:"dailyDebitLimit"` // Most that may be debited over any 24 hours
+}
+
+// Optional interface for resources enforcing account policies
+type PolicyManager interface {
+	Policy(account string) (AccountPolicy, error)
+	SetPolicy(account string, policy AccountPolicy) error
+}
+
+// Reasons a participant votes to abort because of an account's policy
+const (
+	ReasonInsufficientBalance = "INSUFFICIENT_BALANCE"
+	ReasonOverdraftLimit      = "OVERDRAFT_LIMIT"
+	ReasonMinimumBalance      = "MINIMUM_BALANCE"
+	ReasonMaxDebit            = "MAX_DEBIT"
+	ReasonDailyDebitLimit     = "DAILY_DEBIT_LIMIT"
+)
+
+type PolicyViolation struct {
+	Reason  string
+	Message string
+}
+
+func (v *PolicyViolation) Error() string {
+	return fmt.Sprintf("%s: %s", v.Reason, v.Message)
+}
+
+var violationReasons = []string{
+	ReasonInsufficientBalance, ReasonOverdraftLimit, ReasonMinimumBalance, ReasonMaxDebit, ReasonDailyDebitLimit,
+	ReasonAccountFrozen, ReasonAccountClosed,
+}
+
+// Reason of the policy violation behind err, which may have come from another
+// node as text
+func violationReason(err error) (string, bool) {
+	if violation, ok := err.(*PolicyViolation); ok {
+		return violation.Reason, true
+	}
+	for _, reason := range violationReasons {
+		if strings.Contains(err.Error(), reason+": ") {
+			return reason, true
+		}
+	}
+	return "", false
+}
+
+const dailyDebitWindow = 24 * time.Hour
+
+func (p AccountPolicy) validate() error {
+	for _, limit := range []Decimal{p.Overdraft, p.MinBalance, p.MaxDebit, p.DailyDebitLimit} {
+		if limit.Sign() < 0 {
+			return fmt.Errorf("policy limits must not be negative")
+		}
+	}
+	return nil
+}
+
+// Check a change of account from before to after against policy. recent are
+// ledger entries for the account, including ones pending in the same transaction.
+func (p AccountPolicy) check(before Decimal, after Decimal, recent []LedgerEntry) error {
+	if after.Cmp(before) >= 0 {
+		return nil
+	}
+	debit, err := before.Sub(after)
+	if err != nil {
+		return err
+	}
+
+	if after.Cmp(p.Overdraft.Neg()) < 0 {
+		if p.Overdraft.IsZero() {
+			return errInsufficientBalance
+		}
+		return &PolicyViolation{ReasonOverdraftLimit, fmt.Sprintf("balance %s would exceed the overdraft limit %s", after, p.Overdraft)}
+	}
+	if !p.MinBalance.IsZero() && after.Cmp(p.MinBalance) < 0 {
+		return &PolicyViolation{ReasonMinimumBalance, fmt.Sprintf("balance %s would fall below the minimum %s", after, p.MinBalance)}
+	}
+	if !p.MaxDebit.IsZero() && debit.Cmp(p.MaxDebit) > 0 {
+		return &PolicyViolation{ReasonMaxDebit, fmt.Sprintf("debit %s exceeds the maximum single debit %s", debit, p.MaxDebit)}
+	}
+	if !p.DailyDebitLimit.IsZero() {
+		total := debit
+		since := time.Now().Add(-dailyDebitWindow)
+		for _, entry := range recent {
+			// Pending entries have no time yet
+			if !entry.Time.IsZero() && entry.Time.Before(since) {
+				continue
+			}
+			if entry.After.Cmp(entry.Before) < 0 {
+				entryDebit, err := entry.Before.Sub(entry.After)
+				if err != nil {
+					return err
+				}
+				if total, err = total.Add(entryDebit); err != nil {
+					return err
+				}
+			}
+		}
+		if total.Cmp(p.DailyDebitLimit) > 0 {
+			return &PolicyViolation{ReasonDailyDebitLimit, fmt.Sprintf("debits of %s in 24 hours would exceed the daily limit %s", total, p.DailyDebitLimit)}
+		}
+	}
+	return nil
+}
+
+// Committed entries debiting each account, oldest first, kept for daily debit
+// limits so resources need not read back their ledger on every debit
+type debitLog map[string][]LedgerEntry
+
+// Keep entry if it lowers its account's balance
+func (l debitLog) record(entry LedgerEntry) {
+	if entry.After.Cmp(entry.Before) < 0 {
+		l[entry.Account] = append(l[entry.Account], entry)
+	}
+}
+
+// Debits of account over the last day, dropping older ones kept so far
+func (l debitLog) recent(account string) []LedgerEntry {
+	since := time.Now().Add(-dailyDebitWindow)
+	debits := l[account]
+	for len(debits) > 0 && debits[0].Time.Before(since) {
+		debits = debits[1:]
+	}
+	if len(debits) == 0 {
+		delete(l, account)
+		return nil
+	}
+	l[account] = debits
+	return slices.Clone(debits)
+}
+
+// RPC: Policy of an account on this participant
+type GetAccountPolicyRequest struct {
+	Account string `json:"account"`
+}
+
+type GetAccountPolicyResponse struct {
+	Policy AccountPolicy `json:"policy"`
+}
+
+func (n *Node) GetAccountPolicy(req *GetAccountPolicyRequest, res *GetAccountPolicyResponse) error {
+	policies, err := n.policyManager()
+	if err != nil {
+		return err
+	}
+	policy, err := policies.Policy(accountOrDefault(req.Account))
+	if err != nil {
+		return err
+	}
+	res.Policy = policy
+	return nil
+}
+
+// RPC: Replace the policy of an account on this participant
+type SetAccountPolicyRequest struct {
+	Account string        `json:"account"`
+	Policy  AccountPolicy `json:"policy"`
+}
+
+type SetAccountPolicyResponse struct{}
+
+func (n *Node) SetAccountPolicy(req *SetAccountPolicyRequest, res *SetAccountPolicyResponse) error {
+	policies, err := n.policyManager()
+	if err != nil {
+		return err
+	}
+	if err := req.Policy.validate(); err != nil {
+		return err
+	}
+	account := accountOrDefault(req.Account)
+	if err := policies.SetPolicy(account, req.Policy); err != nil {
+		return err
+	}
+	n.Print(fmt.Sprintf("Policy of %s set: overdraft %s, minimum %s, max debit %s, daily limit %s",
+		account, req.Policy.Overdraft, req.Policy.MinBalance, req.Policy.MaxDebit, req.Policy.DailyDebitLimit))
+	return nil
+}
+
+func (n *Node) policyManager() (PolicyManager, error) {
+	if n.Type != "Participant" {
+		return nil, fmt.Errorf("this node is not a participant")
+	}
+	policies, ok := n.resource.(PolicyManager)
+	if !ok {
+		return nil, fmt.Errorf("resource does not support account policies")
+	}
+	return policies, nil
+}
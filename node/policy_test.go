@@ -0,0 +1,145 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func isViolation(err error, reason string) bool {
+	var violation *PolicyViolation
+	return errors.As(err, &violation) && violation.Reason == reason
+}
+
+func TestAccountPolicyCheck(t *testing.T) {
+	debit := func(before string, after string, age time.Duration) LedgerEntry {
+		entry := LedgerEntry{Before: mustDecimal(t, before), After: mustDecimal(t, after)}
+		if age > 0 {
+			entry.Time = time.Now().Add(-age)
+		}
+		return entry
+	}
+	tests := []struct {
+		name   string
+		policy AccountPolicy
+		before string
+		after  string
+		recent []LedgerEntry
+		reason string // Expected violation, none if empty
+	}{
+		{name: "credit", policy: AccountPolicy{MaxDebit: mustDecimal(t, "1")}, before: "-5", after: "100"},
+		{name: "debit within balance", before: "10", after: "0"},
+		{name: "no overdraft", before: "10", after: "-0.01", reason: ReasonInsufficientBalance},
+		{name: "within overdraft", policy: AccountPolicy{Overdraft: mustDecimal(t, "50")}, before: "10", after: "-50"},
+		{name: "beyond overdraft", policy: AccountPolicy{Overdraft: mustDecimal(t, "50")}, before: "10", after: "-50.01", reason: ReasonOverdraftLimit},
+		{name: "at minimum", policy: AccountPolicy{MinBalance: mustDecimal(t, "5")}, before: "10", after: "5"},
+		{name: "below minimum", policy: AccountPolicy{MinBalance: mustDecimal(t, "5")}, before: "10", after: "4.99", reason: ReasonMinimumBalance},
+		{name: "max debit", policy: AccountPolicy{MaxDebit: mustDecimal(t, "5")}, before: "10", after: "5"},
+		{name: "over max debit", policy: AccountPolicy{MaxDebit: mustDecimal(t, "5")}, before: "10", after: "4", reason: ReasonMaxDebit},
+		{
+			name:   "daily limit counts recent debits",
+			policy: AccountPolicy{DailyDebitLimit: mustDecimal(t, "20")},
+			before: "100", after: "90",
+			recent: []LedgerEntry{debit("120", "110", time.Hour), debit("110", "109", 0)},
+			reason: ReasonDailyDebitLimit,
+		},
+		{
+			name:   "daily limit ignores credits and old debits",
+			policy: AccountPolicy{DailyDebitLimit: mustDecimal(t, "20")},
+			before: "100", after: "90",
+			recent: []LedgerEntry{debit("200", "150", 25*time.Hour), debit("90", "120", time.Hour), debit("110", "100", time.Hour)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.check(mustDecimal(t, tt.before), mustDecimal(t, tt.after), tt.recent)
+			if tt.reason == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if !isViolation(err, tt.reason) {
+				t.Fatalf("got %v, want %s", err, tt.reason)
+			}
+		})
+	}
+}
+
+func TestAccountPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  AccountPolicy
+		wantErr bool
+	}{
+		{name: "no limits", policy: AccountPolicy{}},
+		{name: "all limits", policy: AccountPolicy{Overdraft: NewDecimal(1, 0), MinBalance: NewDecimal(1, 0), MaxDebit: NewDecimal(1, 0), DailyDebitLimit: NewDecimal(1, 0)}},
+		{name: "negative overdraft", policy: AccountPolicy{Overdraft: NewDecimal(-1, 0)}, wantErr: true},
+		{name: "negative daily limit", policy: AccountPolicy{DailyDebitLimit: NewDecimal(-1, 0)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.validate()
+			if tt.wantErr && err == nil {
+				t.Fatal("valid, want an error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestViolationReason(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		reason string // Expected reason, none if empty
+	}{
+		{name: "violation", err: &PolicyViolation{ReasonMaxDebit, "too much"}, reason: ReasonMaxDebit},
+		{name: "from another node", err: errors.New("vote abort from A: MINIMUM_BALANCE: balance 1 would fall below the minimum 5"), reason: ReasonMinimumBalance},
+		{name: "wrapped", err: fmt.Errorf("prepare failed: %v", errInsufficientBalance), reason: ReasonInsufficientBalance},
+		{name: "frozen", err: errors.New("ACCOUNT_FROZEN: account main is frozen"), reason: ReasonAccountFrozen},
+		{name: "code without message", err: errors.New("MAX_DEBIT"), reason: ""},
+		{name: "other error", err: errors.New("connection refused"), reason: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, ok := violationReason(tt.err)
+			if ok != (tt.reason != "") || reason != tt.reason {
+				t.Fatalf("got %q, %v, want %q", reason, ok, tt.reason)
+			}
+		})
+	}
+}
+
+func TestDebitLogRecent(t *testing.T) {
+	entry := func(account string, before string, after string, age time.Duration) LedgerEntry {
+		return LedgerEntry{Account: account, Before: mustDecimal(t, before), After: mustDecimal(t, after), Time: time.Now().Add(-age)}
+	}
+	debits := make(debitLog)
+	debits.record(entry("main", "100", "50", 30*time.Hour))
+	debits.record(entry("main", "50", "60", time.Hour)) // A credit, not kept
+	debits.record(entry("main", "60", "40", time.Hour))
+	debits.record(entry("savings", "10", "5", 25*time.Hour))
+
+	tests := []struct {
+		account string
+		want    int
+	}{
+		{account: "main", want: 1},
+		{account: "savings", want: 0},
+		{account: "never debited", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.account, func(t *testing.T) {
+			if got := debits.recent(tt.account); len(got) != tt.want {
+				t.Fatalf("got %d recent debits, want %d", len(got), tt.want)
+			}
+		})
+	}
+	if _, ok := debits["savings"]; ok {
+		t.Fatal("debits older than a day still kept")
+	}
+}
@@ -0,0 +1,143 @@
+package node
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// A participant joining a coordinator, or a sub-coordinator, announces the
+// protocol version it speaks, the oldest one it still accepts and the features
+// it supports, and the node joined answers with its own. Either side refuses a
+// peer whose versions do not overlap with its own. Coordinators keep what each
+// member announced, and routes carry it up from sub-coordinators, so a
+// transaction needing a feature one of its participants lacks is refused
+// before anything is prepared. That way nodes can be upgraded one at a time:
+// a new feature is only used once every participant involved supports it.
+//
+// Adding a field older nodes can safely ignore needs nothing more. A change
+// they would misread takes a new feature, and one they cannot work with at
+// all a new protocol version, raising MinProtocolVersion once no node
+// speaking the old one is left.
+
+const (
+	ProtocolVersion    = 1
+	MinProtocolVersion = 0 // Nodes from before the handshake, which announce nothing
+)
+
+const (
+	FeatureAccounts   = "accounts"   // Named accounts besides main
+	FeatureCurrencies = "currencies" // Amounts converted from another currency
+	FeatureHolds      = "holds"      // Subtracts capturing a hold
+	FeatureSagas      = "sagas"      // Saga steps and their compensations
+	FeatureSubtrees   = "subtrees"   // Forwarding to participants below, routes
+	FeatureHeartbeat  = "heartbeat"  // Answering heartbeats, sending them to members
+	FeatureAuditHold  = "audit-hold" // Holding decisions back while an audit snapshots
+
+	featureOperationPrefix = "op:" // Followed by an operation type applied
+)
+
+// Supported by every node from before the handshake
+var legacyFeatures = []string{
+	FeatureAccounts, FeatureCurrencies, FeatureHolds, FeatureSagas, FeatureSubtrees, FeatureHeartbeat,
+	operationFeature(OpAdd), operationFeature(OpSubtract), operationFeature(OpMultiply),
+	operationFeature(OpDivide), operationFeature(OpSet), operationFeature(OpCompareAndSet),
+	operationFeature(OpAssertMin),
+}
+
+func operationFeature(operation OperationType) string {
+	return featureOperationPrefix + string(operation)
+}
+
+// Features this node supports, participants' depending on their resource
+func (n *Node) features() []string {
+	features := []string{FeatureSagas, FeatureSubtrees, FeatureHeartbeat}
+	if n.Type != "Participant" {
+		return features
+	}
+	features = append(features, FeatureAuditHold)
+	if _, ok := n.resource.(AccountManager); ok {
+		features = append(features, FeatureAccounts, FeatureCurrencies)
+	}
+	if _, ok := n.resource.(HoldManager); ok {
+		features = append(features, FeatureHolds)
+	}
+	for _, spec := range Operations() {
+		features = append(features, operationFeature(spec.Type))
+	}
+	return features
+}
+
+// Error unless a peer speaking version, and accepting minVersion or later,
+// can talk with this node
+func checkProtocol(version int, minVersion int) error {
+	if version < MinProtocolVersion {
+		return fmt.Errorf("protocol version %d is no longer supported, %d or later is needed", version, MinProtocolVersion)
+	}
+	if minVersion > ProtocolVersion {
+		return fmt.Errorf("protocol version %d or later is needed, this node speaks %d", minVersion, ProtocolVersion)
+	}
+	return nil
+}
+
+// Whether a peer that announced version and features supports feature
+func supportsFeature(version int, features []string, feature string) bool {
+	if version == 0 {
+		features = legacyFeatures
+	}
+	return slices.Contains(features, feature)
+}
+
+// Features a participant needs to apply tx
+func (tx Transaction) requiredFeatures() []string {
+	features := []string{operationFeature(tx.Operation)}
+	if tx.Account != "" && tx.Account != defaultAccount {
+		features = append(features, FeatureAccounts)
+	}
+	if tx.Conversion != nil {
+		features = append(features, FeatureCurrencies)
+	}
+	if tx.Hold != uuid.Nil {
+		features = append(features, FeatureHolds)
+	}
+	return features
+}
+
+// Error naming the first participant lacking a feature its transactions
+// need, sent as saga steps when saga is set and through two-phase commit
+// otherwise
+func (n *Node) checkFeatures(transactions []Transaction, saga bool) error {
+	n.c_membershipMutex.RLock()
+	defer n.c_membershipMutex.RUnlock()
+	for _, tx := range transactions {
+		required := tx.requiredFeatures()
+		if saga {
+			required = append(required, FeatureSagas)
+		}
+		var version int
+		var features []string
+		if data, ok := n.c_participantClients[tx.Name]; ok {
+			version, features = data.Version, data.Features
+		} else if route, ok := n.c_routes[tx.Name]; ok {
+			version, features = route.Version, route.Features
+			// Saga steps go to the participant directly, prepares through the one it is reached by
+			if via, ok := n.c_participantClients[route.Via]; ok && !saga && !supportsFeature(via.Version, via.Features, FeatureSubtrees) {
+				return fmt.Errorf("%s, which %s is reached through, does not support %s", route.Via, tx.Name, FeatureSubtrees)
+			}
+		} else {
+			return fmt.Errorf("unknown participant %s", tx.Name)
+		}
+		var missing []string
+		for _, feature := range required {
+			if !supportsFeature(version, features, feature) {
+				missing = append(missing, feature)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("participant %s does not support %s", tx.Name, strings.Join(missing, ", "))
+		}
+	}
+	return nil
+}
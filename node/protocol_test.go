@@ -0,0 +1,127 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCheckProtocol(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    int
+		minVersion int
+		wantErr    bool
+	}{
+		{name: "same version", version: ProtocolVersion, minVersion: ProtocolVersion},
+		{name: "from before the handshake", version: 0, minVersion: 0},
+		{name: "newer peer accepting ours", version: ProtocolVersion + 1, minVersion: ProtocolVersion},
+		{name: "newer peer refusing ours", version: ProtocolVersion + 1, minVersion: ProtocolVersion + 1, wantErr: true},
+		{name: "older than supported", version: MinProtocolVersion - 1, minVersion: MinProtocolVersion - 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkProtocol(tt.version, tt.minVersion)
+			if tt.wantErr && err == nil {
+				t.Fatal("accepted, want an error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestSupportsFeature(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  int
+		features []string
+		feature  string
+		want     bool
+	}{
+		{name: "announced", version: 1, features: []string{FeatureSagas, FeatureHolds}, feature: FeatureHolds, want: true},
+		{name: "not announced", version: 1, features: []string{FeatureSagas}, feature: FeatureHolds, want: false},
+		{name: "announced none", version: 1, feature: FeatureSagas, want: false},
+		{name: "legacy node", version: 0, feature: FeatureSubtrees, want: true},
+		{name: "legacy node, built-in operation", version: 0, feature: operationFeature(OpCompareAndSet), want: true},
+		{name: "legacy node, newer operation", version: 0, feature: operationFeature("transfer"), want: false},
+		{name: "legacy node announcements ignored", version: 0, features: []string{"transfer"}, feature: "transfer", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := supportsFeature(tt.version, tt.features, tt.feature); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequiredFeatures(t *testing.T) {
+	tests := []struct {
+		name string
+		tx   Transaction
+		want []string
+	}{
+		{name: "main account", tx: Transaction{Operation: OpAdd}, want: []string{operationFeature(OpAdd)}},
+		{name: "main account by name", tx: Transaction{Operation: OpAdd, Account: defaultAccount}, want: []string{operationFeature(OpAdd)}},
+		{name: "named account", tx: Transaction{Operation: OpSet, Account: "savings"}, want: []string{operationFeature(OpSet), FeatureAccounts}},
+		{name: "converted", tx: Transaction{Operation: OpAdd, Conversion: &Conversion{}}, want: []string{operationFeature(OpAdd), FeatureCurrencies}},
+		{name: "capturing a hold", tx: Transaction{Operation: OpSubtract, Hold: uuid.New()}, want: []string{operationFeature(OpSubtract), FeatureHolds}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.tx.requiredFeatures()
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckFeatures(t *testing.T) {
+	n := &Node{
+		Type: "Coordinator",
+		c_participantClients: map[string]*ConnectionData{
+			"A": {Name: "A", Version: 1, Features: []string{FeatureSubtrees, operationFeature(OpAdd)}},
+			"B": {Name: "B", Version: 1, Features: []string{FeatureSagas, operationFeature(OpAdd)}},
+			"L": {Name: "L", Version: 0},
+		},
+		c_routes: map[string]*RouteData{
+			"C": {Name: "C", Via: "A", Version: 1, Features: []string{FeatureSagas, operationFeature(OpAdd)}},
+			"D": {Name: "D", Via: "B", Version: 1, Features: []string{FeatureSagas, operationFeature(OpAdd)}},
+		},
+	}
+	tests := []struct {
+		name    string
+		tx      Transaction
+		saga    bool
+		wantErr bool
+	}{
+		{name: "supported", tx: Transaction{Name: "A", Operation: OpAdd}},
+		{name: "operation missing", tx: Transaction{Name: "A", Operation: OpSubtract}, wantErr: true},
+		{name: "saga step", tx: Transaction{Name: "B", Operation: OpAdd}, saga: true},
+		{name: "saga step without sagas", tx: Transaction{Name: "A", Operation: OpAdd}, saga: true, wantErr: true},
+		{name: "legacy node", tx: Transaction{Name: "L", Operation: OpMultiply, Account: "savings"}},
+		{name: "through a sub-coordinator", tx: Transaction{Name: "C", Operation: OpAdd}},
+		{name: "through one without subtrees", tx: Transaction{Name: "D", Operation: OpAdd}, wantErr: true},
+		{name: "saga step past one without subtrees", tx: Transaction{Name: "D", Operation: OpAdd}, saga: true},
+		{name: "unknown participant", tx: Transaction{Name: "Z", Operation: OpAdd}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := n.checkFeatures([]Transaction{tt.tx}, tt.saga)
+			if tt.wantErr && err == nil {
+				t.Fatal("supported, want an error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
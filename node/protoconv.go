@@ -0,0 +1,254 @@
+package node
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Messages in pb/twophasecommit.proto mirror the Go request and response
+// types field for field, so they are converted by reflection rather than by
+// hand. Fields match by name ignoring case and underscores. Decimals and UUIDs
+// travel as their text form, times and durations as the well known types.
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+func protoFieldKey(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// Proto field of msg for each exported field of the struct type t
+func protoFields(t reflect.Type, msg protoreflect.MessageDescriptor) ([]protoreflect.FieldDescriptor, error) {
+	byKey := make(map[string]protoreflect.FieldDescriptor)
+	fields := msg.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		byKey[protoFieldKey(string(fields.Get(i).Name()))] = fields.Get(i)
+	}
+	result := make([]protoreflect.FieldDescriptor, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		fd, ok := byKey[protoFieldKey(t.Field(i).Name)]
+		if !ok {
+			return nil, fmt.Errorf("%s has no field for %s.%s", msg.FullName(), t.Name(), t.Field(i).Name)
+		}
+		result[i] = fd
+	}
+	return result, nil
+}
+
+// Fill msg from the struct v
+func toProto(v reflect.Value, msg protoreflect.Message) error {
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	fields, err := protoFields(v.Type(), msg.Descriptor())
+	if err != nil {
+		return err
+	}
+	for i, fd := range fields {
+		if fd == nil {
+			continue
+		}
+		field := v.Field(i)
+		switch {
+		case fd.IsList():
+			list := msg.Mutable(fd).List()
+			for j := 0; j < field.Len(); j++ {
+				value, err := toProtoValue(field.Index(j), fd, list.NewElement)
+				if err != nil {
+					return err
+				}
+				list.Append(value)
+			}
+		case fd.IsMap():
+			m := msg.Mutable(fd).Map()
+			iter := field.MapRange()
+			for iter.Next() {
+				value, err := toProtoValue(iter.Value(), fd.MapValue(), m.NewValue)
+				if err != nil {
+					return err
+				}
+				m.Set(protoreflect.ValueOfString(iter.Key().String()).MapKey(), value)
+			}
+		default:
+			if isUnset(field) {
+				continue
+			}
+			value, err := toProtoValue(field, fd, func() protoreflect.Value { return msg.NewField(fd) })
+			if err != nil {
+				return err
+			}
+			msg.Set(fd, value)
+		}
+	}
+	return nil
+}
+
+// Values left out of the message, the receiver decoding them to zero values
+func isUnset(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer:
+		return v.IsNil()
+	case reflect.Struct:
+		return v.IsZero()
+	}
+	return false
+}
+
+func toProtoValue(v reflect.Value, fd protoreflect.FieldDescriptor, newMessage func() protoreflect.Value) (protoreflect.Value, error) {
+	switch {
+	case v.Type() == timeType:
+		return protoreflect.ValueOfMessage(timestamppb.New(v.Interface().(time.Time)).ProtoReflect()), nil
+	case v.Type() == durationType:
+		return protoreflect.ValueOfMessage(durationpb.New(v.Interface().(time.Duration)).ProtoReflect()), nil
+	case v.Type().Implements(textMarshalerType) && fd.Kind() == protoreflect.StringKind:
+		if v.IsZero() {
+			return protoreflect.ValueOfString(""), nil
+		}
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfString(string(text)), nil
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		value := newMessage()
+		if err := toProto(v, value.Message()); err != nil {
+			return protoreflect.Value{}, err
+		}
+		return value, nil
+	case reflect.String:
+		return protoreflect.ValueOfString(v.String()), nil
+	case reflect.Bool:
+		return protoreflect.ValueOfBool(v.Bool()), nil
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		if fd.Kind() == protoreflect.Int32Kind {
+			return protoreflect.ValueOfInt32(int32(v.Int())), nil
+		}
+		return protoreflect.ValueOfInt64(v.Int()), nil
+	case reflect.Float64:
+		return protoreflect.ValueOfFloat64(v.Float()), nil
+	}
+	return protoreflect.Value{}, fmt.Errorf("cannot convert %s to %s", v.Type(), fd.FullName())
+}
+
+// Fill the struct v points to from msg
+func fromProto(msg protoreflect.Message, v reflect.Value) error {
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	fields, err := protoFields(v.Type(), msg.Descriptor())
+	if err != nil {
+		return err
+	}
+	for i, fd := range fields {
+		if fd == nil {
+			continue
+		}
+		field := v.Field(i)
+		switch {
+		case fd.IsList():
+			list := msg.Get(fd).List()
+			if list.Len() == 0 {
+				continue
+			}
+			slice := reflect.MakeSlice(field.Type(), list.Len(), list.Len())
+			for j := 0; j < list.Len(); j++ {
+				if err := fromProtoValue(list.Get(j), fd, slice.Index(j)); err != nil {
+					return err
+				}
+			}
+			field.Set(slice)
+		case fd.IsMap():
+			m := msg.Get(fd).Map()
+			if m.Len() == 0 {
+				continue
+			}
+			result := reflect.MakeMapWithSize(field.Type(), m.Len())
+			var rangeErr error
+			m.Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+				elem := reflect.New(field.Type().Elem()).Elem()
+				if rangeErr = fromProtoValue(value, fd.MapValue(), elem); rangeErr != nil {
+					return false
+				}
+				result.SetMapIndex(reflect.ValueOf(key.String()).Convert(field.Type().Key()), elem)
+				return true
+			})
+			if rangeErr != nil {
+				return rangeErr
+			}
+			field.Set(result)
+		default:
+			if fd.Message() != nil && !msg.Has(fd) {
+				continue
+			}
+			if err := fromProtoValue(msg.Get(fd), fd, field); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func fromProtoValue(value protoreflect.Value, fd protoreflect.FieldDescriptor, v reflect.Value) error {
+	switch {
+	case v.Type() == timeType:
+		ts := &timestamppb.Timestamp{}
+		copyMessage(value.Message(), ts.ProtoReflect())
+		v.Set(reflect.ValueOf(ts.AsTime().Local()))
+		return nil
+	case v.Type() == durationType:
+		d := &durationpb.Duration{}
+		copyMessage(value.Message(), d.ProtoReflect())
+		v.SetInt(int64(d.AsDuration()))
+		return nil
+	case reflect.PointerTo(v.Type()).Implements(textMarshalerType) && fd.Kind() == protoreflect.StringKind:
+		if value.String() == "" {
+			return nil
+		}
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value.String()))
+	}
+	if v.Kind() == reflect.Pointer {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		return fromProto(value.Message(), v)
+	case reflect.String:
+		v.SetString(value.String())
+	case reflect.Bool:
+		v.SetBool(value.Bool())
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		v.SetInt(value.Int())
+	case reflect.Float64:
+		v.SetFloat(value.Float())
+	default:
+		return fmt.Errorf("cannot convert %s to %s", fd.FullName(), v.Type())
+	}
+	return nil
+}
+
+// Well known types may come as dynamic messages, copy them into the concrete ones
+func copyMessage(from protoreflect.Message, to protoreflect.Message) {
+	from.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		to.Set(to.Descriptor().Fields().ByNumber(fd.Number()), value)
+		return true
+	})
+}
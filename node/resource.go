@@ -0,0 +1,989 @@
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResourceManager is the transactional resource behind a participant, in the
+// style of an XA resource manager. The participant's RPC layer drives it
+// through the two phases and never touches the underlying data itself.
+type ResourceManager interface {
+	// Current value of key, "" for single-valued resources
+	Read(key string) (Decimal, error)
+	// Check the change can be applied and hold it until Commit or Abort
+	Prepare(transactionID uuid.UUID, op ResourceOperation) error
+	Commit(transactionID uuid.UUID) error
+	Abort(transactionID uuid.UUID) error
+	// Transactions prepared but not yet committed or aborted
+	Recover() ([]uuid.UUID, error)
+}
+
+type ResourceOperation struct {
+	Key          string        `json:"key"`
+	Operation    OperationType `json:"operation"`
+	Amount       Decimal       `json:"amount"`
+	Expected     Decimal       `json:"expected"`
+	Counterparts []string      `json:"counterparts"` // Other participants in the transaction, for history
+	External     bool          `json:"external"`     // Money entering or leaving the cluster, as deposits and withdrawals do
+	Currency     string        `json:"currency"`     // Of Amount, the account's currency if empty
+	Conversion   *Conversion   `json:"conversion"`
+	Hold         uuid.UUID     `json:"hold"` // Hold a subtract captures, nil if none
+}
+
+var (
+	errAlreadyPromised     = errors.New("already promised")
+	errInsufficientBalance = &PolicyViolation{ReasonInsufficientBalance, "insufficient balance"}
+	errNotPrepared         = errors.New("transaction not prepared")
+)
+
+// Prepare and commit op in one go, for changes outside of two-phase commit
+func applyNow(rm ResourceManager, op ResourceOperation) error {
+	transactionID := uuid.New()
+	if err := rm.Prepare(transactionID, op); err != nil {
+		return err
+	}
+	return rm.Commit(transactionID)
+}
+
+// Account used when a request does not name one
+const defaultAccount = "main"
+
+var accountNamePattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// Optional interface for resources holding several named accounts
+type AccountManager interface {
+	// New account holding amounts with scale decimal places, starting at initial
+	OpenAccount(account string, details AccountDetails, scale int, initial Decimal) error
+	// Freeze, unfreeze or close account, returning its new status
+	ChangeAccount(account string, operation OperationType) (AccountStatus, error)
+	ListAccounts() ([]string, error)
+	AccountDetails(account string) (AccountDetails, error)
+}
+
+// BalanceResource keeps one value per account in a Storage backend, along
+// with a ledger of committed changes. Each account can be promised to only one
+// transaction at a time.
+type BalanceResource struct {
+	mutex    sync.Mutex
+	storage  Storage
+	locks    map[string]uuid.UUID
+	prepared map[uuid.UUID]*preparedChanges
+	ledgered map[uuid.UUID]bool // Entries already in the ledger
+	holds    map[uuid.UUID]Hold
+	debits   debitLog
+}
+
+type preparedChanges struct {
+	Balances map[string]Decimal // New balance per account
+	Entries  []LedgerEntry      // Ledger entries written on commit
+	Holds    []uuid.UUID        // Holds captured, dropped on commit
+}
+
+const (
+	accountKeySuffix     = ".data"
+	policyKeySuffix      = ".policy"
+	accountMetaKeySuffix = ".meta"
+	preparedKey          = "prepared.json"
+	holdsKey             = "holds.json"
+)
+
+func NewBalanceResource(storage Storage) (*BalanceResource, error) {
+	r := &BalanceResource{
+		storage:  storage,
+		locks:    make(map[string]uuid.UUID),
+		prepared: make(map[uuid.UUID]*preparedChanges),
+		ledgered: make(map[uuid.UUID]bool),
+		holds:    make(map[uuid.UUID]Hold),
+		debits:   make(debitLog),
+	}
+	if exists, err := r.accountExists(defaultAccount); err != nil {
+		return nil, err
+	} else if !exists {
+		if err := r.writeBalance(defaultAccount, NewDecimal(0, defaultScale)); err != nil {
+			return nil, err
+		}
+	}
+	data, ok, err := storage.Get(preparedKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reading prepared transactions: %v", err)
+	}
+	if ok {
+		if err := json.Unmarshal(data, &r.prepared); err != nil {
+			return nil, fmt.Errorf("error reading prepared transactions: %v", err)
+		}
+	}
+	for transactionID, changes := range r.prepared {
+		for account := range changes.Balances {
+			r.locks[account] = transactionID
+		}
+	}
+	data, ok, err = storage.Get(holdsKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reading holds: %v", err)
+	}
+	if ok {
+		if err := json.Unmarshal(data, &r.holds); err != nil {
+			return nil, fmt.Errorf("error reading holds: %v", err)
+		}
+	}
+	entries, err := readLedger(storage)
+	if err != nil {
+		return nil, err
+	}
+	// Redo the last lifecycle change of each account in case it was cut short
+	lifecycle := make(map[string]LedgerEntry)
+	since := time.Now().Add(-dailyDebitWindow)
+	for _, entry := range entries {
+		r.ledgered[entry.EntryID] = true
+		if !entry.Time.Before(since) {
+			r.debits.record(entry)
+		}
+		if entry.Details != nil {
+			lifecycle[entry.Account] = entry
+		}
+	}
+	for _, entry := range lifecycle {
+		if err := r.applyLifecycle(entry); err != nil {
+			return nil, fmt.Errorf("error replaying %s of %s: %v", entry.Operation, entry.Account, err)
+		}
+	}
+	return r, nil
+}
+
+func (r *BalanceResource) Read(key string) (Decimal, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.readBalance(accountOrDefault(key))
+}
+
+func (r *BalanceResource) Prepare(transactionID uuid.UUID, op ResourceOperation) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	changes, ok := r.prepared[transactionID]
+	if !ok {
+		changes = &preparedChanges{Balances: make(map[string]Decimal)}
+	}
+	// Nothing to hold when only the subtree below takes part
+	if op.Operation == "" {
+		r.prepared[transactionID] = changes
+		return r.writePrepared()
+	}
+
+	account := accountOrDefault(op.Key)
+	if owner, locked := r.locks[account]; locked && owner != transactionID {
+		return errAlreadyPromised
+	}
+	details, err := r.readDetails(account)
+	if err != nil {
+		return err
+	}
+	if err := checkCurrency(account, details.Currency, op); err != nil {
+		return err
+	}
+	bal, ok := changes.Balances[account]
+	if !ok {
+		var err error
+		bal, err = r.readBalance(account)
+		if err != nil {
+			return fmt.Errorf("error getting balance: %v", err)
+		}
+	}
+	if op.Hold != uuid.Nil {
+		if err := r.checkCapture(account, op); err != nil {
+			return err
+		}
+	}
+	newBalance, err := applyOperation(bal, op)
+	if err != nil {
+		return err
+	}
+	if err := details.check(account, bal, newBalance); err != nil {
+		return err
+	}
+	// Limits apply to the available balance, leaving out the hold being captured
+	held, err := r.held(account, op.Hold)
+	if err != nil {
+		return err
+	}
+	available, err := bal.Sub(held)
+	if err != nil {
+		return err
+	}
+	availableAfter, err := newBalance.Sub(held)
+	if err != nil {
+		return err
+	}
+	policy, err := r.readPolicy(account)
+	if err != nil {
+		return err
+	}
+	recent := r.debits.recent(account)
+	for _, entry := range changes.Entries {
+		if entry.Account == account {
+			recent = append(recent, entry)
+		}
+	}
+	if err := policy.check(available, availableAfter, recent); err != nil {
+		return err
+	}
+	changes.Balances[account] = newBalance
+	if op.Hold != uuid.Nil {
+		changes.Holds = append(changes.Holds, op.Hold)
+	}
+	changes.Entries = append(changes.Entries, LedgerEntry{
+		EntryID:       uuid.New(),
+		TransactionID: transactionID,
+		Account:       account,
+		Operation:     op.Operation,
+		Amount:        op.Amount,
+		Before:        bal,
+		After:         newBalance,
+		Counterparts:  op.Counterparts,
+		External:      op.External,
+		Currency:      details.Currency,
+		Conversion:    op.Conversion,
+		Hold:          op.Hold,
+	})
+	r.prepared[transactionID] = changes
+	r.locks[account] = transactionID
+	return r.writePrepared()
+}
+
+func (r *BalanceResource) Commit(transactionID uuid.UUID) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	changes, ok := r.prepared[transactionID]
+	if !ok {
+		return errNotPrepared
+	}
+	// Ledger first: after a crash the commit is redone and entries already written are skipped
+	now := time.Now()
+	for _, entry := range changes.Entries {
+		if r.ledgered[entry.EntryID] {
+			continue
+		}
+		entry.Time = now
+		if err := appendLedger(r.storage, []LedgerEntry{entry}); err != nil {
+			return err
+		}
+		r.ledgered[entry.EntryID] = true
+		r.debits.record(entry)
+	}
+	for account, newBalance := range changes.Balances {
+		if err := r.writeBalance(account, newBalance); err != nil {
+			return err
+		}
+		delete(r.locks, account)
+	}
+	if len(changes.Holds) > 0 {
+		for _, holdID := range changes.Holds {
+			delete(r.holds, holdID)
+		}
+		if err := r.writeHolds(); err != nil {
+			return err
+		}
+	}
+	delete(r.prepared, transactionID)
+	return r.writePrepared()
+}
+
+func (r *BalanceResource) Abort(transactionID uuid.UUID) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	changes, ok := r.prepared[transactionID]
+	if !ok {
+		return errNotPrepared
+	}
+	for account := range changes.Balances {
+		delete(r.locks, account)
+	}
+	delete(r.prepared, transactionID)
+	return r.writePrepared()
+}
+
+func (r *BalanceResource) Recover() ([]uuid.UUID, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var transactionIDs []uuid.UUID
+	for transactionID := range r.prepared {
+		transactionIDs = append(transactionIDs, transactionID)
+	}
+	return transactionIDs, nil
+}
+
+func (r *BalanceResource) OpenAccount(account string, details AccountDetails, scale int, initial Decimal) error {
+	if err := validateNewAccount(account, details.Currency, scale); err != nil {
+		return err
+	}
+	initial, err := openingBalance(initial, scale)
+	if err != nil {
+		return err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if exists, err := r.accountExists(account); err != nil {
+		return err
+	} else if exists {
+		return fmt.Errorf("account %s already exists", account)
+	}
+	details.Status = AccountOpen
+	return r.recordLifecycle(lifecycleEntry(account, OpOpen, NewDecimal(0, scale), initial, details))
+}
+
+func (r *BalanceResource) ChangeAccount(account string, operation OperationType) (AccountStatus, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	balance, err := r.readBalance(account)
+	if err != nil {
+		return "", err
+	}
+	details, err := r.readDetails(account)
+	if err != nil {
+		return "", err
+	}
+	if details.Status, err = statusTransition(operation, details.Status); err != nil {
+		return "", err
+	}
+	if operation == OpClose {
+		if _, locked := r.locks[account]; locked {
+			return "", fmt.Errorf("account %s has a transaction in progress", account)
+		}
+		if !balance.IsZero() {
+			return "", fmt.Errorf("account %s still holds %s", account, balance)
+		}
+		if held, err := r.held(account, uuid.Nil); err != nil {
+			return "", err
+		} else if !held.IsZero() {
+			return "", fmt.Errorf("account %s has holds of %s", account, held)
+		}
+	}
+	if err := r.recordLifecycle(lifecycleEntry(account, operation, balance, balance, details)); err != nil {
+		return "", err
+	}
+	return details.Status, nil
+}
+
+func (r *BalanceResource) AccountDetails(account string) (AccountDetails, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if exists, err := r.accountExists(account); err != nil {
+		return AccountDetails{}, err
+	} else if !exists {
+		return AccountDetails{}, fmt.Errorf("account %s not found", account)
+	}
+	return r.readDetails(account)
+}
+
+// Write a lifecycle change to the ledger, then apply it
+func (r *BalanceResource) recordLifecycle(entry LedgerEntry) error {
+	if err := appendLedger(r.storage, []LedgerEntry{entry}); err != nil {
+		return err
+	}
+	r.ledgered[entry.EntryID] = true
+	return r.applyLifecycle(entry)
+}
+
+// Bring the account up to date with a lifecycle change, which may already be applied
+func (r *BalanceResource) applyLifecycle(entry LedgerEntry) error {
+	// Details first, an account only exists once its balance is written
+	if err := r.writeDetails(entry.Account, *entry.Details); err != nil {
+		return err
+	}
+	if entry.Operation != OpOpen {
+		return nil
+	}
+	if exists, err := r.accountExists(entry.Account); err != nil || exists {
+		return err
+	}
+	return r.writeBalance(entry.Account, entry.After)
+}
+
+func (r *BalanceResource) PlaceHold(account string, amount Decimal, ttl time.Duration) (Hold, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	balance, err := r.readBalance(account)
+	if err != nil {
+		return Hold{}, err
+	}
+	if amount.Sign() <= 0 {
+		return Hold{}, fmt.Errorf("hold amount must be positive")
+	}
+	if amount, err = exactAmount(balance, amount); err != nil {
+		return Hold{}, err
+	}
+	// Checked as if the amount were debited now
+	held, err := r.held(account, uuid.Nil)
+	if err != nil {
+		return Hold{}, err
+	}
+	available, err := balance.Sub(held)
+	if err != nil {
+		return Hold{}, err
+	}
+	availableAfter, err := available.Sub(amount)
+	if err != nil {
+		return Hold{}, err
+	}
+	details, err := r.readDetails(account)
+	if err != nil {
+		return Hold{}, err
+	}
+	if err := details.check(account, available, availableAfter); err != nil {
+		return Hold{}, err
+	}
+	policy, err := r.readPolicy(account)
+	if err != nil {
+		return Hold{}, err
+	}
+	if err := policy.check(available, availableAfter, r.debits.recent(account)); err != nil {
+		return Hold{}, err
+	}
+
+	now := time.Now()
+	hold := Hold{HoldID: uuid.New(), Account: account, Amount: amount, Created: now, Expires: now.Add(ttl)}
+	r.holds[hold.HoldID] = hold
+	return hold, r.writeHolds()
+}
+
+func (r *BalanceResource) ReleaseHold(holdID uuid.UUID) (Hold, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	hold, err := r.activeHold(holdID)
+	if err != nil {
+		return Hold{}, err
+	}
+	if r.capturing(holdID) {
+		return Hold{}, fmt.Errorf("hold %s is being captured", holdID)
+	}
+	delete(r.holds, holdID)
+	return hold, r.writeHolds()
+}
+
+func (r *BalanceResource) FindHold(holdID uuid.UUID) (Hold, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.activeHold(holdID)
+}
+
+func (r *BalanceResource) Holds(account string) ([]Hold, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	now := time.Now()
+	var holds []Hold
+	for _, hold := range r.holds {
+		if hold.Account == account && !hold.expired(now) {
+			holds = append(holds, hold)
+		}
+	}
+	sort.Slice(holds, func(i, j int) bool { return holds[i].Created.Before(holds[j].Created) })
+	return holds, nil
+}
+
+func (r *BalanceResource) Available(account string) (Decimal, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	balance, err := r.readBalance(account)
+	if err != nil {
+		return Decimal{}, err
+	}
+	held, err := r.held(account, uuid.Nil)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return balance.Sub(held)
+}
+
+// Total of the holds on account that have not expired, leaving out except
+func (r *BalanceResource) held(account string, except uuid.UUID) (Decimal, error) {
+	total := NewDecimal(0, 0)
+	now := time.Now()
+	for holdID, hold := range r.holds {
+		if hold.Account != account || holdID == except || hold.expired(now) {
+			continue
+		}
+		var err error
+		if total, err = total.Add(hold.Amount); err != nil {
+			return Decimal{}, err
+		}
+	}
+	return total, nil
+}
+
+func (r *BalanceResource) activeHold(holdID uuid.UUID) (Hold, error) {
+	hold, ok := r.holds[holdID]
+	if !ok || hold.expired(time.Now()) {
+		return Hold{}, fmt.Errorf("hold %s not found or expired", holdID)
+	}
+	return hold, nil
+}
+
+// Whether a prepared transaction captures holdID
+func (r *BalanceResource) capturing(holdID uuid.UUID) bool {
+	for _, changes := range r.prepared {
+		if slices.Contains(changes.Holds, holdID) {
+			return true
+		}
+	}
+	return false
+}
+
+// A debit capturing a hold must be on its account and within its amount
+func (r *BalanceResource) checkCapture(account string, op ResourceOperation) error {
+	hold, err := r.activeHold(op.Hold)
+	if err != nil {
+		return err
+	}
+	if r.capturing(op.Hold) {
+		return fmt.Errorf("hold %s is already being captured", op.Hold)
+	}
+	if op.Operation != OpSubtract || hold.Account != account {
+		return fmt.Errorf("hold %s can only be captured by a subtract from %s", op.Hold, hold.Account)
+	}
+	if op.Amount.Cmp(hold.Amount) > 0 {
+		return fmt.Errorf("capture of %s exceeds hold of %s", op.Amount, hold.Amount)
+	}
+	return nil
+}
+
+// Save holds, dropping expired ones no transaction is capturing
+func (r *BalanceResource) writeHolds() error {
+	now := time.Now()
+	for holdID, hold := range r.holds {
+		if hold.expired(now) && !r.capturing(holdID) {
+			delete(r.holds, holdID)
+		}
+	}
+	data, err := json.Marshal(r.holds)
+	if err != nil {
+		return err
+	}
+	return r.storage.Put(holdsKey, data)
+}
+
+func (r *BalanceResource) History(account string) ([]LedgerEntry, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.history(account)
+}
+
+func (r *BalanceResource) Snapshot() (ResourceSnapshot, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	snapshot := ResourceSnapshot{Balances: make(map[string]Decimal), Currencies: make(map[string]string)}
+	keys, err := r.storage.Keys()
+	if err != nil {
+		return snapshot, err
+	}
+	for _, key := range keys {
+		if !strings.HasSuffix(key, accountKeySuffix) {
+			continue
+		}
+		account := strings.TrimSuffix(key, accountKeySuffix)
+		if snapshot.Balances[account], err = r.readBalance(account); err != nil {
+			return snapshot, err
+		}
+		details, err := r.readDetails(account)
+		if err != nil {
+			return snapshot, err
+		}
+		snapshot.Currencies[account] = details.Currency
+	}
+	if snapshot.Ledger, err = readLedger(r.storage); err != nil {
+		return snapshot, err
+	}
+	for transactionID := range r.prepared {
+		snapshot.InDoubt = append(snapshot.InDoubt, transactionID)
+	}
+	return snapshot, nil
+}
+
+func (r *BalanceResource) Policy(account string) (AccountPolicy, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if exists, err := r.accountExists(account); err != nil {
+		return AccountPolicy{}, err
+	} else if !exists {
+		return AccountPolicy{}, fmt.Errorf("account %s not found", account)
+	}
+	return r.readPolicy(account)
+}
+
+func (r *BalanceResource) SetPolicy(account string, policy AccountPolicy) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if exists, err := r.accountExists(account); err != nil {
+		return err
+	} else if !exists {
+		return fmt.Errorf("account %s not found", account)
+	}
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	return r.storage.Put(account+policyKeySuffix, data)
+}
+
+func (r *BalanceResource) history(account string) ([]LedgerEntry, error) {
+	entries, err := readLedger(r.storage)
+	if err != nil {
+		return nil, err
+	}
+	var history []LedgerEntry
+	for _, entry := range entries {
+		if entry.Account == account {
+			history = append(history, entry)
+		}
+	}
+	return history, nil
+}
+
+func (r *BalanceResource) ListAccounts() ([]string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	keys, err := r.storage.Keys()
+	if err != nil {
+		return nil, err
+	}
+	var accounts []string
+	for _, key := range keys {
+		if strings.HasSuffix(key, accountKeySuffix) {
+			accounts = append(accounts, strings.TrimSuffix(key, accountKeySuffix))
+		}
+	}
+	return accounts, nil
+}
+
+func validateNewAccount(account string, currency string, scale int) error {
+	if !accountNamePattern.MatchString(account) {
+		return fmt.Errorf("invalid account name %q", account)
+	}
+	if !currencyPattern.MatchString(currency) {
+		return fmt.Errorf("invalid currency %q", currency)
+	}
+	if scale < 0 || scale > maxDecimalScale {
+		return fmt.Errorf("scale must be between 0 and %d", maxDecimalScale)
+	}
+	return nil
+}
+
+// Initial balance of a new account at its scale
+func openingBalance(initial Decimal, scale int) (Decimal, error) {
+	if initial.Sign() < 0 {
+		return Decimal{}, fmt.Errorf("initial balance must not be negative")
+	}
+	return initial.Rescale(scale)
+}
+
+// Amounts of op must be in the account's currency by the time they are prepared
+func checkCurrency(account string, currency string, op ResourceOperation) error {
+	if op.Currency != "" && op.Currency != currency {
+		return invalidOperation(fmt.Errorf("account %s holds %s, not %s", account, currency, op.Currency))
+	}
+	return nil
+}
+
+func accountOrDefault(account string) string {
+	if account == "" {
+		return defaultAccount
+	}
+	return account
+}
+
+func (r *BalanceResource) accountExists(account string) (bool, error) {
+	_, ok, err := r.storage.Get(account + accountKeySuffix)
+	return ok, err
+}
+
+// Details of account, defaults for accounts that have none stored
+func (r *BalanceResource) readDetails(account string) (AccountDetails, error) {
+	details := defaultAccountDetails
+	data, ok, err := r.storage.Get(account + accountMetaKeySuffix)
+	if err != nil || !ok {
+		return details, err
+	}
+	if err := json.Unmarshal(data, &details); err != nil {
+		return details, fmt.Errorf("error reading details of %s: %v", account, err)
+	}
+	return details, nil
+}
+
+func (r *BalanceResource) writeDetails(account string, details AccountDetails) error {
+	data, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+	return r.storage.Put(account+accountMetaKeySuffix, data)
+}
+
+// Policy of account, no limits if none was set
+func (r *BalanceResource) readPolicy(account string) (AccountPolicy, error) {
+	var policy AccountPolicy
+	data, ok, err := r.storage.Get(account + policyKeySuffix)
+	if err != nil || !ok {
+		return policy, err
+	}
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return policy, fmt.Errorf("error reading policy of %s: %v", account, err)
+	}
+	return policy, nil
+}
+
+func (r *BalanceResource) writePrepared() error {
+	data, err := json.Marshal(r.prepared)
+	if err != nil {
+		return err
+	}
+	return r.storage.Put(preparedKey, data)
+}
+
+func (r *BalanceResource) readBalance(account string) (Decimal, error) {
+	data, ok, err := r.storage.Get(account + accountKeySuffix)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("error reading balance: %v", err)
+	}
+	if !ok {
+		return Decimal{}, fmt.Errorf("account %s not found", account)
+	}
+
+	// Parse the content as an exact decimal, its decimal places being the account's scale
+	balance, err := ParseDecimal(string(data))
+	if err != nil {
+		return Decimal{}, fmt.Errorf("error parsing balance: %v", err)
+	}
+	return balance, nil
+}
+
+func (r *BalanceResource) writeBalance(account string, balance Decimal) error {
+	if err := r.storage.Put(account+accountKeySuffix, []byte(balance.String())); err != nil {
+		return fmt.Errorf("error writing balance: %v", err)
+	}
+	return nil
+}
+
+// KeyValueResource is an in-memory map of named values. Transactions lock the
+// keys they change, so transactions on different keys can be prepared at once.
+// Like BalanceResource, it starts with the default account only and holds
+// values for opened accounts alone.
+type KeyValueResource struct {
+	mutex    sync.Mutex
+	values   map[string]Decimal
+	details  map[string]AccountDetails
+	policies map[string]AccountPolicy
+	debits   debitLog
+	locks    map[string]uuid.UUID
+	prepared map[uuid.UUID]map[string]Decimal
+}
+
+func NewKeyValueResource() *KeyValueResource {
+	return &KeyValueResource{
+		values:   map[string]Decimal{defaultAccount: NewDecimal(0, defaultScale)},
+		details:  make(map[string]AccountDetails),
+		policies: make(map[string]AccountPolicy),
+		debits:   make(debitLog),
+		locks:    make(map[string]uuid.UUID),
+		prepared: make(map[uuid.UUID]map[string]Decimal),
+	}
+}
+
+func (r *KeyValueResource) Read(key string) (Decimal, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	account := accountOrDefault(key)
+	value, ok := r.values[account]
+	if !ok {
+		return Decimal{}, fmt.Errorf("account %s not found", account)
+	}
+	return value, nil
+}
+
+func (r *KeyValueResource) Prepare(transactionID uuid.UUID, op ResourceOperation) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if op.Operation == "" {
+		if _, ok := r.prepared[transactionID]; !ok {
+			r.prepared[transactionID] = make(map[string]Decimal)
+		}
+		return nil
+	}
+	account := accountOrDefault(op.Key)
+	if owner, locked := r.locks[account]; locked && owner != transactionID {
+		return errAlreadyPromised
+	}
+	if op.Hold != uuid.Nil {
+		return fmt.Errorf("resource does not support holds")
+	}
+	committed, ok := r.values[account]
+	if !ok {
+		return fmt.Errorf("account %s not found", account)
+	}
+	details := r.accountDetails(account)
+	if err := checkCurrency(account, details.Currency, op); err != nil {
+		return err
+	}
+	changes, ok := r.prepared[transactionID]
+	if !ok {
+		changes = make(map[string]Decimal)
+	}
+	current, pending := changes[account]
+	if !pending {
+		current = committed
+	}
+	newValue, err := applyOperation(current, op)
+	if err != nil {
+		return err
+	}
+	if err := details.check(account, current, newValue); err != nil {
+		return err
+	}
+	recent := r.debits.recent(account)
+	if pending {
+		recent = append(recent, LedgerEntry{Account: account, Before: committed, After: current})
+	}
+	if err := r.policies[account].check(current, newValue, recent); err != nil {
+		return err
+	}
+	changes[account] = newValue
+	r.prepared[transactionID] = changes
+	r.locks[account] = transactionID
+	return nil
+}
+
+func (r *KeyValueResource) Commit(transactionID uuid.UUID) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	changes, ok := r.prepared[transactionID]
+	if !ok {
+		return errNotPrepared
+	}
+	now := time.Now()
+	for key, value := range changes {
+		r.debits.record(LedgerEntry{Time: now, TransactionID: transactionID, Account: key, Before: r.values[key], After: value})
+		r.values[key] = value
+		delete(r.locks, key)
+	}
+	delete(r.prepared, transactionID)
+	return nil
+}
+
+func (r *KeyValueResource) Abort(transactionID uuid.UUID) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	changes, ok := r.prepared[transactionID]
+	if !ok {
+		return errNotPrepared
+	}
+	for key := range changes {
+		delete(r.locks, key)
+	}
+	delete(r.prepared, transactionID)
+	return nil
+}
+
+func (r *KeyValueResource) Recover() ([]uuid.UUID, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var transactionIDs []uuid.UUID
+	for transactionID := range r.prepared {
+		transactionIDs = append(transactionIDs, transactionID)
+	}
+	return transactionIDs, nil
+}
+
+func (r *KeyValueResource) OpenAccount(account string, details AccountDetails, scale int, initial Decimal) error {
+	if err := validateNewAccount(account, details.Currency, scale); err != nil {
+		return err
+	}
+	initial, err := openingBalance(initial, scale)
+	if err != nil {
+		return err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.values[account]; ok {
+		return fmt.Errorf("account %s already exists", account)
+	}
+	details.Status = AccountOpen
+	r.values[account] = initial
+	r.details[account] = details
+	return nil
+}
+
+func (r *KeyValueResource) ChangeAccount(account string, operation OperationType) (AccountStatus, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	value, ok := r.values[account]
+	if !ok {
+		return "", fmt.Errorf("account %s not found", account)
+	}
+	details := r.accountDetails(account)
+	var err error
+	if details.Status, err = statusTransition(operation, details.Status); err != nil {
+		return "", err
+	}
+	if operation == OpClose {
+		if _, locked := r.locks[account]; locked {
+			return "", fmt.Errorf("account %s has a transaction in progress", account)
+		}
+		if !value.IsZero() {
+			return "", fmt.Errorf("account %s still holds %s", account, value)
+		}
+	}
+	r.details[account] = details
+	return details.Status, nil
+}
+
+func (r *KeyValueResource) AccountDetails(account string) (AccountDetails, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.values[account]; !ok {
+		return AccountDetails{}, fmt.Errorf("account %s not found", account)
+	}
+	return r.accountDetails(account), nil
+}
+
+func (r *KeyValueResource) Policy(account string) (AccountPolicy, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.values[account]; !ok {
+		return AccountPolicy{}, fmt.Errorf("account %s not found", account)
+	}
+	return r.policies[account], nil
+}
+
+func (r *KeyValueResource) SetPolicy(account string, policy AccountPolicy) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.values[account]; !ok {
+		return fmt.Errorf("account %s not found", account)
+	}
+	r.policies[account] = policy
+	return nil
+}
+
+func (r *KeyValueResource) accountDetails(key string) AccountDetails {
+	if details, ok := r.details[key]; ok {
+		return details
+	}
+	return defaultAccountDetails
+}
+
+func (r *KeyValueResource) ListAccounts() ([]string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var accounts []string
+	for account := range r.values {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+	return accounts, nil
+}
@@ -0,0 +1,105 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestBalanceResourceDailyDebitLimit(t *testing.T) {
+	storage := NewMemoryStorage()
+	r, err := NewBalanceResource(storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deposit := uuid.New()
+	if err := r.Prepare(deposit, ResourceOperation{Operation: OpAdd, Amount: mustDecimal(t, "100")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Commit(deposit); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SetPolicy(defaultAccount, AccountPolicy{DailyDebitLimit: mustDecimal(t, "50")}); err != nil {
+		t.Fatal(err)
+	}
+
+	debit := func(r *BalanceResource, amount string) error {
+		id := uuid.New()
+		if err := r.Prepare(id, ResourceOperation{Operation: OpSubtract, Amount: mustDecimal(t, amount)}); err != nil {
+			return err
+		}
+		return r.Commit(id)
+	}
+	if err := debit(r, "30"); err != nil {
+		t.Fatalf("first debit: %v", err)
+	}
+	if _, err := r.PlaceHold(defaultAccount, mustDecimal(t, "25"), 0); !isViolation(err, ReasonDailyDebitLimit) {
+		t.Fatalf("hold over the daily limit: got %v, want %s", err, ReasonDailyDebitLimit)
+	}
+
+	// Debits already made count after a restart
+	r, err = NewBalanceResource(storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := debit(r, "25"); !isViolation(err, ReasonDailyDebitLimit) {
+		t.Fatalf("debit over the daily limit after restart: got %v, want %s", err, ReasonDailyDebitLimit)
+	}
+	if err := debit(r, "20"); err != nil {
+		t.Fatalf("debit within the daily limit: %v", err)
+	}
+}
+
+func TestKeyValueResourceAccounts(t *testing.T) {
+	r := NewKeyValueResource()
+	tests := []struct {
+		name    string
+		account string
+		op      OperationType
+		amount  string
+		reason  string // Policy violation expected, if any
+		wantErr bool
+	}{
+		{name: "default account", account: "", op: OpAdd, amount: "100"},
+		{name: "default account by name", account: defaultAccount, op: OpSubtract, amount: "10"},
+		{name: "never opened", account: "savings", op: OpAdd, amount: "1", wantErr: true},
+		{name: "opened", account: "spending", op: OpAdd, amount: "5"},
+		{name: "max debit", account: defaultAccount, op: OpSubtract, amount: "30", reason: ReasonMaxDebit},
+		{name: "overdraft", account: "spending", op: OpSubtract, amount: "6", reason: ReasonInsufficientBalance},
+	}
+	if err := r.OpenAccount("spending", AccountDetails{Currency: defaultCurrency}, defaultScale, Decimal{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SetPolicy(defaultAccount, AccountPolicy{MaxDebit: mustDecimal(t, "20")}); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uuid.New()
+			err := r.Prepare(id, ResourceOperation{Key: tt.account, Operation: tt.op, Amount: mustDecimal(t, tt.amount)})
+			switch {
+			case tt.reason != "":
+				if !isViolation(err, tt.reason) {
+					t.Fatalf("got %v, want %s", err, tt.reason)
+				}
+				return
+			case tt.wantErr:
+				if err == nil {
+					t.Fatal("prepared, want an error")
+				}
+				return
+			case err != nil:
+				t.Fatal(err)
+			}
+			if err := r.Commit(id); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+	if balance, err := r.Read(""); err != nil || balance.String() != "90.00" {
+		t.Fatalf("default account holds %v (%v), want 90.00", balance, err)
+	}
+	if _, err := r.Read("savings"); err == nil {
+		t.Fatal("read an account never opened")
+	}
+}
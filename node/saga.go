@@ -0,0 +1,475 @@
+package node
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Saga mode applies each step as an immediate local commit instead of holding a
+// prepared promise. If a step fails, the steps already applied are undone by
+// running their inverse operations in reverse order. The leader replicates the
+// saga's state in the decision log at every step, and a newly elected leader
+// resumes the sagas it finds unfinished.
+
+const (
+	sagaRunning      = "RUNNING"
+	sagaCompleted    = "COMPLETED"
+	sagaCompensating = "COMPENSATING"
+	sagaCompensated  = "COMPENSATED"
+	sagaStuck        = "STUCK" // A compensation failed, needs an operator
+
+	stepPending           = "PENDING"
+	stepApplying          = "APPLYING" // Sent, whether it was applied is not known yet
+	stepApplied           = "APPLIED"
+	stepFailed            = "FAILED"
+	stepCompensated       = "COMPENSATED"
+	stepCompensationError = "COMPENSATION_FAILED"
+
+	compensationRetries = 3
+)
+
+type SagaStep struct {
+	Transaction  Transaction `json:"transaction"`
+	Compensation Transaction `json:"compensation"`
+	Status       string      `json:"status"`
+	Error        string      `json:"error"`
+}
+
+type SagaState struct {
+	SagaID  uuid.UUID  `json:"sagaId"`
+	Status  string     `json:"status"`
+	Steps   []SagaStep `json:"steps"`
+	Started time.Time  `json:"started"`
+}
+
+// Sagas this leader is driving, so none is resumed twice
+type sagaSet struct {
+	mutex  sync.Mutex
+	active map[uuid.UUID]bool
+}
+
+// Inverse of tx, used to compensate it, given the balance before tx was
+// applied or nil when that is unknown
+func compensationFor(tx Transaction, before *Decimal) (Transaction, error) {
+	spec, err := LookupOperation(tx.Operation)
+	if err != nil {
+		return Transaction{}, err
+	}
+	if spec.Inverse == nil {
+		return Transaction{}, fmt.Errorf("%s changes nothing to undo", tx.Operation)
+	}
+	op, err := spec.Inverse(tx.resourceOperation(), before)
+	if err != nil {
+		return Transaction{}, fmt.Errorf("%s cannot be compensated: %v", tx.Operation, err)
+	}
+	compensation := Transaction{Addr: tx.Addr, Name: tx.Name, Account: tx.Account, Operation: op.Operation, Amount: op.Amount, Expected: op.Expected, Currency: tx.Currency}
+	if tx.Conversion != nil && op.Amount.Cmp(tx.Amount) == 0 && !spec.FactorAmount {
+		// The same money moved back, at the rate it came in with
+		compensation.Conversion = tx.Conversion
+	}
+	return compensation, nil
+}
+
+// RPC: Client to Participant saga request. Forward request to Coordinator.
+type ClientParticipantSagaRequest struct {
+	Transactions []Transaction `json:"transactions"`
+}
+type ClientParticipantSagaResponse struct {
+	Saga SagaState `json:"saga"`
+}
+
+func (n *Node) ClientParticipantSaga(req *ClientParticipantSagaRequest, res *ClientParticipantSagaResponse) error {
+	if n.Type != "Participant" {
+		return fmt.Errorf("must be participant to send")
+	}
+	coordReq := ParticipantCoordinatorSagaRequest{
+		Transactions: req.Transactions,
+	}
+	var coordRes ParticipantCoordinatorSagaResponse
+	err := n.callCoordinator("Node.ParticipantCoordinatorSaga", &coordReq, &coordRes)
+	res.Saga = coordRes.Saga
+	if err != nil {
+		return fmt.Errorf("coordinator error: %v", err)
+	}
+	return nil
+}
+
+// RPC: Participant to Coordinator saga request
+type ParticipantCoordinatorSagaRequest struct {
+	Transactions []Transaction `json:"transactions"`
+}
+
+type ParticipantCoordinatorSagaResponse struct {
+	Saga SagaState `json:"saga"`
+}
+
+func (n *Node) ParticipantCoordinatorSaga(req *ParticipantCoordinatorSagaRequest, res *ParticipantCoordinatorSagaResponse) error {
+	if !n.isLeader() {
+		return n.notLeaderError()
+	}
+	n.c_auditMutex.RLock()
+	defer n.c_auditMutex.RUnlock()
+
+	for _, tx := range req.Transactions {
+		if err := ValidateTransaction(tx); err != nil {
+			return fmt.Errorf("saga rejected: invalid step for %s: %v", tx.Name, err)
+		}
+		if tx.Hold != uuid.Nil {
+			return fmt.Errorf("saga rejected: holds are captured with two-phase commit")
+		}
+	}
+	var names []string
+	for _, tx := range req.Transactions {
+		names = append(names, tx.Name)
+	}
+	if err := n.checkLiveness(names); err != nil {
+		return fmt.Errorf("saga rejected: %v", err)
+	}
+	transactions, err := n.convertCurrencies(req.Transactions)
+	if err != nil {
+		return fmt.Errorf("saga rejected: %v", err)
+	}
+	if err := n.checkFeatures(transactions, true); err != nil {
+		return fmt.Errorf("saga rejected: %v", err)
+	}
+	saga := &SagaState{SagaID: uuid.New(), Status: sagaRunning, Started: time.Now()}
+	for _, tx := range transactions {
+		saga.Steps = append(saga.Steps, SagaStep{Transaction: tx, Status: stepPending})
+	}
+	n.c_sagas.start(saga.SagaID)
+	defer n.c_sagas.finish(saga.SagaID)
+	n.Print(fmt.Sprintf("---Saga ID: %s---", saga.SagaID))
+	if err := n.recordSaga(saga); err != nil {
+		return fmt.Errorf("saga rejected: %v", err)
+	}
+	err = n.runSaga(saga)
+	res.Saga = copySaga(saga)
+	return err
+}
+
+// Apply the saga's pending steps in order and, once one fails, undo the
+// applied ones in reverse order. Every change is replicated before going on,
+// so a new leader can resume the saga where it was left.
+func (n *Node) runSaga(saga *SagaState) error {
+	if saga.Status == sagaCompensating {
+		return n.compensateSaga(saga)
+	}
+	failure, err := n.applySagaSteps(saga)
+	if err != nil {
+		return err
+	}
+	if failure == nil {
+		return n.setSagaStatus(saga, sagaCompleted)
+	}
+	if err := n.setSagaStatus(saga, sagaCompensating); err != nil {
+		return err
+	}
+	if err := n.compensateSaga(saga); err != nil {
+		return err
+	}
+	return failure
+}
+
+// Apply steps not applied yet, returning the failure of the first one that
+// fails, or an error if the saga's state could not be replicated
+func (n *Node) applySagaSteps(saga *SagaState) (failure error, err error) {
+	for i := range saga.Steps {
+		step := &saga.Steps[i]
+		tx := step.Transaction
+		switch step.Status {
+		case stepApplied:
+			continue
+		case stepFailed:
+			return fmt.Errorf("step %d for %s failed: %s", i+1, tx.Name, step.Error), nil
+		case stepApplying:
+			// Sent by a previous leader that never learned whether it was applied
+			step.Status = stepFailed
+			step.Error = "outcome unknown after a leader change"
+			if err := n.recordSaga(saga); err != nil {
+				return nil, err
+			}
+			return fmt.Errorf("step %d for %s failed: %s", i+1, tx.Name, step.Error), nil
+		}
+		step.Status = stepApplying
+		if err := n.recordSaga(saga); err != nil {
+			return nil, err
+		}
+		before, err := n.sendSagaStep(saga.SagaID, i, tx, false)
+		if err != nil {
+			step.Status = stepFailed
+			step.Error = err.Error()
+		} else {
+			step.Status = stepApplied
+			// Left without an operation if it cannot be undone, so compensating it fails
+			step.Compensation, _ = compensationFor(tx, &before)
+		}
+		if err := n.recordSaga(saga); err != nil {
+			return nil, err
+		}
+		if err != nil {
+			return fmt.Errorf("step %d for %s failed: %v", i+1, tx.Name, err), nil
+		}
+	}
+	return nil, nil
+}
+
+// Undo applied steps in reverse order. The failed step is compensated too in
+// case it was applied after a timeout; participants skip steps they never
+// applied, and compensations they already did.
+func (n *Node) compensateSaga(saga *SagaState) error {
+	status := sagaCompensated
+	for i := len(saga.Steps) - 1; i >= 0; i-- {
+		step := &saga.Steps[i]
+		if step.Status == stepCompensationError {
+			status = sagaStuck
+			continue
+		}
+		if step.Status != stepApplied && step.Status != stepFailed {
+			continue
+		}
+		tx := step.Transaction
+		if spec, _ := LookupOperation(tx.Operation); spec != nil && spec.Inverse == nil {
+			// Nothing to undo, and applying it late does no harm either
+			step.Status = stepCompensated
+			if err := n.recordSaga(saga); err != nil {
+				return err
+			}
+			continue
+		}
+		compensation := step.Compensation
+		if step.Status == stepFailed {
+			// The balance before a failed step is unknown. Without an operation the
+			// compensation still stops a late apply, and fails if the step was applied.
+			var err error
+			if compensation, err = compensationFor(tx, nil); err != nil {
+				compensation = Transaction{Addr: tx.Addr, Name: tx.Name, Account: tx.Account}
+			}
+		}
+		var err error
+		for attempt := 0; attempt < compensationRetries; attempt++ {
+			if _, err = n.sendSagaStep(saga.SagaID, i, compensation, true); err == nil {
+				break
+			}
+			time.Sleep(time.Second)
+		}
+		if err != nil {
+			n.Print(fmt.Sprintf(colorRed+"Compensation of step %d for %s failed: %v"+colorReset, i+1, compensation.Name, err))
+			step.Status = stepCompensationError
+			step.Error = err.Error()
+			status = sagaStuck
+		} else {
+			step.Status = stepCompensated
+		}
+		if err := n.recordSaga(saga); err != nil {
+			return err
+		}
+	}
+	return n.setSagaStatus(saga, status)
+}
+
+func (n *Node) setSagaStatus(saga *SagaState, status string) error {
+	saga.Status = status
+	n.Print(fmt.Sprintf("Saga %s %s", saga.SagaID, status))
+	return n.recordSaga(saga)
+}
+
+// Replicate the saga's state in the decision log before going on
+func (n *Node) recordSaga(saga *SagaState) error {
+	copied := copySaga(saga)
+	if err := n.replicateDecision(DecisionEntry{Type: "SAGA", TransactionID: saga.SagaID, Saga: &copied}); err != nil {
+		return fmt.Errorf("saga %s interrupted, the next leader resumes it: %v", saga.SagaID, err)
+	}
+	return nil
+}
+
+func copySaga(saga *SagaState) SagaState {
+	copied := *saga
+	copied.Steps = append([]SagaStep(nil), saga.Steps...)
+	return copied
+}
+
+// Latest state of each saga in entries, in the order they were started
+func sagaStates(entries []DecisionEntry) []SagaState {
+	index := make(map[uuid.UUID]int)
+	var sagas []SagaState
+	for _, entry := range entries {
+		if entry.Type != "SAGA" || entry.Saga == nil {
+			continue
+		}
+		if i, ok := index[entry.TransactionID]; ok {
+			sagas[i] = *entry.Saga
+			continue
+		}
+		index[entry.TransactionID] = len(sagas)
+		sagas = append(sagas, *entry.Saga)
+	}
+	return sagas
+}
+
+func sagaFinished(status string) bool {
+	return status != sagaRunning && status != sagaCompensating
+}
+
+// Resume sagas left running or compensating by a previous leader, or by this
+// one when their state could not be replicated
+func (n *Node) resumeSagas() {
+	for _, saga := range sagaStates(n.committedEntries()) {
+		if sagaFinished(saga.Status) || !n.c_sagas.start(saga.SagaID) {
+			continue
+		}
+		go func(saga SagaState) {
+			defer n.c_sagas.finish(saga.SagaID)
+			n.c_auditMutex.RLock()
+			defer n.c_auditMutex.RUnlock()
+			n.Print(fmt.Sprintf("Resuming saga %s, %s", saga.SagaID, saga.Status))
+			if err := n.runSaga(&saga); err != nil {
+				n.Print(fmt.Sprintf("Saga %s: %v", saga.SagaID, err))
+			}
+		}(saga)
+	}
+}
+
+// Start driving sagaID unless this leader already is
+func (s *sagaSet) start(sagaID uuid.UUID) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.active[sagaID] {
+		return false
+	}
+	s.active[sagaID] = true
+	return true
+}
+
+func (s *sagaSet) finish(sagaID uuid.UUID) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.active, sagaID)
+}
+
+// Send one saga step (or its compensation) to the participant named in tx,
+// returning the balance before it was applied
+func (n *Node) sendSagaStep(sagaID uuid.UUID, step int, tx Transaction, compensation bool) (Decimal, error) {
+	n.Print(fmt.Sprintf("Request: ApplySagaStep %d (%s %s) to %s", step+1, tx.Operation, tx.Amount, tx.Name))
+	req := ApplySagaStepRequest{
+		SagaID:       sagaID,
+		Step:         step,
+		Compensation: compensation,
+		Account:      tx.Account,
+		Operation:    tx.Operation,
+		Amount:       tx.Amount,
+		Expected:     tx.Expected,
+		Currency:     tx.Currency,
+		Conversion:   tx.Conversion,
+	}
+	var res ApplySagaStepResponse
+
+	// Descendants below sub-coordinators are reached directly
+	if err := n.callTimeout(tx.Addr, "Node.ApplySagaStep", &req, &res, prepareTimeout); err != nil {
+		return Decimal{}, err
+	}
+	return res.Before, nil
+}
+
+// RPC: Apply a saga step as an immediate local commit
+type ApplySagaStepRequest struct {
+	SagaID       uuid.UUID     `json:"sagaId"`
+	Step         int           `json:"step"`
+	Compensation bool          `json:"compensation"`
+	Account      string        `json:"account"`
+	Operation    OperationType `json:"operation"` // Empty to only cancel a step whose undo is unknown
+	Amount       Decimal       `json:"amount"`
+	Expected     Decimal       `json:"expected"`
+	Currency     string        `json:"currency"`
+	Conversion   *Conversion   `json:"conversion"`
+}
+
+type ApplySagaStepResponse struct {
+	Before     Decimal `json:"before"`
+	NewBalance Decimal `json:"newBalance"`
+}
+
+func (n *Node) ApplySagaStep(req *ApplySagaStepRequest, res *ApplySagaStepResponse) error {
+	if n.Type != "Participant" {
+		return fmt.Errorf("this node is not a participant")
+	}
+	if n.rejectIncoming {
+		return fmt.Errorf("rejected, simulating crash")
+	}
+	n.lockForDecision()
+	defer n.commitMutex.Unlock()
+
+	applyPhase := fmt.Sprintf("SAGA-APPLY-%d", req.Step+1)
+	compensatePhase := fmt.Sprintf("SAGA-COMPENSATE-%d", req.Step+1)
+	phase := applyPhase
+	if req.Compensation {
+		phase = compensatePhase
+	}
+	// Retried compensations must not be applied twice
+	if n.hasLogEntry(req.SagaID, phase) {
+		return nil
+	}
+	if !req.Compensation && n.hasLogEntry(req.SagaID, compensatePhase) {
+		return fmt.Errorf("saga step already compensated")
+	}
+	if req.Compensation && !n.hasLogEntry(req.SagaID, applyPhase) {
+		// Never applied, record it so a late apply is refused
+		n.LogTransaction(phase, req.SagaID)
+		return nil
+	}
+	if req.Compensation && req.Operation == "" {
+		return fmt.Errorf("saga step %d was applied and cannot be undone automatically", req.Step+1)
+	}
+	before, err := n.resource.Read(req.Account)
+	if err != nil {
+		return err
+	}
+	op := ResourceOperation{Key: req.Account, Operation: req.Operation, Amount: req.Amount, Expected: req.Expected, Currency: req.Currency, Conversion: req.Conversion}
+	if err := n.resource.Prepare(req.SagaID, op); err != nil {
+		return err
+	}
+	if err := n.resource.Commit(req.SagaID); err != nil {
+		return err
+	}
+	newBalance, err := n.resource.Read(req.Account)
+	if err != nil {
+		return err
+	}
+	n.LogTransaction(phase, req.SagaID)
+	res.Before = before
+	n.Print(fmt.Sprintf(colorGreen+"Saga step applied (%s %s), new balance %s"+colorReset, req.Operation, req.Amount, newBalance))
+	res.NewBalance = newBalance
+	return nil
+}
+
+// RPC: Query saga progress, all sagas when SagaID is nil
+type GetSagaStatusRequest struct {
+	SagaID uuid.UUID `json:"sagaId"`
+}
+
+type GetSagaStatusResponse struct {
+	Sagas []SagaState `json:"sagas"`
+}
+
+func (n *Node) GetSagaStatus(req *GetSagaStatusRequest, res *GetSagaStatusResponse) error {
+	if n.Type != "Coordinator" {
+		return n.callCoordinator("Node.GetSagaStatus", req, res)
+	}
+	// Followers may not have every step yet
+	if !n.isLeader() {
+		return n.notLeaderError()
+	}
+	for _, saga := range sagaStates(n.logEntries()) {
+		if req.SagaID != uuid.Nil && req.SagaID != saga.SagaID {
+			continue
+		}
+		res.Sagas = append(res.Sagas, saga)
+	}
+	if req.SagaID != uuid.Nil && len(res.Sagas) == 0 {
+		// Also once finished and compacted away
+		return fmt.Errorf("saga %s not found", req.SagaID)
+	}
+	return nil
+}
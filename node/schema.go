@@ -0,0 +1,135 @@
+package node
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+//go:generate go run .. schema -o ../docs/jsonrpc-schema.json
+
+// Version of the JSON-RPC schema. Adding methods or fields keeps it, renaming
+// or removing them, or changing their types, takes a new one.
+const JSONRPCSchemaVersion = "1"
+
+// The RPC methods every node serves with the JSON names of their parameters
+// and results
+type Schema struct {
+	Version  string         `json:"version"`
+	Protocol string         `json:"protocol"`
+	Formats  []SchemaField  `json:"formats"` // Encoding of the non-JSON types used
+	Methods  []SchemaMethod `json:"methods"`
+	Types    []SchemaType   `json:"types"`
+}
+
+type SchemaMethod struct {
+	Name   string `json:"name"`
+	Params string `json:"params"` // Type of the single parameter
+	Result string `json:"result"`
+}
+
+type SchemaType struct {
+	Name   string        `json:"name"`
+	Fields []SchemaField `json:"fields"`
+}
+
+type SchemaField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+var (
+	decimalType = reflect.TypeOf(Decimal{})
+	uuidType    = reflect.TypeOf(uuid.UUID{})
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+var schemaFormats = []SchemaField{
+	{Name: "decimal", Type: `string with the amount's decimal places, e.g. "10.50"`},
+	{Name: "uuid", Type: `string, e.g. "3f0c1b6e-5d2a-4c8e-9b7a-2e4f6a8c0d1e", all zeros for none`},
+	{Name: "timestamp", Type: `RFC 3339 string, "0001-01-01T00:00:00Z" for none`},
+	{Name: "duration", Type: "integer nanoseconds"},
+}
+
+// Schema of the methods net/rpc serves for Node, which are its exported
+// methods taking a request and a response pointer and returning an error
+func JSONRPCSchema() Schema {
+	schema := Schema{
+		Version:  JSONRPCSchemaVersion,
+		Protocol: "JSON-RPC 1.0, net/rpc/jsonrpc",
+		Formats:  schemaFormats,
+	}
+	types := make(map[string]SchemaType)
+	nodeType := reflect.TypeOf(&Node{})
+	for i := 0; i < nodeType.NumMethod(); i++ {
+		method := nodeType.Method(i)
+		if method.Type.NumIn() != 3 || method.Type.NumOut() != 1 || method.Type.Out(0) != errorType ||
+			method.Type.In(1).Kind() != reflect.Pointer || method.Type.In(2).Kind() != reflect.Pointer {
+			continue
+		}
+		schema.Methods = append(schema.Methods, SchemaMethod{
+			Name:   "Node." + method.Name,
+			Params: schemaTypeName(method.Type.In(1).Elem(), types),
+			Result: schemaTypeName(method.Type.In(2).Elem(), types),
+		})
+	}
+	for _, t := range types {
+		schema.Types = append(schema.Types, t)
+	}
+	sort.Slice(schema.Types, func(i, j int) bool {
+		return schema.Types[i].Name < schema.Types[j].Name
+	})
+	return schema
+}
+
+// Name of t in the schema, adding the structs it uses to types
+func schemaTypeName(t reflect.Type, types map[string]SchemaType) string {
+	switch {
+	case t == decimalType:
+		return "decimal"
+	case t == uuidType:
+		return "uuid"
+	case t == timeType:
+		return "timestamp"
+	case t == durationType:
+		return "duration"
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaTypeName(t.Elem(), types) + " or null"
+	case reflect.Slice:
+		return "[]" + schemaTypeName(t.Elem(), types)
+	case reflect.Map:
+		return "map[" + schemaTypeName(t.Key(), types) + "]" + schemaTypeName(t.Elem(), types)
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Struct:
+		if _, ok := types[t.Name()]; ok {
+			return t.Name()
+		}
+		schemaType := SchemaType{Name: t.Name(), Fields: []SchemaField{}}
+		types[t.Name()] = schemaType // Before the fields, which may refer back to t
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !field.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			schemaType.Fields = append(schemaType.Fields, SchemaField{Name: name, Type: schemaTypeName(field.Type, types)})
+		}
+		types[t.Name()] = schemaType
+		return t.Name()
+	}
+	return t.Kind().String()
+}
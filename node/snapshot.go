@@ -0,0 +1,167 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// The decision log would grow with every transaction, so once enough entries
+// are committed the start of it is compacted into a snapshot. The snapshot
+// keeps only the committed entries still of interest, those of transactions
+// that are undecided or whose decision some participant has yet to
+// acknowledge, of sagas not completed or compensated, every heuristic
+// outcome reported and the latest exchange rates. Followers too far behind to
+// be sent the entries they lack are sent the snapshot instead.
+
+// Committed entries after the snapshot before the log is compacted
+const logCompactionThreshold = 100
+
+// Compacted start of the decision log, up to and including Index
+type DecisionSnapshot struct {
+	Index   int             `json:"index"` // Of the last entry compacted
+	Term    int             `json:"term"`  // Of the last entry compacted
+	Entries []DecisionEntry `json:"entries"`
+}
+
+// Compact committed entries into the snapshot once there are enough of them.
+// Must hold c_raftMutex.
+func (n *Node) compactLog() {
+	compacted := n.c_commitIndex - n.c_snapshot.Index
+	if compacted < logCompactionThreshold {
+		return
+	}
+	entries := append(append([]DecisionEntry(nil), n.c_snapshot.Entries...), n.c_log[:compacted]...)
+	n.c_snapshot = DecisionSnapshot{
+		Index:   n.c_commitIndex,
+		Term:    n.termAt(n.c_commitIndex),
+		Entries: liveEntries(entries),
+	}
+	n.c_log = append([]DecisionEntry(nil), n.c_log[compacted:]...)
+	n.persistRaftState()
+	n.Print(fmt.Sprintf("Compacted the decision log up to %d, keeping %d entries", n.c_snapshot.Index, len(n.c_snapshot.Entries)))
+}
+
+// Entries still of interest among committed ones: those of transactions not
+// yet finished, the last acknowledgement of each being enough, the state of
+// sagas not finished or stuck, and the latest rates
+func liveEntries(entries []DecisionEntry) []DecisionEntry {
+	outcomes := make(map[uuid.UUID]*transactionOutcome)
+	for _, outcome := range transactionOutcomes(entries) {
+		outcomes[outcome.TransactionID] = outcome
+	}
+	// The last acknowledgement or state of each transaction or saga, and the
+	// last rates under the nil ID
+	last := make(map[uuid.UUID]int)
+	for i, entry := range entries {
+		if entry.Type == "ACK" || entry.Type == "SAGA" || entry.Type == "RATES" {
+			last[entry.TransactionID] = i
+		}
+	}
+	var live []DecisionEntry
+	for i, entry := range entries {
+		id := entry.TransactionID
+		switch entry.Type {
+		case "NOOP":
+			continue
+		case "PREPARE", "COMMIT", "ABORT", "ACK":
+			if outcome, ok := outcomes[id]; !ok || outcome.finished() {
+				continue
+			}
+			if entry.Type == "ACK" && last[id] != i {
+				continue
+			}
+		case "SAGA":
+			if last[id] != i || sagaFinished(entry.Saga.Status) && entry.Saga.Status != sagaStuck {
+				continue
+			}
+		case "RATES":
+			if last[id] != i {
+				continue
+			}
+		}
+		live = append(live, entry)
+	}
+	return live
+}
+
+// Send the snapshot to a follower lacking entries compacted into it
+func (n *Node) sendSnapshot(peer string, term int) {
+	n.c_raftMutex.Lock()
+	if n.c_role != roleLeader || n.c_term != term {
+		n.c_raftMutex.Unlock()
+		return
+	}
+	req := InstallSnapshotRequest{Term: term, LeaderAddr: n.Addr, Snapshot: n.c_snapshot}
+	n.c_raftMutex.Unlock()
+
+	var res InstallSnapshotResponse
+	if err := n.callPeer(peer, "Node.InstallSnapshot", &req, &res); err != nil {
+		return
+	}
+
+	n.c_raftMutex.Lock()
+	defer n.c_raftMutex.Unlock()
+	if res.Term > n.c_term {
+		n.stepDown(res.Term)
+		return
+	}
+	if n.c_role != roleLeader || n.c_term != term || !res.Success {
+		return
+	}
+	if req.Snapshot.Index > n.c_matchIndex[peer] {
+		n.c_matchIndex[peer] = req.Snapshot.Index
+		n.c_nextIndex[peer] = req.Snapshot.Index + 1
+	}
+}
+
+// RPC: Leader replacing the start of a follower's decision log with its snapshot
+type InstallSnapshotRequest struct {
+	Term       int              `json:"term"`
+	LeaderAddr string           `json:"leaderAddr"`
+	Snapshot   DecisionSnapshot `json:"snapshot"`
+	callerIdentity
+}
+
+type InstallSnapshotResponse struct {
+	Term    int  `json:"term"`
+	Success bool `json:"success"`
+}
+
+func (n *Node) InstallSnapshot(req *InstallSnapshotRequest, res *InstallSnapshotResponse) error {
+	if n.Type != "Coordinator" {
+		return fmt.Errorf("this node is not a coordinator")
+	}
+	if err := n.checkPeerCaller(&req.callerIdentity, req.LeaderAddr); err != nil {
+		return err
+	}
+	n.c_raftMutex.Lock()
+	defer n.c_raftMutex.Unlock()
+	res.Term = n.c_term
+	if req.Term < n.c_term {
+		return nil
+	}
+	if req.Term > n.c_term || n.c_role != roleFollower {
+		n.stepDown(req.Term)
+		res.Term = n.c_term
+	}
+	n.c_leaderAddr = req.LeaderAddr
+	n.resetElectionTimer()
+	res.Success = true
+
+	snapshot := req.Snapshot
+	if snapshot.Index <= n.c_snapshot.Index {
+		return nil
+	}
+	// Entries after the snapshot are kept if the log agrees with it
+	if snapshot.Index <= n.logLength() && n.termAt(snapshot.Index) == snapshot.Term {
+		n.c_log = append([]DecisionEntry(nil), n.c_log[snapshot.Index-n.c_snapshot.Index:]...)
+	} else {
+		n.c_log = nil
+	}
+	n.c_snapshot = snapshot
+	n.c_commitIndex = max(n.c_commitIndex, snapshot.Index)
+	n.persistRaftState()
+	n.Print(fmt.Sprintf("Installed snapshot up to %d from leader %s", snapshot.Index, req.LeaderAddr))
+	return nil
+}
@@ -0,0 +1,216 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Storage holds a participant's durable state as named values. Put must be
+// atomic: after a crash a key holds either its old or its new value.
+type Storage interface {
+	Get(key string) ([]byte, bool, error)
+	Put(key string, value []byte) error
+	// Add a newline terminated record to the end of key. A crash may leave the
+	// last record cut short, readers skip it.
+	Append(key string, record []byte) error
+	Keys() ([]string, error)
+}
+
+const (
+	StorageFile   = "file"
+	StorageMemory = "memory"
+)
+
+// Storage backend by name, dir being where the file backend keeps its data
+func OpenStorage(backend string, dir string) (Storage, error) {
+	switch backend {
+	case StorageFile, "":
+		return NewFileStorage(dir)
+	case StorageMemory:
+		return NewMemoryStorage(), nil
+	}
+	return nil, fmt.Errorf("unknown storage backend %q", backend)
+}
+
+// MemoryStorage keeps values in memory only, for tests and throwaway nodes
+type MemoryStorage struct {
+	mutex  sync.Mutex
+	values map[string][]byte
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{values: make(map[string][]byte)}
+}
+
+func (s *MemoryStorage) Get(key string) ([]byte, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	value, ok := s.values[key]
+	return append([]byte(nil), value...), ok, nil
+}
+
+func (s *MemoryStorage) Put(key string, value []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.values[key] = append([]byte(nil), value...)
+	return nil
+}
+
+func (s *MemoryStorage) Append(key string, record []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.values[key] = append(s.values[key], appendRecord(s.values[key], record)...)
+	return nil
+}
+
+func (s *MemoryStorage) Keys() ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return sortedKeys(s.values), nil
+}
+
+// FileStorage keeps one file per key in a directory. Values are written to a
+// temporary file, synced and renamed over the old one, and cached so reads
+// never go back to disk.
+type FileStorage struct {
+	mutex sync.Mutex
+	dir   string
+	cache map[string][]byte
+}
+
+const tempFileSuffix = ".tmp"
+
+func NewFileStorage(dir string) (*FileStorage, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating data directory: %v", err)
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading data directory: %v", err)
+	}
+	s := &FileStorage{dir: dir, cache: make(map[string][]byte)}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		// Left over from a write interrupted by a crash, the old value is still in place
+		if strings.HasSuffix(file.Name(), tempFileSuffix) {
+			os.Remove(path)
+			continue
+		}
+		value, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %v", path, err)
+		}
+		s.cache[file.Name()] = value
+	}
+	return s, nil
+}
+
+func (s *FileStorage) Get(key string) ([]byte, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	value, ok := s.cache[key]
+	return append([]byte(nil), value...), ok, nil
+}
+
+func (s *FileStorage) Put(key string, value []byte) error {
+	if err := validateStorageKey(key); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	path := filepath.Join(s.dir, key)
+	tempPath := path + tempFileSuffix
+	file, err := os.Create(tempPath)
+	if err != nil {
+		return fmt.Errorf("error creating data file: %v", err)
+	}
+	if _, err := file.Write(value); err != nil {
+		file.Close()
+		return fmt.Errorf("error writing data file: %v", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("error syncing data file: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error closing data file: %v", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("error replacing data file: %v", err)
+	}
+	// Make the rename itself durable
+	if dir, err := os.Open(s.dir); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+
+	s.cache[key] = append([]byte(nil), value...)
+	return nil
+}
+
+func (s *FileStorage) Append(key string, record []byte) error {
+	if err := validateStorageKey(key); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data := appendRecord(s.cache[key], record)
+	file, err := os.OpenFile(filepath.Join(s.dir, key), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening data file: %v", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("error writing data file: %v", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("error syncing data file: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error closing data file: %v", err)
+	}
+	s.cache[key] = append(s.cache[key], data...)
+	return nil
+}
+
+func (s *FileStorage) Keys() ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return sortedKeys(s.cache), nil
+}
+
+func validateStorageKey(key string) error {
+	if key == "" || strings.ContainsAny(key, `/\`) || strings.HasSuffix(key, tempFileSuffix) {
+		return fmt.Errorf("invalid storage key %q", key)
+	}
+	return nil
+}
+
+// Bytes to append so record ends up on a line of its own after existing
+func appendRecord(existing []byte, record []byte) []byte {
+	var data []byte
+	if len(existing) > 0 && existing[len(existing)-1] != '\n' {
+		data = append(data, '\n')
+	}
+	data = append(data, record...)
+	return append(data, '\n')
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
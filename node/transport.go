@@ -0,0 +1,255 @@
+package node
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/rpc"
+	"sync"
+	"time"
+)
+
+// Nodes talk to each other through a Transport, so the protocol code does not
+// depend on how calls travel. Every node serves both net/rpc and gRPC on its
+// address, the transport a node dials with is chosen by UseTransport. Both run
+// over mutual TLS when given credentials.
+
+const (
+	TransportRPC  = "rpc"
+	TransportGRPC = "grpc"
+)
+
+type Transport interface {
+	Name() string
+	Dial(addr string) (Client, error)
+	// Dial a node that must prove it is name, when using TLS
+	DialNode(addr string, name string) (Client, error)
+}
+
+// Client is a connection to one node. Methods are named as net/rpc names
+// them, e.g. "Node.ReceivePrepare", whatever the transport.
+type Client interface {
+	Call(method string, req any, res any) error
+	// Call giving up after timeout. res may still be written after that.
+	CallTimeout(method string, req any, res any, timeout time.Duration) error
+	Close() error
+}
+
+// ServerError is an error returned by the remote method, as opposed to the
+// call failing to get there or back
+type ServerError string
+
+func (e ServerError) Error() string {
+	return string(e)
+}
+
+// Returned by calls on a connection that is known to be broken, so the
+// request was not sent
+var ErrClientClosed = errors.New("connection is shut down")
+
+// Returned by calls whose connection broke while they were under way, so the
+// request may or may not have been processed
+var ErrConnectionLost = errors.New("connection lost")
+
+// Transport by name, over TLS with creds unless nil
+func NewTransport(name string, creds *Credentials) (Transport, error) {
+	switch name {
+	case TransportRPC:
+		return rpcTransport{creds}, nil
+	case TransportGRPC:
+		return grpcTransport{creds}, nil
+	}
+	return nil, fmt.Errorf("unknown transport %q", name)
+}
+
+// Connect to addr with the named transport
+func Dial(transport string, creds *Credentials, addr string) (Client, error) {
+	t, err := NewTransport(transport, creds)
+	if err != nil {
+		return nil, err
+	}
+	return t.Dial(addr)
+}
+
+// Dial other nodes with t from now on. Call before Start.
+func (n *Node) UseTransport(t Transport) {
+	n.transport = t
+}
+
+// net/rpc with gob over TCP
+
+type rpcTransport struct {
+	credentials *Credentials
+}
+
+func (rpcTransport) Name() string {
+	return TransportRPC
+}
+
+func (t rpcTransport) Dial(addr string) (Client, error) {
+	return t.DialNode(addr, "")
+}
+
+func (t rpcTransport) DialNode(addr string, name string) (Client, error) {
+	if t.credentials == nil {
+		client, err := rpc.Dial("tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		return rpcClient{client}, nil
+	}
+	conn, err := tls.Dial("tcp", addr, t.credentials.clientConfig(name))
+	if err != nil {
+		return nil, err
+	}
+	return rpcClient{rpc.NewClient(conn)}, nil
+}
+
+type rpcClient struct {
+	client *rpc.Client
+}
+
+func (c rpcClient) Call(method string, req any, res any) error {
+	return rpcError(c.client.Call(method, req, res))
+}
+
+func (c rpcClient) CallTimeout(method string, req any, res any, timeout time.Duration) error {
+	call := c.client.Go(method, req, res, make(chan *rpc.Call, 1))
+	select {
+	case <-call.Done:
+		return rpcError(call.Error)
+	case <-time.After(timeout):
+		return fmt.Errorf("call to %s timed out", method)
+	}
+}
+
+func (c rpcClient) Close() error {
+	return c.client.Close()
+}
+
+func rpcError(err error) error {
+	var serverError rpc.ServerError
+	switch {
+	case errors.As(err, &serverError):
+		return ServerError(serverError)
+	case errors.Is(err, rpc.ErrShutdown):
+		return ErrClientClosed
+	}
+	return err
+}
+
+// Serving both transports on one listener
+
+// Every gRPC connection opens with the HTTP/2 client preface, a net/rpc one
+// with a gob message that never does
+var http2Preface = []byte("PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n")
+
+// Serve net/rpc and gRPC on listener until it is closed
+func (n *Node) serve(listener net.Listener) error {
+	if n.credentials != nil {
+		listener = tls.NewListener(listener, n.credentials.serverConfig())
+	}
+	rpcServer := rpc.NewServer()
+	if err := rpcServer.Register(n); err != nil {
+		return fmt.Errorf("error registering RPC server: %v", err)
+	}
+	grpcListener := newConnListener(listener.Addr())
+	defer grpcListener.Close()
+	go n.serveGRPC(grpcListener)
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go func() {
+			caller, known, err := peerName(conn)
+			if err != nil {
+				conn.Close()
+				return
+			}
+			conn, isGRPC, err := sniffPreface(conn)
+			if err != nil {
+				conn.Close()
+				return
+			}
+			switch {
+			case isGRPC && known:
+				grpcListener.push(callerConn{Conn: conn, caller: caller})
+			case isGRPC:
+				grpcListener.push(conn)
+			case known:
+				rpcServer.ServeCodec(callerCodec{ServerCodec: newGobServerCodec(conn), caller: caller})
+			default:
+				rpcServer.ServeConn(conn)
+			}
+		}()
+	}
+}
+
+// Look at the start of a connection without consuming it
+func sniffPreface(conn net.Conn) (net.Conn, bool, error) {
+	reader := bufio.NewReaderSize(conn, len(http2Preface))
+	peeked := &peekedConn{Conn: conn, reader: reader}
+	// Compare as bytes arrive, a short net/rpc message must not be waited on
+	for i := 1; i <= len(http2Preface); i++ {
+		start, err := reader.Peek(i)
+		if err != nil {
+			return peeked, false, err
+		}
+		if !bytes.Equal(start, http2Preface[:i]) {
+			return peeked, false, nil
+		}
+	}
+	return peeked, true, nil
+}
+
+type peekedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *peekedConn) Read(p []byte) (int, error) {
+	return c.reader.Read(p)
+}
+
+// Listener handing over connections accepted elsewhere
+type connListener struct {
+	addr   net.Addr
+	conns  chan net.Conn
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newConnListener(addr net.Addr) *connListener {
+	return &connListener{addr: addr, conns: make(chan net.Conn), closed: make(chan struct{})}
+}
+
+func (l *connListener) push(conn net.Conn) {
+	select {
+	case l.conns <- conn:
+	case <-l.closed:
+		conn.Close()
+	}
+}
+
+func (l *connListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *connListener) Close() error {
+	l.once.Do(func() { close(l.closed) })
+	return nil
+}
+
+func (l *connListener) Addr() net.Addr {
+	return l.addr
+}
@@ -0,0 +1,220 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"twophasecommit/pb"
+)
+
+// gRPC with the services of pb/twophasecommit.proto. Each rpc there is served
+// by the Node method of the same name, requests and responses being converted
+// to and from the Go types. The services are registered from their descriptors
+// rather than generated stubs, so net/rpc and gRPC share the Node methods.
+
+const grpcDialTimeout = 5 * time.Second
+
+// Proto method of each Node method, by name
+var grpcMethods = func() map[string]protoreflect.MethodDescriptor {
+	methods := make(map[string]protoreflect.MethodDescriptor)
+	services := pb.File_twophasecommit_proto.Services()
+	for i := 0; i < services.Len(); i++ {
+		for j := 0; j < services.Get(i).Methods().Len(); j++ {
+			method := services.Get(i).Methods().Get(j)
+			methods[string(method.Name())] = method
+		}
+	}
+	return methods
+}()
+
+func grpcMethodPath(method protoreflect.MethodDescriptor) string {
+	return fmt.Sprintf("/%s/%s", method.Parent().FullName(), method.Name())
+}
+
+func newProtoMessage(desc protoreflect.MessageDescriptor) (proto.Message, error) {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(desc.FullName())
+	if err != nil {
+		return nil, err
+	}
+	return messageType.New().Interface(), nil
+}
+
+type grpcTransport struct {
+	credentials *Credentials
+}
+
+func (grpcTransport) Name() string {
+	return TransportGRPC
+}
+
+func (t grpcTransport) Dial(addr string) (Client, error) {
+	return t.DialNode(addr, "")
+}
+
+// Connect right away like net/rpc does, so an unreachable node fails here
+// rather than on the first call
+func (t grpcTransport) DialNode(addr string, name string) (Client, error) {
+	transportCredentials := insecure.NewCredentials()
+	if t.credentials != nil {
+		transportCredentials = credentials.NewTLS(t.credentials.clientConfig(name))
+	}
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(transportCredentials))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), grpcDialTimeout)
+	defer cancel()
+	conn.Connect()
+	for state := conn.GetState(); state != connectivity.Ready; state = conn.GetState() {
+		if state == connectivity.TransientFailure || !conn.WaitForStateChange(ctx, state) {
+			conn.Close()
+			return nil, fmt.Errorf("dial %s: connection failed", addr)
+		}
+	}
+	return grpcClient{conn}, nil
+}
+
+type grpcClient struct {
+	conn *grpc.ClientConn
+}
+
+func (c grpcClient) Call(method string, req any, res any) error {
+	return c.invoke(context.Background(), method, req, res)
+}
+
+func (c grpcClient) CallTimeout(method string, req any, res any, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return c.invoke(ctx, method, req, res)
+}
+
+func (c grpcClient) invoke(ctx context.Context, method string, req any, res any) error {
+	desc, ok := grpcMethods[strings.TrimPrefix(method, "Node.")]
+	if !ok {
+		return fmt.Errorf("no gRPC method for %s", method)
+	}
+	in, err := newProtoMessage(desc.Input())
+	if err != nil {
+		return err
+	}
+	out, err := newProtoMessage(desc.Output())
+	if err != nil {
+		return err
+	}
+	if err := toProto(reflect.ValueOf(req), in.ProtoReflect()); err != nil {
+		return fmt.Errorf("encoding %s: %v", method, err)
+	}
+	// Unavailable does not tell whether the request got out, so only a
+	// connection found broken beforehand counts as not sent
+	if state := c.conn.GetState(); state != connectivity.Ready {
+		return fmt.Errorf("%w: %s", ErrClientClosed, state)
+	}
+	if err := c.conn.Invoke(ctx, grpcMethodPath(desc), in, out); err != nil {
+		return grpcError(method, err)
+	}
+	if err := fromProto(out.ProtoReflect(), reflect.ValueOf(res)); err != nil {
+		return fmt.Errorf("decoding %s: %v", method, err)
+	}
+	return nil
+}
+
+func (c grpcClient) Close() error {
+	return c.conn.Close()
+}
+
+func grpcError(method string, err error) error {
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	switch s.Code() {
+	case codes.Unknown:
+		return ServerError(s.Message())
+	case codes.Unavailable:
+		return fmt.Errorf("%w: %s", ErrConnectionLost, s.Message())
+	case codes.DeadlineExceeded:
+		return fmt.Errorf("call to %s timed out", method)
+	}
+	return err
+}
+
+// Serve the proto services with n's methods until listener is closed
+func (n *Node) serveGRPC(listener net.Listener) {
+	server := grpc.NewServer(grpc.Creds(callerCredentials{}))
+	services := pb.File_twophasecommit_proto.Services()
+	for i := 0; i < services.Len(); i++ {
+		service := services.Get(i)
+		desc := grpc.ServiceDesc{
+			ServiceName: string(service.FullName()),
+			HandlerType: (*any)(nil),
+			Metadata:    pb.File_twophasecommit_proto.Path(),
+		}
+		for j := 0; j < service.Methods().Len(); j++ {
+			method := service.Methods().Get(j)
+			desc.Methods = append(desc.Methods, grpc.MethodDesc{
+				MethodName: string(method.Name()),
+				Handler:    n.grpcHandler(method),
+			})
+		}
+		server.RegisterService(&desc, n)
+	}
+	if err := server.Serve(listener); err != nil && !errors.Is(err, net.ErrClosed) {
+		n.Print(fmt.Sprintf("Error serving gRPC: %v", err))
+	}
+}
+
+func (n *Node) grpcHandler(desc protoreflect.MethodDescriptor) func(any, context.Context, func(any) error, grpc.UnaryServerInterceptor) (any, error) {
+	method := reflect.ValueOf(n).MethodByName(string(desc.Name()))
+	return func(_ any, ctx context.Context, decode func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		if !method.IsValid() {
+			return nil, status.Errorf(codes.Unimplemented, "%s is not served", desc.Name())
+		}
+		in, err := newProtoMessage(desc.Input())
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if err := decode(in); err != nil {
+			return nil, err
+		}
+		req := reflect.New(method.Type().In(0).Elem())
+		res := reflect.New(method.Type().In(1).Elem())
+		if err := fromProto(in.ProtoReflect(), req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			if info, ok := p.AuthInfo.(callerInfo); ok {
+				if setter, ok := req.Interface().(callerSetter); ok {
+					setter.setCaller(info.caller)
+				}
+			}
+		}
+		results := method.Call([]reflect.Value{req, res})
+		if err, _ := results[0].Interface().(error); err != nil {
+			return nil, status.Error(codes.Unknown, err.Error())
+		}
+		out, err := newProtoMessage(desc.Output())
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if err := toProto(res, out.ProtoReflect()); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		return out, nil
+	}
+}
@@ -0,0 +1,9 @@
+// Package pb holds the generated protobuf messages of the gRPC transport.
+//
+// Only the messages are generated. No gRPC stubs are: the transport in package
+// node serves and calls the services of twophasecommit.proto from their
+// descriptors, each rpc through the Node method of the same name, so the same
+// methods serve both transports.
+package pb
+
+//go:generate go -C protogen run . .. twophasecommit.proto
@@ -0,0 +1,7194 @@
+// gRPC definitions of the node protocol. Every node serves all three services;
+// the message and field names follow the Go request and response types in
+// package node, which the gRPC transport converts to and from by name.
+//
+// Amounts are exact decimals and IDs are UUIDs, both sent as strings. An unset
+// string is a zero amount or nil ID.
+//
+// No stubs are generated for the services: the transport serves and calls them
+// from their descriptors. Regenerate the messages with go generate, which runs
+// the generator pinned in protogen.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: twophasecommit.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Transaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Addr          string                 `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Account       string                 `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`
+	Operation     string                 `protobuf:"bytes,4,opt,name=operation,proto3" json:"operation,omitempty"`
+	Amount        string                 `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Expected      string                 `protobuf:"bytes,6,opt,name=expected,proto3" json:"expected,omitempty"`
+	Currency      string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	Conversion    *Conversion            `protobuf:"bytes,8,opt,name=conversion,proto3" json:"conversion,omitempty"`
+	Hold          string                 `protobuf:"bytes,9,opt,name=hold,proto3" json:"hold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_twophasecommit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Transaction) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Transaction) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Transaction) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *Transaction) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *Transaction) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Transaction) GetExpected() string {
+	if x != nil {
+		return x.Expected
+	}
+	return ""
+}
+
+func (x *Transaction) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Transaction) GetConversion() *Conversion {
+	if x != nil {
+		return x.Conversion
+	}
+	return nil
+}
+
+func (x *Transaction) GetHold() string {
+	if x != nil {
+		return x.Hold
+	}
+	return ""
+}
+
+type Conversion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Rate          string                 `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	Original      string                 `protobuf:"bytes,4,opt,name=original,proto3" json:"original,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Conversion) Reset() {
+	*x = Conversion{}
+	mi := &file_twophasecommit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Conversion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Conversion) ProtoMessage() {}
+
+func (x *Conversion) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Conversion.ProtoReflect.Descriptor instead.
+func (*Conversion) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Conversion) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Conversion) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *Conversion) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *Conversion) GetOriginal() string {
+	if x != nil {
+		return x.Original
+	}
+	return ""
+}
+
+type ExchangeRate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Rate          string                 `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExchangeRate) Reset() {
+	*x = ExchangeRate{}
+	mi := &file_twophasecommit_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeRate) ProtoMessage() {}
+
+func (x *ExchangeRate) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeRate.ProtoReflect.Descriptor instead.
+func (*ExchangeRate) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExchangeRate) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+type ResourceOperation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Operation     string                 `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	Amount        string                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Expected      string                 `protobuf:"bytes,4,opt,name=expected,proto3" json:"expected,omitempty"`
+	Counterparts  []string               `protobuf:"bytes,5,rep,name=counterparts,proto3" json:"counterparts,omitempty"`
+	External      bool                   `protobuf:"varint,6,opt,name=external,proto3" json:"external,omitempty"`
+	Currency      string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	Conversion    *Conversion            `protobuf:"bytes,8,opt,name=conversion,proto3" json:"conversion,omitempty"`
+	Hold          string                 `protobuf:"bytes,9,opt,name=hold,proto3" json:"hold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceOperation) Reset() {
+	*x = ResourceOperation{}
+	mi := &file_twophasecommit_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceOperation) ProtoMessage() {}
+
+func (x *ResourceOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceOperation.ProtoReflect.Descriptor instead.
+func (*ResourceOperation) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ResourceOperation) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ResourceOperation) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *ResourceOperation) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *ResourceOperation) GetExpected() string {
+	if x != nil {
+		return x.Expected
+	}
+	return ""
+}
+
+func (x *ResourceOperation) GetCounterparts() []string {
+	if x != nil {
+		return x.Counterparts
+	}
+	return nil
+}
+
+func (x *ResourceOperation) GetExternal() bool {
+	if x != nil {
+		return x.External
+	}
+	return false
+}
+
+func (x *ResourceOperation) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ResourceOperation) GetConversion() *Conversion {
+	if x != nil {
+		return x.Conversion
+	}
+	return nil
+}
+
+func (x *ResourceOperation) GetHold() string {
+	if x != nil {
+		return x.Hold
+	}
+	return ""
+}
+
+type LedgerEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryId       string                 `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	TransactionId string                 `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Account       string                 `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`
+	Operation     string                 `protobuf:"bytes,4,opt,name=operation,proto3" json:"operation,omitempty"`
+	Amount        string                 `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Before        string                 `protobuf:"bytes,6,opt,name=before,proto3" json:"before,omitempty"`
+	After         string                 `protobuf:"bytes,7,opt,name=after,proto3" json:"after,omitempty"`
+	Counterparts  []string               `protobuf:"bytes,8,rep,name=counterparts,proto3" json:"counterparts,omitempty"`
+	External      bool                   `protobuf:"varint,9,opt,name=external,proto3" json:"external,omitempty"`
+	Currency      string                 `protobuf:"bytes,10,opt,name=currency,proto3" json:"currency,omitempty"`
+	Conversion    *Conversion            `protobuf:"bytes,11,opt,name=conversion,proto3" json:"conversion,omitempty"`
+	Details       *AccountDetails        `protobuf:"bytes,12,opt,name=details,proto3" json:"details,omitempty"`
+	Hold          string                 `protobuf:"bytes,13,opt,name=hold,proto3" json:"hold,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerEntry) Reset() {
+	*x = LedgerEntry{}
+	mi := &file_twophasecommit_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerEntry) ProtoMessage() {}
+
+func (x *LedgerEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerEntry.ProtoReflect.Descriptor instead.
+func (*LedgerEntry) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LedgerEntry) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetCounterparts() []string {
+	if x != nil {
+		return x.Counterparts
+	}
+	return nil
+}
+
+func (x *LedgerEntry) GetExternal() bool {
+	if x != nil {
+		return x.External
+	}
+	return false
+}
+
+func (x *LedgerEntry) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetConversion() *Conversion {
+	if x != nil {
+		return x.Conversion
+	}
+	return nil
+}
+
+func (x *LedgerEntry) GetDetails() *AccountDetails {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *LedgerEntry) GetHold() string {
+	if x != nil {
+		return x.Hold
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type AccountDetails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Owner         string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountDetails) Reset() {
+	*x = AccountDetails{}
+	mi := &file_twophasecommit_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountDetails) ProtoMessage() {}
+
+func (x *AccountDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountDetails.ProtoReflect.Descriptor instead.
+func (*AccountDetails) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AccountDetails) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *AccountDetails) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *AccountDetails) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type AccountInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Balance       string                 `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Owner         string                 `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountInfo) Reset() {
+	*x = AccountInfo{}
+	mi := &file_twophasecommit_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountInfo) ProtoMessage() {}
+
+func (x *AccountInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountInfo.ProtoReflect.Descriptor instead.
+func (*AccountInfo) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AccountInfo) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *AccountInfo) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *AccountInfo) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *AccountInfo) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *AccountInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type AccountPolicy struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Overdraft       string                 `protobuf:"bytes,1,opt,name=overdraft,proto3" json:"overdraft,omitempty"`
+	MinBalance      string                 `protobuf:"bytes,2,opt,name=min_balance,json=minBalance,proto3" json:"min_balance,omitempty"`
+	MaxDebit        string                 `protobuf:"bytes,3,opt,name=max_debit,json=maxDebit,proto3" json:"max_debit,omitempty"`
+	DailyDebitLimit string                 `protobuf:"bytes,4,opt,name=daily_debit_limit,json=dailyDebitLimit,proto3" json:"daily_debit_limit,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AccountPolicy) Reset() {
+	*x = AccountPolicy{}
+	mi := &file_twophasecommit_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountPolicy) ProtoMessage() {}
+
+func (x *AccountPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountPolicy.ProtoReflect.Descriptor instead.
+func (*AccountPolicy) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AccountPolicy) GetOverdraft() string {
+	if x != nil {
+		return x.Overdraft
+	}
+	return ""
+}
+
+func (x *AccountPolicy) GetMinBalance() string {
+	if x != nil {
+		return x.MinBalance
+	}
+	return ""
+}
+
+func (x *AccountPolicy) GetMaxDebit() string {
+	if x != nil {
+		return x.MaxDebit
+	}
+	return ""
+}
+
+func (x *AccountPolicy) GetDailyDebitLimit() string {
+	if x != nil {
+		return x.DailyDebitLimit
+	}
+	return ""
+}
+
+type Hold struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoldId        string                 `protobuf:"bytes,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	Account       string                 `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	Amount        string                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created,proto3" json:"created,omitempty"`
+	Expires       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires,proto3" json:"expires,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hold) Reset() {
+	*x = Hold{}
+	mi := &file_twophasecommit_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hold) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hold) ProtoMessage() {}
+
+func (x *Hold) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hold.ProtoReflect.Descriptor instead.
+func (*Hold) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Hold) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
+func (x *Hold) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *Hold) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Hold) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *Hold) GetExpires() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expires
+	}
+	return nil
+}
+
+type DecisionEntry struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Term           int64                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	Type           string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	TransactionId  string                 `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Transactions   []*Transaction         `protobuf:"bytes,4,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Unacknowledged []string               `protobuf:"bytes,5,rep,name=unacknowledged,proto3" json:"unacknowledged,omitempty"`
+	Saga           *SagaState             `protobuf:"bytes,6,opt,name=saga,proto3" json:"saga,omitempty"`
+	Outcome        *HeuristicOutcome      `protobuf:"bytes,7,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	Rates          []*ExchangeRate        `protobuf:"bytes,8,rep,name=rates,proto3" json:"rates,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DecisionEntry) Reset() {
+	*x = DecisionEntry{}
+	mi := &file_twophasecommit_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecisionEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecisionEntry) ProtoMessage() {}
+
+func (x *DecisionEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecisionEntry.ProtoReflect.Descriptor instead.
+func (*DecisionEntry) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DecisionEntry) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *DecisionEntry) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DecisionEntry) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *DecisionEntry) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *DecisionEntry) GetUnacknowledged() []string {
+	if x != nil {
+		return x.Unacknowledged
+	}
+	return nil
+}
+
+func (x *DecisionEntry) GetSaga() *SagaState {
+	if x != nil {
+		return x.Saga
+	}
+	return nil
+}
+
+func (x *DecisionEntry) GetOutcome() *HeuristicOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return nil
+}
+
+func (x *DecisionEntry) GetRates() []*ExchangeRate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+type DecisionSnapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int64                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Term          int64                  `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	Entries       []*DecisionEntry       `protobuf:"bytes,3,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecisionSnapshot) Reset() {
+	*x = DecisionSnapshot{}
+	mi := &file_twophasecommit_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecisionSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecisionSnapshot) ProtoMessage() {}
+
+func (x *DecisionSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecisionSnapshot.ProtoReflect.Descriptor instead.
+func (*DecisionSnapshot) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DecisionSnapshot) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *DecisionSnapshot) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *DecisionSnapshot) GetEntries() []*DecisionEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type HeuristicOutcome struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Participant   string                 `protobuf:"bytes,2,opt,name=participant,proto3" json:"participant,omitempty"`
+	Heuristic     string                 `protobuf:"bytes,3,opt,name=heuristic,proto3" json:"heuristic,omitempty"`
+	Actual        string                 `protobuf:"bytes,4,opt,name=actual,proto3" json:"actual,omitempty"`
+	Mixed         bool                   `protobuf:"varint,5,opt,name=mixed,proto3" json:"mixed,omitempty"`
+	Reported      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=reported,proto3" json:"reported,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeuristicOutcome) Reset() {
+	*x = HeuristicOutcome{}
+	mi := &file_twophasecommit_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeuristicOutcome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeuristicOutcome) ProtoMessage() {}
+
+func (x *HeuristicOutcome) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeuristicOutcome.ProtoReflect.Descriptor instead.
+func (*HeuristicOutcome) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *HeuristicOutcome) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *HeuristicOutcome) GetParticipant() string {
+	if x != nil {
+		return x.Participant
+	}
+	return ""
+}
+
+func (x *HeuristicOutcome) GetHeuristic() string {
+	if x != nil {
+		return x.Heuristic
+	}
+	return ""
+}
+
+func (x *HeuristicOutcome) GetActual() string {
+	if x != nil {
+		return x.Actual
+	}
+	return ""
+}
+
+func (x *HeuristicOutcome) GetMixed() bool {
+	if x != nil {
+		return x.Mixed
+	}
+	return false
+}
+
+func (x *HeuristicOutcome) GetReported() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Reported
+	}
+	return nil
+}
+
+type SagaStep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Compensation  *Transaction           `protobuf:"bytes,2,opt,name=compensation,proto3" json:"compensation,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SagaStep) Reset() {
+	*x = SagaStep{}
+	mi := &file_twophasecommit_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SagaStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SagaStep) ProtoMessage() {}
+
+func (x *SagaStep) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SagaStep.ProtoReflect.Descriptor instead.
+func (*SagaStep) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SagaStep) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *SagaStep) GetCompensation() *Transaction {
+	if x != nil {
+		return x.Compensation
+	}
+	return nil
+}
+
+func (x *SagaStep) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SagaStep) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type SagaState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SagaId        string                 `protobuf:"bytes,1,opt,name=saga_id,json=sagaId,proto3" json:"saga_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Steps         []*SagaStep            `protobuf:"bytes,3,rep,name=steps,proto3" json:"steps,omitempty"`
+	Started       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=started,proto3" json:"started,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SagaState) Reset() {
+	*x = SagaState{}
+	mi := &file_twophasecommit_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SagaState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SagaState) ProtoMessage() {}
+
+func (x *SagaState) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SagaState.ProtoReflect.Descriptor instead.
+func (*SagaState) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SagaState) GetSagaId() string {
+	if x != nil {
+		return x.SagaId
+	}
+	return ""
+}
+
+func (x *SagaState) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SagaState) GetSteps() []*SagaStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *SagaState) GetStarted() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Started
+	}
+	return nil
+}
+
+type ResourceSnapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balances      map[string]string      `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Currencies    map[string]string      `protobuf:"bytes,2,rep,name=currencies,proto3" json:"currencies,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Ledger        []*LedgerEntry         `protobuf:"bytes,3,rep,name=ledger,proto3" json:"ledger,omitempty"`
+	InDoubt       []string               `protobuf:"bytes,4,rep,name=in_doubt,json=inDoubt,proto3" json:"in_doubt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceSnapshot) Reset() {
+	*x = ResourceSnapshot{}
+	mi := &file_twophasecommit_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceSnapshot) ProtoMessage() {}
+
+func (x *ResourceSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceSnapshot.ProtoReflect.Descriptor instead.
+func (*ResourceSnapshot) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ResourceSnapshot) GetBalances() map[string]string {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+func (x *ResourceSnapshot) GetCurrencies() map[string]string {
+	if x != nil {
+		return x.Currencies
+	}
+	return nil
+}
+
+func (x *ResourceSnapshot) GetLedger() []*LedgerEntry {
+	if x != nil {
+		return x.Ledger
+	}
+	return nil
+}
+
+func (x *ResourceSnapshot) GetInDoubt() []string {
+	if x != nil {
+		return x.InDoubt
+	}
+	return nil
+}
+
+type AuditIssue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Participant   string                 `protobuf:"bytes,2,opt,name=participant,proto3" json:"participant,omitempty"`
+	Account       string                 `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditIssue) Reset() {
+	*x = AuditIssue{}
+	mi := &file_twophasecommit_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditIssue) ProtoMessage() {}
+
+func (x *AuditIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditIssue.ProtoReflect.Descriptor instead.
+func (*AuditIssue) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AuditIssue) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *AuditIssue) GetParticipant() string {
+	if x != nil {
+		return x.Participant
+	}
+	return ""
+}
+
+func (x *AuditIssue) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *AuditIssue) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type CurrencyTotals struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	BaselineTotal string                 `protobuf:"bytes,2,opt,name=baseline_total,json=baselineTotal,proto3" json:"baseline_total,omitempty"`
+	Deposits      string                 `protobuf:"bytes,3,opt,name=deposits,proto3" json:"deposits,omitempty"`
+	Withdrawals   string                 `protobuf:"bytes,4,opt,name=withdrawals,proto3" json:"withdrawals,omitempty"`
+	Exchanged     string                 `protobuf:"bytes,5,opt,name=exchanged,proto3" json:"exchanged,omitempty"`
+	ExpectedTotal string                 `protobuf:"bytes,6,opt,name=expected_total,json=expectedTotal,proto3" json:"expected_total,omitempty"`
+	ActualTotal   string                 `protobuf:"bytes,7,opt,name=actual_total,json=actualTotal,proto3" json:"actual_total,omitempty"`
+	Discrepancy   string                 `protobuf:"bytes,8,opt,name=discrepancy,proto3" json:"discrepancy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CurrencyTotals) Reset() {
+	*x = CurrencyTotals{}
+	mi := &file_twophasecommit_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CurrencyTotals) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrencyTotals) ProtoMessage() {}
+
+func (x *CurrencyTotals) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrencyTotals.ProtoReflect.Descriptor instead.
+func (*CurrencyTotals) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CurrencyTotals) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CurrencyTotals) GetBaselineTotal() string {
+	if x != nil {
+		return x.BaselineTotal
+	}
+	return ""
+}
+
+func (x *CurrencyTotals) GetDeposits() string {
+	if x != nil {
+		return x.Deposits
+	}
+	return ""
+}
+
+func (x *CurrencyTotals) GetWithdrawals() string {
+	if x != nil {
+		return x.Withdrawals
+	}
+	return ""
+}
+
+func (x *CurrencyTotals) GetExchanged() string {
+	if x != nil {
+		return x.Exchanged
+	}
+	return ""
+}
+
+func (x *CurrencyTotals) GetExpectedTotal() string {
+	if x != nil {
+		return x.ExpectedTotal
+	}
+	return ""
+}
+
+func (x *CurrencyTotals) GetActualTotal() string {
+	if x != nil {
+		return x.ActualTotal
+	}
+	return ""
+}
+
+func (x *CurrencyTotals) GetDiscrepancy() string {
+	if x != nil {
+		return x.Discrepancy
+	}
+	return ""
+}
+
+type AuditReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Baseline      *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=baseline,proto3" json:"baseline,omitempty"`
+	Cut           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=cut,proto3" json:"cut,omitempty"`
+	Participants  []string               `protobuf:"bytes,3,rep,name=participants,proto3" json:"participants,omitempty"`
+	Totals        []*CurrencyTotals      `protobuf:"bytes,4,rep,name=totals,proto3" json:"totals,omitempty"`
+	Issues        []*AuditIssue          `protobuf:"bytes,5,rep,name=issues,proto3" json:"issues,omitempty"`
+	Consistent    bool                   `protobuf:"varint,6,opt,name=consistent,proto3" json:"consistent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditReport) Reset() {
+	*x = AuditReport{}
+	mi := &file_twophasecommit_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditReport) ProtoMessage() {}
+
+func (x *AuditReport) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditReport.ProtoReflect.Descriptor instead.
+func (*AuditReport) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AuditReport) GetBaseline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Baseline
+	}
+	return nil
+}
+
+func (x *AuditReport) GetCut() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Cut
+	}
+	return nil
+}
+
+func (x *AuditReport) GetParticipants() []string {
+	if x != nil {
+		return x.Participants
+	}
+	return nil
+}
+
+func (x *AuditReport) GetTotals() []*CurrencyTotals {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+func (x *AuditReport) GetIssues() []*AuditIssue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+func (x *AuditReport) GetConsistent() bool {
+	if x != nil {
+		return x.Consistent
+	}
+	return false
+}
+
+type NodeInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeInfo) Reset() {
+	*x = NodeInfo{}
+	mi := &file_twophasecommit_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeInfo) ProtoMessage() {}
+
+func (x *NodeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeInfo.ProtoReflect.Descriptor instead.
+func (*NodeInfo) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *NodeInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NodeInfo) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *NodeInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type ConnectionState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Failures      int64                  `protobuf:"varint,4,opt,name=failures,proto3" json:"failures,omitempty"`
+	LastError     string                 `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectionState) Reset() {
+	*x = ConnectionState{}
+	mi := &file_twophasecommit_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectionState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionState) ProtoMessage() {}
+
+func (x *ConnectionState) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionState.ProtoReflect.Descriptor instead.
+func (*ConnectionState) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ConnectionState) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ConnectionState) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ConnectionState) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ConnectionState) GetFailures() int64 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *ConnectionState) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *ConnectionState) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+type ParticipantCoordinatorTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParticipantCoordinatorTransactionRequest) Reset() {
+	*x = ParticipantCoordinatorTransactionRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParticipantCoordinatorTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParticipantCoordinatorTransactionRequest) ProtoMessage() {}
+
+func (x *ParticipantCoordinatorTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParticipantCoordinatorTransactionRequest.ProtoReflect.Descriptor instead.
+func (*ParticipantCoordinatorTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ParticipantCoordinatorTransactionRequest) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type ParticipantCoordinatorTransactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParticipantCoordinatorTransactionResponse) Reset() {
+	*x = ParticipantCoordinatorTransactionResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParticipantCoordinatorTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParticipantCoordinatorTransactionResponse) ProtoMessage() {}
+
+func (x *ParticipantCoordinatorTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParticipantCoordinatorTransactionResponse.ProtoReflect.Descriptor instead.
+func (*ParticipantCoordinatorTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ParticipantCoordinatorTransactionResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type ParticipantCoordinatorSagaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParticipantCoordinatorSagaRequest) Reset() {
+	*x = ParticipantCoordinatorSagaRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParticipantCoordinatorSagaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParticipantCoordinatorSagaRequest) ProtoMessage() {}
+
+func (x *ParticipantCoordinatorSagaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParticipantCoordinatorSagaRequest.ProtoReflect.Descriptor instead.
+func (*ParticipantCoordinatorSagaRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ParticipantCoordinatorSagaRequest) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type ParticipantCoordinatorSagaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Saga          *SagaState             `protobuf:"bytes,1,opt,name=saga,proto3" json:"saga,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParticipantCoordinatorSagaResponse) Reset() {
+	*x = ParticipantCoordinatorSagaResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParticipantCoordinatorSagaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParticipantCoordinatorSagaResponse) ProtoMessage() {}
+
+func (x *ParticipantCoordinatorSagaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParticipantCoordinatorSagaResponse.ProtoReflect.Descriptor instead.
+func (*ParticipantCoordinatorSagaResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ParticipantCoordinatorSagaResponse) GetSaga() *SagaState {
+	if x != nil {
+		return x.Saga
+	}
+	return nil
+}
+
+type AddParticipantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Id            string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Version       int64                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	MinVersion    int64                  `protobuf:"varint,5,opt,name=min_version,json=minVersion,proto3" json:"min_version,omitempty"`
+	Features      []string               `protobuf:"bytes,6,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddParticipantRequest) Reset() {
+	*x = AddParticipantRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddParticipantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddParticipantRequest) ProtoMessage() {}
+
+func (x *AddParticipantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddParticipantRequest.ProtoReflect.Descriptor instead.
+func (*AddParticipantRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AddParticipantRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AddParticipantRequest) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *AddParticipantRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AddParticipantRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *AddParticipantRequest) GetMinVersion() int64 {
+	if x != nil {
+		return x.MinVersion
+	}
+	return 0
+}
+
+func (x *AddParticipantRequest) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+type AddParticipantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	MinVersion    int64                  `protobuf:"varint,3,opt,name=min_version,json=minVersion,proto3" json:"min_version,omitempty"`
+	Features      []string               `protobuf:"bytes,4,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddParticipantResponse) Reset() {
+	*x = AddParticipantResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddParticipantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddParticipantResponse) ProtoMessage() {}
+
+func (x *AddParticipantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddParticipantResponse.ProtoReflect.Descriptor instead.
+func (*AddParticipantResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AddParticipantResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AddParticipantResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *AddParticipantResponse) GetMinVersion() int64 {
+	if x != nil {
+		return x.MinVersion
+	}
+	return 0
+}
+
+func (x *AddParticipantResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+type RemoveParticipantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveParticipantRequest) Reset() {
+	*x = RemoveParticipantRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveParticipantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveParticipantRequest) ProtoMessage() {}
+
+func (x *RemoveParticipantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveParticipantRequest.ProtoReflect.Descriptor instead.
+func (*RemoveParticipantRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RemoveParticipantRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RemoveParticipantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveParticipantResponse) Reset() {
+	*x = RemoveParticipantResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveParticipantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveParticipantResponse) ProtoMessage() {}
+
+func (x *RemoveParticipantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveParticipantResponse.ProtoReflect.Descriptor instead.
+func (*RemoveParticipantResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{27}
+}
+
+type RequestVoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          int64                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	CandidateAddr string                 `protobuf:"bytes,2,opt,name=candidate_addr,json=candidateAddr,proto3" json:"candidate_addr,omitempty"`
+	LastLogIndex  int64                  `protobuf:"varint,3,opt,name=last_log_index,json=lastLogIndex,proto3" json:"last_log_index,omitempty"`
+	LastLogTerm   int64                  `protobuf:"varint,4,opt,name=last_log_term,json=lastLogTerm,proto3" json:"last_log_term,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestVoteRequest) Reset() {
+	*x = RequestVoteRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestVoteRequest) ProtoMessage() {}
+
+func (x *RequestVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestVoteRequest.ProtoReflect.Descriptor instead.
+func (*RequestVoteRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RequestVoteRequest) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *RequestVoteRequest) GetCandidateAddr() string {
+	if x != nil {
+		return x.CandidateAddr
+	}
+	return ""
+}
+
+func (x *RequestVoteRequest) GetLastLogIndex() int64 {
+	if x != nil {
+		return x.LastLogIndex
+	}
+	return 0
+}
+
+func (x *RequestVoteRequest) GetLastLogTerm() int64 {
+	if x != nil {
+		return x.LastLogTerm
+	}
+	return 0
+}
+
+type RequestVoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          int64                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	VoteGranted   bool                   `protobuf:"varint,2,opt,name=vote_granted,json=voteGranted,proto3" json:"vote_granted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestVoteResponse) Reset() {
+	*x = RequestVoteResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestVoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestVoteResponse) ProtoMessage() {}
+
+func (x *RequestVoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestVoteResponse.ProtoReflect.Descriptor instead.
+func (*RequestVoteResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RequestVoteResponse) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *RequestVoteResponse) GetVoteGranted() bool {
+	if x != nil {
+		return x.VoteGranted
+	}
+	return false
+}
+
+type AppendEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          int64                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	LeaderAddr    string                 `protobuf:"bytes,2,opt,name=leader_addr,json=leaderAddr,proto3" json:"leader_addr,omitempty"`
+	PrevLogIndex  int64                  `protobuf:"varint,3,opt,name=prev_log_index,json=prevLogIndex,proto3" json:"prev_log_index,omitempty"`
+	PrevLogTerm   int64                  `protobuf:"varint,4,opt,name=prev_log_term,json=prevLogTerm,proto3" json:"prev_log_term,omitempty"`
+	Entries       []*DecisionEntry       `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"`
+	LeaderCommit  int64                  `protobuf:"varint,6,opt,name=leader_commit,json=leaderCommit,proto3" json:"leader_commit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppendEntriesRequest) Reset() {
+	*x = AppendEntriesRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppendEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendEntriesRequest) ProtoMessage() {}
+
+func (x *AppendEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendEntriesRequest.ProtoReflect.Descriptor instead.
+func (*AppendEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *AppendEntriesRequest) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *AppendEntriesRequest) GetLeaderAddr() string {
+	if x != nil {
+		return x.LeaderAddr
+	}
+	return ""
+}
+
+func (x *AppendEntriesRequest) GetPrevLogIndex() int64 {
+	if x != nil {
+		return x.PrevLogIndex
+	}
+	return 0
+}
+
+func (x *AppendEntriesRequest) GetPrevLogTerm() int64 {
+	if x != nil {
+		return x.PrevLogTerm
+	}
+	return 0
+}
+
+func (x *AppendEntriesRequest) GetEntries() []*DecisionEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *AppendEntriesRequest) GetLeaderCommit() int64 {
+	if x != nil {
+		return x.LeaderCommit
+	}
+	return 0
+}
+
+type AppendEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          int64                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppendEntriesResponse) Reset() {
+	*x = AppendEntriesResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppendEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendEntriesResponse) ProtoMessage() {}
+
+func (x *AppendEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendEntriesResponse.ProtoReflect.Descriptor instead.
+func (*AppendEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AppendEntriesResponse) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *AppendEntriesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type InstallSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          int64                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	LeaderAddr    string                 `protobuf:"bytes,2,opt,name=leader_addr,json=leaderAddr,proto3" json:"leader_addr,omitempty"`
+	Snapshot      *DecisionSnapshot      `protobuf:"bytes,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstallSnapshotRequest) Reset() {
+	*x = InstallSnapshotRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstallSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallSnapshotRequest) ProtoMessage() {}
+
+func (x *InstallSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*InstallSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *InstallSnapshotRequest) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *InstallSnapshotRequest) GetLeaderAddr() string {
+	if x != nil {
+		return x.LeaderAddr
+	}
+	return ""
+}
+
+func (x *InstallSnapshotRequest) GetSnapshot() *DecisionSnapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+type InstallSnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          int64                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstallSnapshotResponse) Reset() {
+	*x = InstallSnapshotResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstallSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallSnapshotResponse) ProtoMessage() {}
+
+func (x *InstallSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*InstallSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *InstallSnapshotResponse) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *InstallSnapshotResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetLeaderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderRequest) Reset() {
+	*x = GetLeaderRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderRequest) ProtoMessage() {}
+
+func (x *GetLeaderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderRequest.ProtoReflect.Descriptor instead.
+func (*GetLeaderRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{34}
+}
+
+type GetLeaderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LeaderAddr    string                 `protobuf:"bytes,1,opt,name=leader_addr,json=leaderAddr,proto3" json:"leader_addr,omitempty"`
+	Term          int64                  `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderResponse) Reset() {
+	*x = GetLeaderResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderResponse) ProtoMessage() {}
+
+func (x *GetLeaderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderResponse.ProtoReflect.Descriptor instead.
+func (*GetLeaderResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetLeaderResponse) GetLeaderAddr() string {
+	if x != nil {
+		return x.LeaderAddr
+	}
+	return ""
+}
+
+func (x *GetLeaderResponse) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+type ReportHeuristicOutcomeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Outcome       *HeuristicOutcome      `protobuf:"bytes,1,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportHeuristicOutcomeRequest) Reset() {
+	*x = ReportHeuristicOutcomeRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportHeuristicOutcomeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportHeuristicOutcomeRequest) ProtoMessage() {}
+
+func (x *ReportHeuristicOutcomeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportHeuristicOutcomeRequest.ProtoReflect.Descriptor instead.
+func (*ReportHeuristicOutcomeRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ReportHeuristicOutcomeRequest) GetOutcome() *HeuristicOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return nil
+}
+
+type ReportHeuristicOutcomeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportHeuristicOutcomeResponse) Reset() {
+	*x = ReportHeuristicOutcomeResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportHeuristicOutcomeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportHeuristicOutcomeResponse) ProtoMessage() {}
+
+func (x *ReportHeuristicOutcomeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportHeuristicOutcomeResponse.ProtoReflect.Descriptor instead.
+func (*ReportHeuristicOutcomeResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{37}
+}
+
+type SetExchangeRateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rate          *ExchangeRate          `protobuf:"bytes,1,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetExchangeRateRequest) Reset() {
+	*x = SetExchangeRateRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetExchangeRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetExchangeRateRequest) ProtoMessage() {}
+
+func (x *SetExchangeRateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetExchangeRateRequest.ProtoReflect.Descriptor instead.
+func (*SetExchangeRateRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SetExchangeRateRequest) GetRate() *ExchangeRate {
+	if x != nil {
+		return x.Rate
+	}
+	return nil
+}
+
+type SetExchangeRateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetExchangeRateResponse) Reset() {
+	*x = SetExchangeRateResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetExchangeRateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetExchangeRateResponse) ProtoMessage() {}
+
+func (x *SetExchangeRateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetExchangeRateResponse.ProtoReflect.Descriptor instead.
+func (*SetExchangeRateResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{39}
+}
+
+type GetHeuristicOutcomesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHeuristicOutcomesRequest) Reset() {
+	*x = GetHeuristicOutcomesRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHeuristicOutcomesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHeuristicOutcomesRequest) ProtoMessage() {}
+
+func (x *GetHeuristicOutcomesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHeuristicOutcomesRequest.ProtoReflect.Descriptor instead.
+func (*GetHeuristicOutcomesRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{40}
+}
+
+type GetHeuristicOutcomesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Outcomes      []*HeuristicOutcome    `protobuf:"bytes,1,rep,name=outcomes,proto3" json:"outcomes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHeuristicOutcomesResponse) Reset() {
+	*x = GetHeuristicOutcomesResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHeuristicOutcomesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHeuristicOutcomesResponse) ProtoMessage() {}
+
+func (x *GetHeuristicOutcomesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHeuristicOutcomesResponse.ProtoReflect.Descriptor instead.
+func (*GetHeuristicOutcomesResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetHeuristicOutcomesResponse) GetOutcomes() []*HeuristicOutcome {
+	if x != nil {
+		return x.Outcomes
+	}
+	return nil
+}
+
+type GetSagaStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SagaId        string                 `protobuf:"bytes,1,opt,name=saga_id,json=sagaId,proto3" json:"saga_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSagaStatusRequest) Reset() {
+	*x = GetSagaStatusRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSagaStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSagaStatusRequest) ProtoMessage() {}
+
+func (x *GetSagaStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSagaStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetSagaStatusRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetSagaStatusRequest) GetSagaId() string {
+	if x != nil {
+		return x.SagaId
+	}
+	return ""
+}
+
+type GetSagaStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sagas         []*SagaState           `protobuf:"bytes,1,rep,name=sagas,proto3" json:"sagas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSagaStatusResponse) Reset() {
+	*x = GetSagaStatusResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSagaStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSagaStatusResponse) ProtoMessage() {}
+
+func (x *GetSagaStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSagaStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetSagaStatusResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetSagaStatusResponse) GetSagas() []*SagaState {
+	if x != nil {
+		return x.Sagas
+	}
+	return nil
+}
+
+type GetTransactionStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionStatusRequest) Reset() {
+	*x = GetTransactionStatusRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionStatusRequest) ProtoMessage() {}
+
+func (x *GetTransactionStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionStatusRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetTransactionStatusRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type GetTransactionStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Transactions  []*Transaction         `protobuf:"bytes,2,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionStatusResponse) Reset() {
+	*x = GetTransactionStatusResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionStatusResponse) ProtoMessage() {}
+
+func (x *GetTransactionStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetTransactionStatusResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetTransactionStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetTransactionStatusResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type AuditRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditRequest) Reset() {
+	*x = AuditRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditRequest) ProtoMessage() {}
+
+func (x *AuditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditRequest.ProtoReflect.Descriptor instead.
+func (*AuditRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *AuditRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+type AuditResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Report        *AuditReport           `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditResponse) Reset() {
+	*x = AuditResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditResponse) ProtoMessage() {}
+
+func (x *AuditResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditResponse.ProtoReflect.Descriptor instead.
+func (*AuditResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *AuditResponse) GetReport() *AuditReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+type ReceivePrepareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	TransactionId string                 `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Operations    []*ResourceOperation   `protobuf:"bytes,3,rep,name=operations,proto3" json:"operations,omitempty"`
+	Delegated     []*Transaction         `protobuf:"bytes,4,rep,name=delegated,proto3" json:"delegated,omitempty"`
+	Deadline      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceivePrepareRequest) Reset() {
+	*x = ReceivePrepareRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceivePrepareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceivePrepareRequest) ProtoMessage() {}
+
+func (x *ReceivePrepareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceivePrepareRequest.ProtoReflect.Descriptor instead.
+func (*ReceivePrepareRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ReceivePrepareRequest) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ReceivePrepareRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ReceivePrepareRequest) GetOperations() []*ResourceOperation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *ReceivePrepareRequest) GetDelegated() []*Transaction {
+	if x != nil {
+		return x.Delegated
+	}
+	return nil
+}
+
+func (x *ReceivePrepareRequest) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+type ReceivePrepareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      string                 `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceivePrepareResponse) Reset() {
+	*x = ReceivePrepareResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceivePrepareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceivePrepareResponse) ProtoMessage() {}
+
+func (x *ReceivePrepareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceivePrepareResponse.ProtoReflect.Descriptor instead.
+func (*ReceivePrepareResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ReceivePrepareResponse) GetResponse() string {
+	if x != nil {
+		return x.Response
+	}
+	return ""
+}
+
+type ReceiveCommitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiveCommitRequest) Reset() {
+	*x = ReceiveCommitRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveCommitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveCommitRequest) ProtoMessage() {}
+
+func (x *ReceiveCommitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveCommitRequest.ProtoReflect.Descriptor instead.
+func (*ReceiveCommitRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ReceiveCommitRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type ReceiveCommitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiveCommitResponse) Reset() {
+	*x = ReceiveCommitResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveCommitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveCommitResponse) ProtoMessage() {}
+
+func (x *ReceiveCommitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveCommitResponse.ProtoReflect.Descriptor instead.
+func (*ReceiveCommitResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{51}
+}
+
+type ReceiveAbortRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiveAbortRequest) Reset() {
+	*x = ReceiveAbortRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveAbortRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveAbortRequest) ProtoMessage() {}
+
+func (x *ReceiveAbortRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveAbortRequest.ProtoReflect.Descriptor instead.
+func (*ReceiveAbortRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ReceiveAbortRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type ReceiveAbortResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiveAbortResponse) Reset() {
+	*x = ReceiveAbortResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveAbortResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveAbortResponse) ProtoMessage() {}
+
+func (x *ReceiveAbortResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveAbortResponse.ProtoReflect.Descriptor instead.
+func (*ReceiveAbortResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{53}
+}
+
+type ApplySagaStepRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SagaId        string                 `protobuf:"bytes,1,opt,name=saga_id,json=sagaId,proto3" json:"saga_id,omitempty"`
+	Step          int64                  `protobuf:"varint,2,opt,name=step,proto3" json:"step,omitempty"`
+	Compensation  bool                   `protobuf:"varint,3,opt,name=compensation,proto3" json:"compensation,omitempty"`
+	Account       string                 `protobuf:"bytes,4,opt,name=account,proto3" json:"account,omitempty"`
+	Operation     string                 `protobuf:"bytes,5,opt,name=operation,proto3" json:"operation,omitempty"`
+	Amount        string                 `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Expected      string                 `protobuf:"bytes,7,opt,name=expected,proto3" json:"expected,omitempty"`
+	Currency      string                 `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	Conversion    *Conversion            `protobuf:"bytes,9,opt,name=conversion,proto3" json:"conversion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplySagaStepRequest) Reset() {
+	*x = ApplySagaStepRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplySagaStepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplySagaStepRequest) ProtoMessage() {}
+
+func (x *ApplySagaStepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplySagaStepRequest.ProtoReflect.Descriptor instead.
+func (*ApplySagaStepRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ApplySagaStepRequest) GetSagaId() string {
+	if x != nil {
+		return x.SagaId
+	}
+	return ""
+}
+
+func (x *ApplySagaStepRequest) GetStep() int64 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *ApplySagaStepRequest) GetCompensation() bool {
+	if x != nil {
+		return x.Compensation
+	}
+	return false
+}
+
+func (x *ApplySagaStepRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *ApplySagaStepRequest) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *ApplySagaStepRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *ApplySagaStepRequest) GetExpected() string {
+	if x != nil {
+		return x.Expected
+	}
+	return ""
+}
+
+func (x *ApplySagaStepRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ApplySagaStepRequest) GetConversion() *Conversion {
+	if x != nil {
+		return x.Conversion
+	}
+	return nil
+}
+
+type ApplySagaStepResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Before        string                 `protobuf:"bytes,1,opt,name=before,proto3" json:"before,omitempty"`
+	NewBalance    string                 `protobuf:"bytes,2,opt,name=new_balance,json=newBalance,proto3" json:"new_balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplySagaStepResponse) Reset() {
+	*x = ApplySagaStepResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplySagaStepResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplySagaStepResponse) ProtoMessage() {}
+
+func (x *ApplySagaStepResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplySagaStepResponse.ProtoReflect.Descriptor instead.
+func (*ApplySagaStepResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ApplySagaStepResponse) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *ApplySagaStepResponse) GetNewBalance() string {
+	if x != nil {
+		return x.NewBalance
+	}
+	return ""
+}
+
+type GetAuditSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuditSnapshotRequest) Reset() {
+	*x = GetAuditSnapshotRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuditSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuditSnapshotRequest) ProtoMessage() {}
+
+func (x *GetAuditSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuditSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*GetAuditSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{56}
+}
+
+type GetAuditSnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Snapshot      *ResourceSnapshot      `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuditSnapshotResponse) Reset() {
+	*x = GetAuditSnapshotResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuditSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuditSnapshotResponse) ProtoMessage() {}
+
+func (x *GetAuditSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuditSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*GetAuditSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetAuditSnapshotResponse) GetSnapshot() *ResourceSnapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+type HoldDecisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Release       bool                   `protobuf:"varint,1,opt,name=release,proto3" json:"release,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HoldDecisionsRequest) Reset() {
+	*x = HoldDecisionsRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldDecisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldDecisionsRequest) ProtoMessage() {}
+
+func (x *HoldDecisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldDecisionsRequest.ProtoReflect.Descriptor instead.
+func (*HoldDecisionsRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *HoldDecisionsRequest) GetRelease() bool {
+	if x != nil {
+		return x.Release
+	}
+	return false
+}
+
+type HoldDecisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HoldDecisionsResponse) Reset() {
+	*x = HoldDecisionsResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldDecisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldDecisionsResponse) ProtoMessage() {}
+
+func (x *HoldDecisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldDecisionsResponse.ProtoReflect.Descriptor instead.
+func (*HoldDecisionsResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{59}
+}
+
+type ParticipantConnectToCoordinatorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Addrs         []string               `protobuf:"bytes,1,rep,name=addrs,proto3" json:"addrs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParticipantConnectToCoordinatorRequest) Reset() {
+	*x = ParticipantConnectToCoordinatorRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParticipantConnectToCoordinatorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParticipantConnectToCoordinatorRequest) ProtoMessage() {}
+
+func (x *ParticipantConnectToCoordinatorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParticipantConnectToCoordinatorRequest.ProtoReflect.Descriptor instead.
+func (*ParticipantConnectToCoordinatorRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ParticipantConnectToCoordinatorRequest) GetAddrs() []string {
+	if x != nil {
+		return x.Addrs
+	}
+	return nil
+}
+
+type ParticipantConnectToCoordinatorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParticipantConnectToCoordinatorResponse) Reset() {
+	*x = ParticipantConnectToCoordinatorResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParticipantConnectToCoordinatorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParticipantConnectToCoordinatorResponse) ProtoMessage() {}
+
+func (x *ParticipantConnectToCoordinatorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParticipantConnectToCoordinatorResponse.ProtoReflect.Descriptor instead.
+func (*ParticipantConnectToCoordinatorResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ParticipantConnectToCoordinatorResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type AddRouteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Via           string                 `protobuf:"bytes,3,opt,name=via,proto3" json:"via,omitempty"`
+	Version       int64                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	Features      []string               `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddRouteRequest) Reset() {
+	*x = AddRouteRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddRouteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRouteRequest) ProtoMessage() {}
+
+func (x *AddRouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRouteRequest.ProtoReflect.Descriptor instead.
+func (*AddRouteRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *AddRouteRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AddRouteRequest) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *AddRouteRequest) GetVia() string {
+	if x != nil {
+		return x.Via
+	}
+	return ""
+}
+
+func (x *AddRouteRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *AddRouteRequest) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+type AddRouteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddRouteResponse) Reset() {
+	*x = AddRouteResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddRouteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRouteResponse) ProtoMessage() {}
+
+func (x *AddRouteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRouteResponse.ProtoReflect.Descriptor instead.
+func (*AddRouteResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{63}
+}
+
+type RemoveRouteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Via           string                 `protobuf:"bytes,2,opt,name=via,proto3" json:"via,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveRouteRequest) Reset() {
+	*x = RemoveRouteRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveRouteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveRouteRequest) ProtoMessage() {}
+
+func (x *RemoveRouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveRouteRequest.ProtoReflect.Descriptor instead.
+func (*RemoveRouteRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *RemoveRouteRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RemoveRouteRequest) GetVia() string {
+	if x != nil {
+		return x.Via
+	}
+	return ""
+}
+
+type RemoveRouteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveRouteResponse) Reset() {
+	*x = RemoveRouteResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveRouteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveRouteResponse) ProtoMessage() {}
+
+func (x *RemoveRouteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveRouteResponse.ProtoReflect.Descriptor instead.
+func (*RemoveRouteResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{65}
+}
+
+type LeaveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveRequest) Reset() {
+	*x = LeaveRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveRequest) ProtoMessage() {}
+
+func (x *LeaveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveRequest.ProtoReflect.Descriptor instead.
+func (*LeaveRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{66}
+}
+
+type LeaveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveResponse) Reset() {
+	*x = LeaveResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveResponse) ProtoMessage() {}
+
+func (x *LeaveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveResponse.ProtoReflect.Descriptor instead.
+func (*LeaveResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{67}
+}
+
+type HeartbeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *HeartbeatRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+type HeartbeatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatResponse) Reset() {
+	*x = HeartbeatResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatResponse) ProtoMessage() {}
+
+func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{69}
+}
+
+type ListInDoubtRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInDoubtRequest) Reset() {
+	*x = ListInDoubtRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInDoubtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInDoubtRequest) ProtoMessage() {}
+
+func (x *ListInDoubtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInDoubtRequest.ProtoReflect.Descriptor instead.
+func (*ListInDoubtRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{70}
+}
+
+type ListInDoubtResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TransactionIds []string               `protobuf:"bytes,1,rep,name=transaction_ids,json=transactionIds,proto3" json:"transaction_ids,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListInDoubtResponse) Reset() {
+	*x = ListInDoubtResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInDoubtResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInDoubtResponse) ProtoMessage() {}
+
+func (x *ListInDoubtResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInDoubtResponse.ProtoReflect.Descriptor instead.
+func (*ListInDoubtResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ListInDoubtResponse) GetTransactionIds() []string {
+	if x != nil {
+		return x.TransactionIds
+	}
+	return nil
+}
+
+type ForceHeuristicDecisionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Decision      string                 `protobuf:"bytes,2,opt,name=decision,proto3" json:"decision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForceHeuristicDecisionRequest) Reset() {
+	*x = ForceHeuristicDecisionRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceHeuristicDecisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceHeuristicDecisionRequest) ProtoMessage() {}
+
+func (x *ForceHeuristicDecisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceHeuristicDecisionRequest.ProtoReflect.Descriptor instead.
+func (*ForceHeuristicDecisionRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ForceHeuristicDecisionRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ForceHeuristicDecisionRequest) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+type ForceHeuristicDecisionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForceHeuristicDecisionResponse) Reset() {
+	*x = ForceHeuristicDecisionResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceHeuristicDecisionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceHeuristicDecisionResponse) ProtoMessage() {}
+
+func (x *ForceHeuristicDecisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceHeuristicDecisionResponse.ProtoReflect.Descriptor instead.
+func (*ForceHeuristicDecisionResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{73}
+}
+
+type SimulateDelayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SleepBefore   bool                   `protobuf:"varint,1,opt,name=sleep_before,json=sleepBefore,proto3" json:"sleep_before,omitempty"`
+	SleepAfter    bool                   `protobuf:"varint,2,opt,name=sleep_after,json=sleepAfter,proto3" json:"sleep_after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulateDelayRequest) Reset() {
+	*x = SimulateDelayRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulateDelayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulateDelayRequest) ProtoMessage() {}
+
+func (x *SimulateDelayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulateDelayRequest.ProtoReflect.Descriptor instead.
+func (*SimulateDelayRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *SimulateDelayRequest) GetSleepBefore() bool {
+	if x != nil {
+		return x.SleepBefore
+	}
+	return false
+}
+
+func (x *SimulateDelayRequest) GetSleepAfter() bool {
+	if x != nil {
+		return x.SleepAfter
+	}
+	return false
+}
+
+type SimulateDelayResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulateDelayResponse) Reset() {
+	*x = SimulateDelayResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulateDelayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulateDelayResponse) ProtoMessage() {}
+
+func (x *SimulateDelayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulateDelayResponse.ProtoReflect.Descriptor instead.
+func (*SimulateDelayResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{75}
+}
+
+type P2PQueryTransactionStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequesterAddr string                 `protobuf:"bytes,1,opt,name=requester_addr,json=requesterAddr,proto3" json:"requester_addr,omitempty"`
+	TransactionId string                 `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *P2PQueryTransactionStatusRequest) Reset() {
+	*x = P2PQueryTransactionStatusRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *P2PQueryTransactionStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*P2PQueryTransactionStatusRequest) ProtoMessage() {}
+
+func (x *P2PQueryTransactionStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use P2PQueryTransactionStatusRequest.ProtoReflect.Descriptor instead.
+func (*P2PQueryTransactionStatusRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *P2PQueryTransactionStatusRequest) GetRequesterAddr() string {
+	if x != nil {
+		return x.RequesterAddr
+	}
+	return ""
+}
+
+func (x *P2PQueryTransactionStatusRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type P2PQueryTranactionStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *P2PQueryTranactionStatusResponse) Reset() {
+	*x = P2PQueryTranactionStatusResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *P2PQueryTranactionStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*P2PQueryTranactionStatusResponse) ProtoMessage() {}
+
+func (x *P2PQueryTranactionStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use P2PQueryTranactionStatusResponse.ProtoReflect.Descriptor instead.
+func (*P2PQueryTranactionStatusResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{77}
+}
+
+type ClientParticipantTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientParticipantTransactionRequest) Reset() {
+	*x = ClientParticipantTransactionRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientParticipantTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientParticipantTransactionRequest) ProtoMessage() {}
+
+func (x *ClientParticipantTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientParticipantTransactionRequest.ProtoReflect.Descriptor instead.
+func (*ClientParticipantTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ClientParticipantTransactionRequest) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type ClientParticipantTransactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientParticipantTransactionResponse) Reset() {
+	*x = ClientParticipantTransactionResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientParticipantTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientParticipantTransactionResponse) ProtoMessage() {}
+
+func (x *ClientParticipantTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientParticipantTransactionResponse.ProtoReflect.Descriptor instead.
+func (*ClientParticipantTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ClientParticipantTransactionResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type ClientParticipantSagaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientParticipantSagaRequest) Reset() {
+	*x = ClientParticipantSagaRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientParticipantSagaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientParticipantSagaRequest) ProtoMessage() {}
+
+func (x *ClientParticipantSagaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientParticipantSagaRequest.ProtoReflect.Descriptor instead.
+func (*ClientParticipantSagaRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ClientParticipantSagaRequest) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type ClientParticipantSagaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Saga          *SagaState             `protobuf:"bytes,1,opt,name=saga,proto3" json:"saga,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientParticipantSagaResponse) Reset() {
+	*x = ClientParticipantSagaResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientParticipantSagaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientParticipantSagaResponse) ProtoMessage() {}
+
+func (x *ClientParticipantSagaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientParticipantSagaResponse.ProtoReflect.Descriptor instead.
+func (*ClientParticipantSagaResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ClientParticipantSagaResponse) GetSaga() *SagaState {
+	if x != nil {
+		return x.Saga
+	}
+	return nil
+}
+
+type GetBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceRequest) Reset() {
+	*x = GetBalanceRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceRequest) ProtoMessage() {}
+
+func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *GetBalanceRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+type GetBalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balance       string                 `protobuf:"bytes,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	Available     string                 `protobuf:"bytes,2,opt,name=available,proto3" json:"available,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceResponse) Reset() {
+	*x = GetBalanceResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceResponse) ProtoMessage() {}
+
+func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *GetBalanceResponse) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *GetBalanceResponse) GetAvailable() string {
+	if x != nil {
+		return x.Available
+	}
+	return ""
+}
+
+func (x *GetBalanceResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type DepositRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Amount        string                 `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DepositRequest) Reset() {
+	*x = DepositRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DepositRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepositRequest) ProtoMessage() {}
+
+func (x *DepositRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepositRequest.ProtoReflect.Descriptor instead.
+func (*DepositRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *DepositRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *DepositRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+type DepositResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DepositResponse) Reset() {
+	*x = DepositResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DepositResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepositResponse) ProtoMessage() {}
+
+func (x *DepositResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepositResponse.ProtoReflect.Descriptor instead.
+func (*DepositResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{85}
+}
+
+type WithdrawRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Amount        string                 `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithdrawRequest) Reset() {
+	*x = WithdrawRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawRequest) ProtoMessage() {}
+
+func (x *WithdrawRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawRequest.ProtoReflect.Descriptor instead.
+func (*WithdrawRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *WithdrawRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *WithdrawRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+type WithdrawResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithdrawResponse) Reset() {
+	*x = WithdrawResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawResponse) ProtoMessage() {}
+
+func (x *WithdrawResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawResponse.ProtoReflect.Descriptor instead.
+func (*WithdrawResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{87}
+}
+
+type OpenAccountRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Account        string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Currency       string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Scale          int64                  `protobuf:"varint,3,opt,name=scale,proto3" json:"scale,omitempty"`
+	Owner          string                 `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	InitialBalance string                 `protobuf:"bytes,5,opt,name=initial_balance,json=initialBalance,proto3" json:"initial_balance,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OpenAccountRequest) Reset() {
+	*x = OpenAccountRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenAccountRequest) ProtoMessage() {}
+
+func (x *OpenAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenAccountRequest.ProtoReflect.Descriptor instead.
+func (*OpenAccountRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *OpenAccountRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *OpenAccountRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *OpenAccountRequest) GetScale() int64 {
+	if x != nil {
+		return x.Scale
+	}
+	return 0
+}
+
+func (x *OpenAccountRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *OpenAccountRequest) GetInitialBalance() string {
+	if x != nil {
+		return x.InitialBalance
+	}
+	return ""
+}
+
+type OpenAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenAccountResponse) Reset() {
+	*x = OpenAccountResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenAccountResponse) ProtoMessage() {}
+
+func (x *OpenAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenAccountResponse.ProtoReflect.Descriptor instead.
+func (*OpenAccountResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{89}
+}
+
+type ChangeAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Operation     string                 `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeAccountRequest) Reset() {
+	*x = ChangeAccountRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeAccountRequest) ProtoMessage() {}
+
+func (x *ChangeAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeAccountRequest.ProtoReflect.Descriptor instead.
+func (*ChangeAccountRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *ChangeAccountRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *ChangeAccountRequest) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+type ChangeAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeAccountResponse) Reset() {
+	*x = ChangeAccountResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeAccountResponse) ProtoMessage() {}
+
+func (x *ChangeAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeAccountResponse.ProtoReflect.Descriptor instead.
+func (*ChangeAccountResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *ChangeAccountResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListAccountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountsRequest) Reset() {
+	*x = ListAccountsRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsRequest) ProtoMessage() {}
+
+func (x *ListAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{92}
+}
+
+type ListAccountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accounts      []*AccountInfo         `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountsResponse) Reset() {
+	*x = ListAccountsResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsResponse) ProtoMessage() {}
+
+func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *ListAccountsResponse) GetAccounts() []*AccountInfo {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+type GetHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=until,proto3" json:"until,omitempty"`
+	Offset        int64                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         int64                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryRequest) Reset() {
+	*x = GetHistoryRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryRequest) ProtoMessage() {}
+
+func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *GetHistoryRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *GetHistoryRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *GetHistoryRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *GetHistoryRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetHistoryRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LedgerEntry         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryResponse) Reset() {
+	*x = GetHistoryResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryResponse) ProtoMessage() {}
+
+func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *GetHistoryResponse) GetEntries() []*LedgerEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetHistoryResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetAccountPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountPolicyRequest) Reset() {
+	*x = GetAccountPolicyRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountPolicyRequest) ProtoMessage() {}
+
+func (x *GetAccountPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *GetAccountPolicyRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+type GetAccountPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *AccountPolicy         `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountPolicyResponse) Reset() {
+	*x = GetAccountPolicyResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountPolicyResponse) ProtoMessage() {}
+
+func (x *GetAccountPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountPolicyResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *GetAccountPolicyResponse) GetPolicy() *AccountPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type SetAccountPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Policy        *AccountPolicy         `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAccountPolicyRequest) Reset() {
+	*x = SetAccountPolicyRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAccountPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAccountPolicyRequest) ProtoMessage() {}
+
+func (x *SetAccountPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAccountPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetAccountPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *SetAccountPolicyRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *SetAccountPolicyRequest) GetPolicy() *AccountPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type SetAccountPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAccountPolicyResponse) Reset() {
+	*x = SetAccountPolicyResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAccountPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAccountPolicyResponse) ProtoMessage() {}
+
+func (x *SetAccountPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAccountPolicyResponse.ProtoReflect.Descriptor instead.
+func (*SetAccountPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{99}
+}
+
+type PlaceHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Amount        string                 `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Ttl           *durationpb.Duration   `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceHoldRequest) Reset() {
+	*x = PlaceHoldRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceHoldRequest) ProtoMessage() {}
+
+func (x *PlaceHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceHoldRequest.ProtoReflect.Descriptor instead.
+func (*PlaceHoldRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *PlaceHoldRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *PlaceHoldRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *PlaceHoldRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+type PlaceHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hold          *Hold                  `protobuf:"bytes,1,opt,name=hold,proto3" json:"hold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceHoldResponse) Reset() {
+	*x = PlaceHoldResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceHoldResponse) ProtoMessage() {}
+
+func (x *PlaceHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceHoldResponse.ProtoReflect.Descriptor instead.
+func (*PlaceHoldResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *PlaceHoldResponse) GetHold() *Hold {
+	if x != nil {
+		return x.Hold
+	}
+	return nil
+}
+
+type ReleaseHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoldId        string                 `protobuf:"bytes,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseHoldRequest) Reset() {
+	*x = ReleaseHoldRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseHoldRequest) ProtoMessage() {}
+
+func (x *ReleaseHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseHoldRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseHoldRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *ReleaseHoldRequest) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
+type ReleaseHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseHoldResponse) Reset() {
+	*x = ReleaseHoldResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseHoldResponse) ProtoMessage() {}
+
+func (x *ReleaseHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseHoldResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseHoldResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{103}
+}
+
+type ListHoldsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHoldsRequest) Reset() {
+	*x = ListHoldsRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHoldsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHoldsRequest) ProtoMessage() {}
+
+func (x *ListHoldsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHoldsRequest.ProtoReflect.Descriptor instead.
+func (*ListHoldsRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *ListHoldsRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+type ListHoldsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Holds         []*Hold                `protobuf:"bytes,1,rep,name=holds,proto3" json:"holds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHoldsResponse) Reset() {
+	*x = ListHoldsResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHoldsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHoldsResponse) ProtoMessage() {}
+
+func (x *ListHoldsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHoldsResponse.ProtoReflect.Descriptor instead.
+func (*ListHoldsResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *ListHoldsResponse) GetHolds() []*Hold {
+	if x != nil {
+		return x.Holds
+	}
+	return nil
+}
+
+type CaptureHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoldId        string                 `protobuf:"bytes,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	Amount        string                 `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	To            *Transaction           `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureHoldRequest) Reset() {
+	*x = CaptureHoldRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureHoldRequest) ProtoMessage() {}
+
+func (x *CaptureHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureHoldRequest.ProtoReflect.Descriptor instead.
+func (*CaptureHoldRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *CaptureHoldRequest) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
+func (x *CaptureHoldRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *CaptureHoldRequest) GetTo() *Transaction {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type CaptureHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureHoldResponse) Reset() {
+	*x = CaptureHoldResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureHoldResponse) ProtoMessage() {}
+
+func (x *CaptureHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureHoldResponse.ProtoReflect.Descriptor instead.
+func (*CaptureHoldResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{107}
+}
+
+type PingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingRequest) Reset() {
+	*x = PingRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingRequest) ProtoMessage() {}
+
+func (x *PingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
+func (*PingRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{108}
+}
+
+type PingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingResponse) Reset() {
+	*x = PingResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingResponse) ProtoMessage() {}
+
+func (x *PingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
+func (*PingResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *PingResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PingResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type HealthCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthCheckRequest) Reset() {
+	*x = HealthCheckRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheckRequest) ProtoMessage() {}
+
+func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheckRequest.ProtoReflect.Descriptor instead.
+func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{110}
+}
+
+type HealthCheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthCheckResponse) Reset() {
+	*x = HealthCheckResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheckResponse) ProtoMessage() {}
+
+func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
+func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *HealthCheckResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type GetInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInfoRequest) Reset() {
+	*x = GetInfoRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInfoRequest) ProtoMessage() {}
+
+func (x *GetInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetInfoRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{112}
+}
+
+type GetInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Version       int64                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	MinVersion    int64                  `protobuf:"varint,5,opt,name=min_version,json=minVersion,proto3" json:"min_version,omitempty"`
+	Features      []string               `protobuf:"bytes,6,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInfoResponse) Reset() {
+	*x = GetInfoResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInfoResponse) ProtoMessage() {}
+
+func (x *GetInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetInfoResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *GetInfoResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetInfoResponse) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *GetInfoResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GetInfoResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetInfoResponse) GetMinVersion() int64 {
+	if x != nil {
+		return x.MinVersion
+	}
+	return 0
+}
+
+func (x *GetInfoResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+type ListParticipantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListParticipantsRequest) Reset() {
+	*x = ListParticipantsRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListParticipantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListParticipantsRequest) ProtoMessage() {}
+
+func (x *ListParticipantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListParticipantsRequest.ProtoReflect.Descriptor instead.
+func (*ListParticipantsRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{114}
+}
+
+type ListParticipantsResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Names         []string                 `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	Addresses     []string                 `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Liveness      []string                 `protobuf:"bytes,3,rep,name=liveness,proto3" json:"liveness,omitempty"`
+	LastSeen      []*timestamppb.Timestamp `protobuf:"bytes,4,rep,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	Ids           []string                 `protobuf:"bytes,5,rep,name=ids,proto3" json:"ids,omitempty"`
+	Versions      []int64                  `protobuf:"varint,6,rep,packed,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListParticipantsResponse) Reset() {
+	*x = ListParticipantsResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListParticipantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListParticipantsResponse) ProtoMessage() {}
+
+func (x *ListParticipantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListParticipantsResponse.ProtoReflect.Descriptor instead.
+func (*ListParticipantsResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *ListParticipantsResponse) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *ListParticipantsResponse) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *ListParticipantsResponse) GetLiveness() []string {
+	if x != nil {
+		return x.Liveness
+	}
+	return nil
+}
+
+func (x *ListParticipantsResponse) GetLastSeen() []*timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *ListParticipantsResponse) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *ListParticipantsResponse) GetVersions() []int64 {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+type GetConnectionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConnectionsRequest) Reset() {
+	*x = GetConnectionsRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConnectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConnectionsRequest) ProtoMessage() {}
+
+func (x *GetConnectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConnectionsRequest.ProtoReflect.Descriptor instead.
+func (*GetConnectionsRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{116}
+}
+
+type GetConnectionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Connections   []*ConnectionState     `protobuf:"bytes,1,rep,name=connections,proto3" json:"connections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConnectionsResponse) Reset() {
+	*x = GetConnectionsResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConnectionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConnectionsResponse) ProtoMessage() {}
+
+func (x *GetConnectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConnectionsResponse.ProtoReflect.Descriptor instead.
+func (*GetConnectionsResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *GetConnectionsResponse) GetConnections() []*ConnectionState {
+	if x != nil {
+		return x.Connections
+	}
+	return nil
+}
+
+type ListNodesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNodesRequest) Reset() {
+	*x = ListNodesRequest{}
+	mi := &file_twophasecommit_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNodesRequest) ProtoMessage() {}
+
+func (x *ListNodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNodesRequest.ProtoReflect.Descriptor instead.
+func (*ListNodesRequest) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{118}
+}
+
+type ListNodesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nodes         []*NodeInfo            `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNodesResponse) Reset() {
+	*x = ListNodesResponse{}
+	mi := &file_twophasecommit_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNodesResponse) ProtoMessage() {}
+
+func (x *ListNodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_twophasecommit_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNodesResponse.ProtoReflect.Descriptor instead.
+func (*ListNodesResponse) Descriptor() ([]byte, []int) {
+	return file_twophasecommit_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *ListNodesResponse) GetNodes() []*NodeInfo {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+var File_twophasecommit_proto protoreflect.FileDescriptor
+
+const file_twophasecommit_proto_rawDesc = "" +
+	"\n" +
+	"\x14twophasecommit.proto\x12\x0etwophasecommit\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8d\x02\n" +
+	"\vTransaction\x12\x12\n" +
+	"\x04addr\x18\x01 \x01(\tR\x04addr\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aaccount\x18\x03 \x01(\tR\aaccount\x12\x1c\n" +
+	"\toperation\x18\x04 \x01(\tR\toperation\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bexpected\x18\x06 \x01(\tR\bexpected\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12:\n" +
+	"\n" +
+	"conversion\x18\b \x01(\v2\x1a.twophasecommit.ConversionR\n" +
+	"conversion\x12\x12\n" +
+	"\x04hold\x18\t \x01(\tR\x04hold\"f\n" +
+	"\n" +
+	"Conversion\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\tR\x04rate\x12\x1a\n" +
+	"\boriginal\x18\x04 \x01(\tR\boriginalJ\x04\b\x05\x10\x06\"F\n" +
+	"\fExchangeRate\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\tR\x04rate\"\xa3\x02\n" +
+	"\x11ResourceOperation\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1c\n" +
+	"\toperation\x18\x02 \x01(\tR\toperation\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bexpected\x18\x04 \x01(\tR\bexpected\x12\"\n" +
+	"\fcounterparts\x18\x05 \x03(\tR\fcounterparts\x12\x1a\n" +
+	"\bexternal\x18\x06 \x01(\bR\bexternal\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12:\n" +
+	"\n" +
+	"conversion\x18\b \x01(\v2\x1a.twophasecommit.ConversionR\n" +
+	"conversion\x12\x12\n" +
+	"\x04hold\x18\t \x01(\tR\x04hold\"\xe3\x03\n" +
+	"\vLedgerEntry\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x12\x18\n" +
+	"\aaccount\x18\x03 \x01(\tR\aaccount\x12\x1c\n" +
+	"\toperation\x18\x04 \x01(\tR\toperation\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\tR\x06amount\x12\x16\n" +
+	"\x06before\x18\x06 \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\a \x01(\tR\x05after\x12\"\n" +
+	"\fcounterparts\x18\b \x03(\tR\fcounterparts\x12\x1a\n" +
+	"\bexternal\x18\t \x01(\bR\bexternal\x12\x1a\n" +
+	"\bcurrency\x18\n" +
+	" \x01(\tR\bcurrency\x12:\n" +
+	"\n" +
+	"conversion\x18\v \x01(\v2\x1a.twophasecommit.ConversionR\n" +
+	"conversion\x128\n" +
+	"\adetails\x18\f \x01(\v2\x1e.twophasecommit.AccountDetailsR\adetails\x12\x12\n" +
+	"\x04hold\x18\r \x01(\tR\x04hold\x12.\n" +
+	"\x04time\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"Z\n" +
+	"\x0eAccountDetails\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"\x8b\x01\n" +
+	"\vAccountInfo\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\tR\abalance\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x14\n" +
+	"\x05owner\x18\x04 \x01(\tR\x05owner\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\"\x97\x01\n" +
+	"\rAccountPolicy\x12\x1c\n" +
+	"\toverdraft\x18\x01 \x01(\tR\toverdraft\x12\x1f\n" +
+	"\vmin_balance\x18\x02 \x01(\tR\n" +
+	"minBalance\x12\x1b\n" +
+	"\tmax_debit\x18\x03 \x01(\tR\bmaxDebit\x12*\n" +
+	"\x11daily_debit_limit\x18\x04 \x01(\tR\x0fdailyDebitLimit\"\xbd\x01\n" +
+	"\x04Hold\x12\x17\n" +
+	"\ahold_id\x18\x01 \x01(\tR\x06holdId\x12\x18\n" +
+	"\aaccount\x18\x02 \x01(\tR\aaccount\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\x124\n" +
+	"\acreated\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x124\n" +
+	"\aexpires\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aexpires\"\xe6\x02\n" +
+	"\rDecisionEntry\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x03R\x04term\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\tR\rtransactionId\x12?\n" +
+	"\ftransactions\x18\x04 \x03(\v2\x1b.twophasecommit.TransactionR\ftransactions\x12&\n" +
+	"\x0eunacknowledged\x18\x05 \x03(\tR\x0eunacknowledged\x12-\n" +
+	"\x04saga\x18\x06 \x01(\v2\x19.twophasecommit.SagaStateR\x04saga\x12:\n" +
+	"\aoutcome\x18\a \x01(\v2 .twophasecommit.HeuristicOutcomeR\aoutcome\x122\n" +
+	"\x05rates\x18\b \x03(\v2\x1c.twophasecommit.ExchangeRateR\x05rates\"u\n" +
+	"\x10DecisionSnapshot\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x12\n" +
+	"\x04term\x18\x02 \x01(\x03R\x04term\x127\n" +
+	"\aentries\x18\x03 \x03(\v2\x1d.twophasecommit.DecisionEntryR\aentries\"\xdf\x01\n" +
+	"\x10HeuristicOutcome\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12 \n" +
+	"\vparticipant\x18\x02 \x01(\tR\vparticipant\x12\x1c\n" +
+	"\theuristic\x18\x03 \x01(\tR\theuristic\x12\x16\n" +
+	"\x06actual\x18\x04 \x01(\tR\x06actual\x12\x14\n" +
+	"\x05mixed\x18\x05 \x01(\bR\x05mixed\x126\n" +
+	"\breported\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\breported\"\xb8\x01\n" +
+	"\bSagaStep\x12=\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x1b.twophasecommit.TransactionR\vtransaction\x12?\n" +
+	"\fcompensation\x18\x02 \x01(\v2\x1b.twophasecommit.TransactionR\fcompensation\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xa2\x01\n" +
+	"\tSagaState\x12\x17\n" +
+	"\asaga_id\x18\x01 \x01(\tR\x06sagaId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12.\n" +
+	"\x05steps\x18\x03 \x03(\v2\x18.twophasecommit.SagaStepR\x05steps\x124\n" +
+	"\astarted\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\astarted\"\xfc\x02\n" +
+	"\x10ResourceSnapshot\x12J\n" +
+	"\bbalances\x18\x01 \x03(\v2..twophasecommit.ResourceSnapshot.BalancesEntryR\bbalances\x12P\n" +
+	"\n" +
+	"currencies\x18\x02 \x03(\v20.twophasecommit.ResourceSnapshot.CurrenciesEntryR\n" +
+	"currencies\x123\n" +
+	"\x06ledger\x18\x03 \x03(\v2\x1b.twophasecommit.LedgerEntryR\x06ledger\x12\x19\n" +
+	"\bin_doubt\x18\x04 \x03(\tR\ainDoubt\x1a;\n" +
+	"\rBalancesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a=\n" +
+	"\x0fCurrenciesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x91\x01\n" +
+	"\n" +
+	"AuditIssue\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12 \n" +
+	"\vparticipant\x18\x02 \x01(\tR\vparticipant\x12\x18\n" +
+	"\aaccount\x18\x03 \x01(\tR\aaccount\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"\x9b\x02\n" +
+	"\x0eCurrencyTotals\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12%\n" +
+	"\x0ebaseline_total\x18\x02 \x01(\tR\rbaselineTotal\x12\x1a\n" +
+	"\bdeposits\x18\x03 \x01(\tR\bdeposits\x12 \n" +
+	"\vwithdrawals\x18\x04 \x01(\tR\vwithdrawals\x12\x1c\n" +
+	"\texchanged\x18\x05 \x01(\tR\texchanged\x12%\n" +
+	"\x0eexpected_total\x18\x06 \x01(\tR\rexpectedTotal\x12!\n" +
+	"\factual_total\x18\a \x01(\tR\vactualTotal\x12 \n" +
+	"\vdiscrepancy\x18\b \x01(\tR\vdiscrepancy\"\xa3\x02\n" +
+	"\vAuditReport\x126\n" +
+	"\bbaseline\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\bbaseline\x12,\n" +
+	"\x03cut\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x03cut\x12\"\n" +
+	"\fparticipants\x18\x03 \x03(\tR\fparticipants\x126\n" +
+	"\x06totals\x18\x04 \x03(\v2\x1e.twophasecommit.CurrencyTotalsR\x06totals\x122\n" +
+	"\x06issues\x18\x05 \x03(\v2\x1a.twophasecommit.AuditIssueR\x06issues\x12\x1e\n" +
+	"\n" +
+	"consistent\x18\x06 \x01(\bR\n" +
+	"consistent\"F\n" +
+	"\bNodeInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04addr\x18\x02 \x01(\tR\x04addr\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\"\xc2\x01\n" +
+	"\x0fConnectionState\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x1a\n" +
+	"\bfailures\x18\x04 \x01(\x03R\bfailures\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x05 \x01(\tR\tlastError\x120\n" +
+	"\x05since\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\"k\n" +
+	"(ParticipantCoordinatorTransactionRequest\x12?\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x1b.twophasecommit.TransactionR\ftransactions\"R\n" +
+	")ParticipantCoordinatorTransactionResponse\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"d\n" +
+	"!ParticipantCoordinatorSagaRequest\x12?\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x1b.twophasecommit.TransactionR\ftransactions\"S\n" +
+	"\"ParticipantCoordinatorSagaResponse\x12-\n" +
+	"\x04saga\x18\x01 \x01(\v2\x19.twophasecommit.SagaStateR\x04saga\"\xa6\x01\n" +
+	"\x15AddParticipantRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04addr\x18\x02 \x01(\tR\x04addr\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x03R\aversion\x12\x1f\n" +
+	"\vmin_version\x18\x05 \x01(\x03R\n" +
+	"minVersion\x12\x1a\n" +
+	"\bfeatures\x18\x06 \x03(\tR\bfeatures\"\x7f\n" +
+	"\x16AddParticipantResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x12\x1f\n" +
+	"\vmin_version\x18\x03 \x01(\x03R\n" +
+	"minVersion\x12\x1a\n" +
+	"\bfeatures\x18\x04 \x03(\tR\bfeatures\"*\n" +
+	"\x18RemoveParticipantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1b\n" +
+	"\x19RemoveParticipantResponse\"\x99\x01\n" +
+	"\x12RequestVoteRequest\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x03R\x04term\x12%\n" +
+	"\x0ecandidate_addr\x18\x02 \x01(\tR\rcandidateAddr\x12$\n" +
+	"\x0elast_log_index\x18\x03 \x01(\x03R\flastLogIndex\x12\"\n" +
+	"\rlast_log_term\x18\x04 \x01(\x03R\vlastLogTerm\"L\n" +
+	"\x13RequestVoteResponse\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x03R\x04term\x12!\n" +
+	"\fvote_granted\x18\x02 \x01(\bR\vvoteGranted\"\xf3\x01\n" +
+	"\x14AppendEntriesRequest\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x03R\x04term\x12\x1f\n" +
+	"\vleader_addr\x18\x02 \x01(\tR\n" +
+	"leaderAddr\x12$\n" +
+	"\x0eprev_log_index\x18\x03 \x01(\x03R\fprevLogIndex\x12\"\n" +
+	"\rprev_log_term\x18\x04 \x01(\x03R\vprevLogTerm\x127\n" +
+	"\aentries\x18\x05 \x03(\v2\x1d.twophasecommit.DecisionEntryR\aentries\x12#\n" +
+	"\rleader_commit\x18\x06 \x01(\x03R\fleaderCommit\"E\n" +
+	"\x15AppendEntriesResponse\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x03R\x04term\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"\x8b\x01\n" +
+	"\x16InstallSnapshotRequest\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x03R\x04term\x12\x1f\n" +
+	"\vleader_addr\x18\x02 \x01(\tR\n" +
+	"leaderAddr\x12<\n" +
+	"\bsnapshot\x18\x03 \x01(\v2 .twophasecommit.DecisionSnapshotR\bsnapshot\"G\n" +
+	"\x17InstallSnapshotResponse\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x03R\x04term\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"\x12\n" +
+	"\x10GetLeaderRequest\"H\n" +
+	"\x11GetLeaderResponse\x12\x1f\n" +
+	"\vleader_addr\x18\x01 \x01(\tR\n" +
+	"leaderAddr\x12\x12\n" +
+	"\x04term\x18\x02 \x01(\x03R\x04term\"[\n" +
+	"\x1dReportHeuristicOutcomeRequest\x12:\n" +
+	"\aoutcome\x18\x01 \x01(\v2 .twophasecommit.HeuristicOutcomeR\aoutcome\" \n" +
+	"\x1eReportHeuristicOutcomeResponse\"J\n" +
+	"\x16SetExchangeRateRequest\x120\n" +
+	"\x04rate\x18\x01 \x01(\v2\x1c.twophasecommit.ExchangeRateR\x04rate\"\x19\n" +
+	"\x17SetExchangeRateResponse\"\x1d\n" +
+	"\x1bGetHeuristicOutcomesRequest\"\\\n" +
+	"\x1cGetHeuristicOutcomesResponse\x12<\n" +
+	"\boutcomes\x18\x01 \x03(\v2 .twophasecommit.HeuristicOutcomeR\boutcomes\"/\n" +
+	"\x14GetSagaStatusRequest\x12\x17\n" +
+	"\asaga_id\x18\x01 \x01(\tR\x06sagaId\"H\n" +
+	"\x15GetSagaStatusResponse\x12/\n" +
+	"\x05sagas\x18\x01 \x03(\v2\x19.twophasecommit.SagaStateR\x05sagas\"D\n" +
+	"\x1bGetTransactionStatusRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"w\n" +
+	"\x1cGetTransactionStatusResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12?\n" +
+	"\ftransactions\x18\x02 \x03(\v2\x1b.twophasecommit.TransactionR\ftransactions\"@\n" +
+	"\fAuditRequest\x120\n" +
+	"\x05since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\"D\n" +
+	"\rAuditResponse\x123\n" +
+	"\x06report\x18\x01 \x01(\v2\x1b.twophasecommit.AuditReportR\x06report\"\xb5\x02\n" +
+	"\x15ReceivePrepareRequest\x12?\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x1b.twophasecommit.TransactionR\ftransactions\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x12A\n" +
+	"\n" +
+	"operations\x18\x03 \x03(\v2!.twophasecommit.ResourceOperationR\n" +
+	"operations\x129\n" +
+	"\tdelegated\x18\x04 \x03(\v2\x1b.twophasecommit.TransactionR\tdelegated\x126\n" +
+	"\bdeadline\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\"4\n" +
+	"\x16ReceivePrepareResponse\x12\x1a\n" +
+	"\bresponse\x18\x01 \x01(\tR\bresponse\"=\n" +
+	"\x14ReceiveCommitRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"\x17\n" +
+	"\x15ReceiveCommitResponse\"<\n" +
+	"\x13ReceiveAbortRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"\x16\n" +
+	"\x14ReceiveAbortResponse\"\xab\x02\n" +
+	"\x14ApplySagaStepRequest\x12\x17\n" +
+	"\asaga_id\x18\x01 \x01(\tR\x06sagaId\x12\x12\n" +
+	"\x04step\x18\x02 \x01(\x03R\x04step\x12\"\n" +
+	"\fcompensation\x18\x03 \x01(\bR\fcompensation\x12\x18\n" +
+	"\aaccount\x18\x04 \x01(\tR\aaccount\x12\x1c\n" +
+	"\toperation\x18\x05 \x01(\tR\toperation\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bexpected\x18\a \x01(\tR\bexpected\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\x12:\n" +
+	"\n" +
+	"conversion\x18\t \x01(\v2\x1a.twophasecommit.ConversionR\n" +
+	"conversion\"P\n" +
+	"\x15ApplySagaStepResponse\x12\x16\n" +
+	"\x06before\x18\x01 \x01(\tR\x06before\x12\x1f\n" +
+	"\vnew_balance\x18\x02 \x01(\tR\n" +
+	"newBalance\"\x19\n" +
+	"\x17GetAuditSnapshotRequest\"X\n" +
+	"\x18GetAuditSnapshotResponse\x12<\n" +
+	"\bsnapshot\x18\x01 \x01(\v2 .twophasecommit.ResourceSnapshotR\bsnapshot\"0\n" +
+	"\x14HoldDecisionsRequest\x12\x18\n" +
+	"\arelease\x18\x01 \x01(\bR\arelease\"\x17\n" +
+	"\x15HoldDecisionsResponse\">\n" +
+	"&ParticipantConnectToCoordinatorRequest\x12\x14\n" +
+	"\x05addrs\x18\x01 \x03(\tR\x05addrs\"9\n" +
+	"'ParticipantConnectToCoordinatorResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x81\x01\n" +
+	"\x0fAddRouteRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04addr\x18\x02 \x01(\tR\x04addr\x12\x10\n" +
+	"\x03via\x18\x03 \x01(\tR\x03via\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x03R\aversion\x12\x1a\n" +
+	"\bfeatures\x18\x05 \x03(\tR\bfeatures\"\x12\n" +
+	"\x10AddRouteResponse\":\n" +
+	"\x12RemoveRouteRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03via\x18\x02 \x01(\tR\x03via\"\x15\n" +
+	"\x13RemoveRouteResponse\"\x0e\n" +
+	"\fLeaveRequest\"\x0f\n" +
+	"\rLeaveResponse\"&\n" +
+	"\x10HeartbeatRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\"\x13\n" +
+	"\x11HeartbeatResponse\"\x14\n" +
+	"\x12ListInDoubtRequest\">\n" +
+	"\x13ListInDoubtResponse\x12'\n" +
+	"\x0ftransaction_ids\x18\x01 \x03(\tR\x0etransactionIds\"b\n" +
+	"\x1dForceHeuristicDecisionRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x1a\n" +
+	"\bdecision\x18\x02 \x01(\tR\bdecision\" \n" +
+	"\x1eForceHeuristicDecisionResponse\"Z\n" +
+	"\x14SimulateDelayRequest\x12!\n" +
+	"\fsleep_before\x18\x01 \x01(\bR\vsleepBefore\x12\x1f\n" +
+	"\vsleep_after\x18\x02 \x01(\bR\n" +
+	"sleepAfter\"\x17\n" +
+	"\x15SimulateDelayResponse\"p\n" +
+	" P2PQueryTransactionStatusRequest\x12%\n" +
+	"\x0erequester_addr\x18\x01 \x01(\tR\rrequesterAddr\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\"\"\n" +
+	" P2PQueryTranactionStatusResponse\"f\n" +
+	"#ClientParticipantTransactionRequest\x12?\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x1b.twophasecommit.TransactionR\ftransactions\"M\n" +
+	"$ClientParticipantTransactionResponse\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"_\n" +
+	"\x1cClientParticipantSagaRequest\x12?\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x1b.twophasecommit.TransactionR\ftransactions\"N\n" +
+	"\x1dClientParticipantSagaResponse\x12-\n" +
+	"\x04saga\x18\x01 \x01(\v2\x19.twophasecommit.SagaStateR\x04saga\"-\n" +
+	"\x11GetBalanceRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\"h\n" +
+	"\x12GetBalanceResponse\x12\x18\n" +
+	"\abalance\x18\x01 \x01(\tR\abalance\x12\x1c\n" +
+	"\tavailable\x18\x02 \x01(\tR\tavailable\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"B\n" +
+	"\x0eDepositRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\tR\x06amount\"\x11\n" +
+	"\x0fDepositResponse\"C\n" +
+	"\x0fWithdrawRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\tR\x06amount\"\x12\n" +
+	"\x10WithdrawResponse\"\x9f\x01\n" +
+	"\x12OpenAccountRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x14\n" +
+	"\x05scale\x18\x03 \x01(\x03R\x05scale\x12\x14\n" +
+	"\x05owner\x18\x04 \x01(\tR\x05owner\x12'\n" +
+	"\x0finitial_balance\x18\x05 \x01(\tR\x0einitialBalance\"\x15\n" +
+	"\x13OpenAccountResponse\"N\n" +
+	"\x14ChangeAccountRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x1c\n" +
+	"\toperation\x18\x02 \x01(\tR\toperation\"/\n" +
+	"\x15ChangeAccountResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"\x15\n" +
+	"\x13ListAccountsRequest\"O\n" +
+	"\x14ListAccountsResponse\x127\n" +
+	"\baccounts\x18\x01 \x03(\v2\x1b.twophasecommit.AccountInfoR\baccounts\"\xbf\x01\n" +
+	"\x11GetHistoryRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x120\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x03R\x05limit\"a\n" +
+	"\x12GetHistoryResponse\x125\n" +
+	"\aentries\x18\x01 \x03(\v2\x1b.twophasecommit.LedgerEntryR\aentries\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"3\n" +
+	"\x17GetAccountPolicyRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\"Q\n" +
+	"\x18GetAccountPolicyResponse\x125\n" +
+	"\x06policy\x18\x01 \x01(\v2\x1d.twophasecommit.AccountPolicyR\x06policy\"j\n" +
+	"\x17SetAccountPolicyRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x125\n" +
+	"\x06policy\x18\x02 \x01(\v2\x1d.twophasecommit.AccountPolicyR\x06policy\"\x1a\n" +
+	"\x18SetAccountPolicyResponse\"q\n" +
+	"\x10PlaceHoldRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\tR\x06amount\x12+\n" +
+	"\x03ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\"=\n" +
+	"\x11PlaceHoldResponse\x12(\n" +
+	"\x04hold\x18\x01 \x01(\v2\x14.twophasecommit.HoldR\x04hold\"-\n" +
+	"\x12ReleaseHoldRequest\x12\x17\n" +
+	"\ahold_id\x18\x01 \x01(\tR\x06holdId\"\x15\n" +
+	"\x13ReleaseHoldResponse\",\n" +
+	"\x10ListHoldsRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\"?\n" +
+	"\x11ListHoldsResponse\x12*\n" +
+	"\x05holds\x18\x01 \x03(\v2\x14.twophasecommit.HoldR\x05holds\"r\n" +
+	"\x12CaptureHoldRequest\x12\x17\n" +
+	"\ahold_id\x18\x01 \x01(\tR\x06holdId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\tR\x06amount\x12+\n" +
+	"\x02to\x18\x03 \x01(\v2\x1b.twophasecommit.TransactionR\x02to\"\x15\n" +
+	"\x13CaptureHoldResponse\"\r\n" +
+	"\vPingRequest\"<\n" +
+	"\fPingResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x14\n" +
+	"\x12HealthCheckRequest\"-\n" +
+	"\x13HealthCheckResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"\x10\n" +
+	"\x0eGetInfoRequest\"\xa4\x01\n" +
+	"\x0fGetInfoResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04addr\x18\x02 \x01(\tR\x04addr\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x03R\aversion\x12\x1f\n" +
+	"\vmin_version\x18\x05 \x01(\x03R\n" +
+	"minVersion\x12\x1a\n" +
+	"\bfeatures\x18\x06 \x03(\tR\bfeatures\"\x19\n" +
+	"\x17ListParticipantsRequest\"\xd1\x01\n" +
+	"\x18ListParticipantsResponse\x12\x14\n" +
+	"\x05names\x18\x01 \x03(\tR\x05names\x12\x1c\n" +
+	"\taddresses\x18\x02 \x03(\tR\taddresses\x12\x1a\n" +
+	"\bliveness\x18\x03 \x03(\tR\bliveness\x127\n" +
+	"\tlast_seen\x18\x04 \x03(\v2\x1a.google.protobuf.TimestampR\blastSeen\x12\x10\n" +
+	"\x03ids\x18\x05 \x03(\tR\x03ids\x12\x1a\n" +
+	"\bversions\x18\x06 \x03(\x03R\bversions\"\x17\n" +
+	"\x15GetConnectionsRequest\"[\n" +
+	"\x16GetConnectionsResponse\x12A\n" +
+	"\vconnections\x18\x01 \x03(\v2\x1f.twophasecommit.ConnectionStateR\vconnections\"\x12\n" +
+	"\x10ListNodesRequest\"C\n" +
+	"\x11ListNodesResponse\x12.\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x18.twophasecommit.NodeInfoR\x05nodes2\xcc\v\n" +
+	"\vCoordinator\x12\x98\x01\n" +
+	"!ParticipantCoordinatorTransaction\x128.twophasecommit.ParticipantCoordinatorTransactionRequest\x1a9.twophasecommit.ParticipantCoordinatorTransactionResponse\x12\x83\x01\n" +
+	"\x1aParticipantCoordinatorSaga\x121.twophasecommit.ParticipantCoordinatorSagaRequest\x1a2.twophasecommit.ParticipantCoordinatorSagaResponse\x12_\n" +
+	"\x0eAddParticipant\x12%.twophasecommit.AddParticipantRequest\x1a&.twophasecommit.AddParticipantResponse\x12h\n" +
+	"\x11RemoveParticipant\x12(.twophasecommit.RemoveParticipantRequest\x1a).twophasecommit.RemoveParticipantResponse\x12V\n" +
+	"\vRequestVote\x12\".twophasecommit.RequestVoteRequest\x1a#.twophasecommit.RequestVoteResponse\x12\\\n" +
+	"\rAppendEntries\x12$.twophasecommit.AppendEntriesRequest\x1a%.twophasecommit.AppendEntriesResponse\x12b\n" +
+	"\x0fInstallSnapshot\x12&.twophasecommit.InstallSnapshotRequest\x1a'.twophasecommit.InstallSnapshotResponse\x12P\n" +
+	"\tGetLeader\x12 .twophasecommit.GetLeaderRequest\x1a!.twophasecommit.GetLeaderResponse\x12w\n" +
+	"\x16ReportHeuristicOutcome\x12-.twophasecommit.ReportHeuristicOutcomeRequest\x1a..twophasecommit.ReportHeuristicOutcomeResponse\x12q\n" +
+	"\x14GetHeuristicOutcomes\x12+.twophasecommit.GetHeuristicOutcomesRequest\x1a,.twophasecommit.GetHeuristicOutcomesResponse\x12\\\n" +
+	"\rGetSagaStatus\x12$.twophasecommit.GetSagaStatusRequest\x1a%.twophasecommit.GetSagaStatusResponse\x12q\n" +
+	"\x14GetTransactionStatus\x12+.twophasecommit.GetTransactionStatusRequest\x1a,.twophasecommit.GetTransactionStatusResponse\x12D\n" +
+	"\x05Audit\x12\x1c.twophasecommit.AuditRequest\x1a\x1d.twophasecommit.AuditResponse\x12b\n" +
+	"\x0fSetExchangeRate\x12&.twophasecommit.SetExchangeRateRequest\x1a'.twophasecommit.SetExchangeRateResponse2\xce\v\n" +
+	"\vParticipant\x12_\n" +
+	"\x0eReceivePrepare\x12%.twophasecommit.ReceivePrepareRequest\x1a&.twophasecommit.ReceivePrepareResponse\x12\\\n" +
+	"\rReceiveCommit\x12$.twophasecommit.ReceiveCommitRequest\x1a%.twophasecommit.ReceiveCommitResponse\x12Y\n" +
+	"\fReceiveAbort\x12#.twophasecommit.ReceiveAbortRequest\x1a$.twophasecommit.ReceiveAbortResponse\x12\\\n" +
+	"\rApplySagaStep\x12$.twophasecommit.ApplySagaStepRequest\x1a%.twophasecommit.ApplySagaStepResponse\x12e\n" +
+	"\x10GetAuditSnapshot\x12'.twophasecommit.GetAuditSnapshotRequest\x1a(.twophasecommit.GetAuditSnapshotResponse\x12\\\n" +
+	"\rHoldDecisions\x12$.twophasecommit.HoldDecisionsRequest\x1a%.twophasecommit.HoldDecisionsResponse\x12\x92\x01\n" +
+	"\x1fParticipantConnectToCoordinator\x126.twophasecommit.ParticipantConnectToCoordinatorRequest\x1a7.twophasecommit.ParticipantConnectToCoordinatorResponse\x12M\n" +
+	"\bAddRoute\x12\x1f.twophasecommit.AddRouteRequest\x1a .twophasecommit.AddRouteResponse\x12V\n" +
+	"\vRemoveRoute\x12\".twophasecommit.RemoveRouteRequest\x1a#.twophasecommit.RemoveRouteResponse\x12D\n" +
+	"\x05Leave\x12\x1c.twophasecommit.LeaveRequest\x1a\x1d.twophasecommit.LeaveResponse\x12P\n" +
+	"\tHeartbeat\x12 .twophasecommit.HeartbeatRequest\x1a!.twophasecommit.HeartbeatResponse\x12V\n" +
+	"\vListInDoubt\x12\".twophasecommit.ListInDoubtRequest\x1a#.twophasecommit.ListInDoubtResponse\x12w\n" +
+	"\x16ForceHeuristicDecision\x12-.twophasecommit.ForceHeuristicDecisionRequest\x1a..twophasecommit.ForceHeuristicDecisionResponse\x12\\\n" +
+	"\rSimulateDelay\x12$.twophasecommit.SimulateDelayRequest\x1a%.twophasecommit.SimulateDelayResponse\x12\x7f\n" +
+	"\x19P2PQueryTransactionStatus\x120.twophasecommit.P2PQueryTransactionStatusRequest\x1a0.twophasecommit.P2PQueryTranactionStatusResponse2\x83\x0f\n" +
+	"\x06Client\x12\x89\x01\n" +
+	"\x1cClientParticipantTransaction\x123.twophasecommit.ClientParticipantTransactionRequest\x1a4.twophasecommit.ClientParticipantTransactionResponse\x12t\n" +
+	"\x15ClientParticipantSaga\x12,.twophasecommit.ClientParticipantSagaRequest\x1a-.twophasecommit.ClientParticipantSagaResponse\x12S\n" +
+	"\n" +
+	"GetBalance\x12!.twophasecommit.GetBalanceRequest\x1a\".twophasecommit.GetBalanceResponse\x12J\n" +
+	"\aDeposit\x12\x1e.twophasecommit.DepositRequest\x1a\x1f.twophasecommit.DepositResponse\x12M\n" +
+	"\bWithdraw\x12\x1f.twophasecommit.WithdrawRequest\x1a .twophasecommit.WithdrawResponse\x12V\n" +
+	"\vOpenAccount\x12\".twophasecommit.OpenAccountRequest\x1a#.twophasecommit.OpenAccountResponse\x12\\\n" +
+	"\rChangeAccount\x12$.twophasecommit.ChangeAccountRequest\x1a%.twophasecommit.ChangeAccountResponse\x12Y\n" +
+	"\fListAccounts\x12#.twophasecommit.ListAccountsRequest\x1a$.twophasecommit.ListAccountsResponse\x12S\n" +
+	"\n" +
+	"GetHistory\x12!.twophasecommit.GetHistoryRequest\x1a\".twophasecommit.GetHistoryResponse\x12e\n" +
+	"\x10GetAccountPolicy\x12'.twophasecommit.GetAccountPolicyRequest\x1a(.twophasecommit.GetAccountPolicyResponse\x12e\n" +
+	"\x10SetAccountPolicy\x12'.twophasecommit.SetAccountPolicyRequest\x1a(.twophasecommit.SetAccountPolicyResponse\x12P\n" +
+	"\tPlaceHold\x12 .twophasecommit.PlaceHoldRequest\x1a!.twophasecommit.PlaceHoldResponse\x12V\n" +
+	"\vReleaseHold\x12\".twophasecommit.ReleaseHoldRequest\x1a#.twophasecommit.ReleaseHoldResponse\x12P\n" +
+	"\tListHolds\x12 .twophasecommit.ListHoldsRequest\x1a!.twophasecommit.ListHoldsResponse\x12V\n" +
+	"\vCaptureHold\x12\".twophasecommit.CaptureHoldRequest\x1a#.twophasecommit.CaptureHoldResponse\x12A\n" +
+	"\x04Ping\x12\x1b.twophasecommit.PingRequest\x1a\x1c.twophasecommit.PingResponse\x12V\n" +
+	"\vHealthCheck\x12\".twophasecommit.HealthCheckRequest\x1a#.twophasecommit.HealthCheckResponse\x12J\n" +
+	"\aGetInfo\x12\x1e.twophasecommit.GetInfoRequest\x1a\x1f.twophasecommit.GetInfoResponse\x12e\n" +
+	"\x10ListParticipants\x12'.twophasecommit.ListParticipantsRequest\x1a(.twophasecommit.ListParticipantsResponse\x12_\n" +
+	"\x0eGetConnections\x12%.twophasecommit.GetConnectionsRequest\x1a&.twophasecommit.GetConnectionsResponse\x12P\n" +
+	"\tListNodes\x12 .twophasecommit.ListNodesRequest\x1a!.twophasecommit.ListNodesResponseB\x13Z\x11twophasecommit/pbb\x06proto3"
+
+var (
+	file_twophasecommit_proto_rawDescOnce sync.Once
+	file_twophasecommit_proto_rawDescData []byte
+)
+
+func file_twophasecommit_proto_rawDescGZIP() []byte {
+	file_twophasecommit_proto_rawDescOnce.Do(func() {
+		file_twophasecommit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_twophasecommit_proto_rawDesc), len(file_twophasecommit_proto_rawDesc)))
+	})
+	return file_twophasecommit_proto_rawDescData
+}
+
+var file_twophasecommit_proto_msgTypes = make([]protoimpl.MessageInfo, 122)
+var file_twophasecommit_proto_goTypes = []any{
+	(*Transaction)(nil),                               // 0: twophasecommit.Transaction
+	(*Conversion)(nil),                                // 1: twophasecommit.Conversion
+	(*ExchangeRate)(nil),                              // 2: twophasecommit.ExchangeRate
+	(*ResourceOperation)(nil),                         // 3: twophasecommit.ResourceOperation
+	(*LedgerEntry)(nil),                               // 4: twophasecommit.LedgerEntry
+	(*AccountDetails)(nil),                            // 5: twophasecommit.AccountDetails
+	(*AccountInfo)(nil),                               // 6: twophasecommit.AccountInfo
+	(*AccountPolicy)(nil),                             // 7: twophasecommit.AccountPolicy
+	(*Hold)(nil),                                      // 8: twophasecommit.Hold
+	(*DecisionEntry)(nil),                             // 9: twophasecommit.DecisionEntry
+	(*DecisionSnapshot)(nil),                          // 10: twophasecommit.DecisionSnapshot
+	(*HeuristicOutcome)(nil),                          // 11: twophasecommit.HeuristicOutcome
+	(*SagaStep)(nil),                                  // 12: twophasecommit.SagaStep
+	(*SagaState)(nil),                                 // 13: twophasecommit.SagaState
+	(*ResourceSnapshot)(nil),                          // 14: twophasecommit.ResourceSnapshot
+	(*AuditIssue)(nil),                                // 15: twophasecommit.AuditIssue
+	(*CurrencyTotals)(nil),                            // 16: twophasecommit.CurrencyTotals
+	(*AuditReport)(nil),                               // 17: twophasecommit.AuditReport
+	(*NodeInfo)(nil),                                  // 18: twophasecommit.NodeInfo
+	(*ConnectionState)(nil),                           // 19: twophasecommit.ConnectionState
+	(*ParticipantCoordinatorTransactionRequest)(nil),  // 20: twophasecommit.ParticipantCoordinatorTransactionRequest
+	(*ParticipantCoordinatorTransactionResponse)(nil), // 21: twophasecommit.ParticipantCoordinatorTransactionResponse
+	(*ParticipantCoordinatorSagaRequest)(nil),         // 22: twophasecommit.ParticipantCoordinatorSagaRequest
+	(*ParticipantCoordinatorSagaResponse)(nil),        // 23: twophasecommit.ParticipantCoordinatorSagaResponse
+	(*AddParticipantRequest)(nil),                     // 24: twophasecommit.AddParticipantRequest
+	(*AddParticipantResponse)(nil),                    // 25: twophasecommit.AddParticipantResponse
+	(*RemoveParticipantRequest)(nil),                  // 26: twophasecommit.RemoveParticipantRequest
+	(*RemoveParticipantResponse)(nil),                 // 27: twophasecommit.RemoveParticipantResponse
+	(*RequestVoteRequest)(nil),                        // 28: twophasecommit.RequestVoteRequest
+	(*RequestVoteResponse)(nil),                       // 29: twophasecommit.RequestVoteResponse
+	(*AppendEntriesRequest)(nil),                      // 30: twophasecommit.AppendEntriesRequest
+	(*AppendEntriesResponse)(nil),                     // 31: twophasecommit.AppendEntriesResponse
+	(*InstallSnapshotRequest)(nil),                    // 32: twophasecommit.InstallSnapshotRequest
+	(*InstallSnapshotResponse)(nil),                   // 33: twophasecommit.InstallSnapshotResponse
+	(*GetLeaderRequest)(nil),                          // 34: twophasecommit.GetLeaderRequest
+	(*GetLeaderResponse)(nil),                         // 35: twophasecommit.GetLeaderResponse
+	(*ReportHeuristicOutcomeRequest)(nil),             // 36: twophasecommit.ReportHeuristicOutcomeRequest
+	(*ReportHeuristicOutcomeResponse)(nil),            // 37: twophasecommit.ReportHeuristicOutcomeResponse
+	(*SetExchangeRateRequest)(nil),                    // 38: twophasecommit.SetExchangeRateRequest
+	(*SetExchangeRateResponse)(nil),                   // 39: twophasecommit.SetExchangeRateResponse
+	(*GetHeuristicOutcomesRequest)(nil),               // 40: twophasecommit.GetHeuristicOutcomesRequest
+	(*GetHeuristicOutcomesResponse)(nil),              // 41: twophasecommit.GetHeuristicOutcomesResponse
+	(*GetSagaStatusRequest)(nil),                      // 42: twophasecommit.GetSagaStatusRequest
+	(*GetSagaStatusResponse)(nil),                     // 43: twophasecommit.GetSagaStatusResponse
+	(*GetTransactionStatusRequest)(nil),               // 44: twophasecommit.GetTransactionStatusRequest
+	(*GetTransactionStatusResponse)(nil),              // 45: twophasecommit.GetTransactionStatusResponse
+	(*AuditRequest)(nil),                              // 46: twophasecommit.AuditRequest
+	(*AuditResponse)(nil),                             // 47: twophasecommit.AuditResponse
+	(*ReceivePrepareRequest)(nil),                     // 48: twophasecommit.ReceivePrepareRequest
+	(*ReceivePrepareResponse)(nil),                    // 49: twophasecommit.ReceivePrepareResponse
+	(*ReceiveCommitRequest)(nil),                      // 50: twophasecommit.ReceiveCommitRequest
+	(*ReceiveCommitResponse)(nil),                     // 51: twophasecommit.ReceiveCommitResponse
+	(*ReceiveAbortRequest)(nil),                       // 52: twophasecommit.ReceiveAbortRequest
+	(*ReceiveAbortResponse)(nil),                      // 53: twophasecommit.ReceiveAbortResponse
+	(*ApplySagaStepRequest)(nil),                      // 54: twophasecommit.ApplySagaStepRequest
+	(*ApplySagaStepResponse)(nil),                     // 55: twophasecommit.ApplySagaStepResponse
+	(*GetAuditSnapshotRequest)(nil),                   // 56: twophasecommit.GetAuditSnapshotRequest
+	(*GetAuditSnapshotResponse)(nil),                  // 57: twophasecommit.GetAuditSnapshotResponse
+	(*HoldDecisionsRequest)(nil),                      // 58: twophasecommit.HoldDecisionsRequest
+	(*HoldDecisionsResponse)(nil),                     // 59: twophasecommit.HoldDecisionsResponse
+	(*ParticipantConnectToCoordinatorRequest)(nil),    // 60: twophasecommit.ParticipantConnectToCoordinatorRequest
+	(*ParticipantConnectToCoordinatorResponse)(nil),   // 61: twophasecommit.ParticipantConnectToCoordinatorResponse
+	(*AddRouteRequest)(nil),                           // 62: twophasecommit.AddRouteRequest
+	(*AddRouteResponse)(nil),                          // 63: twophasecommit.AddRouteResponse
+	(*RemoveRouteRequest)(nil),                        // 64: twophasecommit.RemoveRouteRequest
+	(*RemoveRouteResponse)(nil),                       // 65: twophasecommit.RemoveRouteResponse
+	(*LeaveRequest)(nil),                              // 66: twophasecommit.LeaveRequest
+	(*LeaveResponse)(nil),                             // 67: twophasecommit.LeaveResponse
+	(*HeartbeatRequest)(nil),                          // 68: twophasecommit.HeartbeatRequest
+	(*HeartbeatResponse)(nil),                         // 69: twophasecommit.HeartbeatResponse
+	(*ListInDoubtRequest)(nil),                        // 70: twophasecommit.ListInDoubtRequest
+	(*ListInDoubtResponse)(nil),                       // 71: twophasecommit.ListInDoubtResponse
+	(*ForceHeuristicDecisionRequest)(nil),             // 72: twophasecommit.ForceHeuristicDecisionRequest
+	(*ForceHeuristicDecisionResponse)(nil),            // 73: twophasecommit.ForceHeuristicDecisionResponse
+	(*SimulateDelayRequest)(nil),                      // 74: twophasecommit.SimulateDelayRequest
+	(*SimulateDelayResponse)(nil),                     // 75: twophasecommit.SimulateDelayResponse
+	(*P2PQueryTransactionStatusRequest)(nil),          // 76: twophasecommit.P2PQueryTransactionStatusRequest
+	(*P2PQueryTranactionStatusResponse)(nil),          // 77: twophasecommit.P2PQueryTranactionStatusResponse
+	(*ClientParticipantTransactionRequest)(nil),       // 78: twophasecommit.ClientParticipantTransactionRequest
+	(*ClientParticipantTransactionResponse)(nil),      // 79: twophasecommit.ClientParticipantTransactionResponse
+	(*ClientParticipantSagaRequest)(nil),              // 80: twophasecommit.ClientParticipantSagaRequest
+	(*ClientParticipantSagaResponse)(nil),             // 81: twophasecommit.ClientParticipantSagaResponse
+	(*GetBalanceRequest)(nil),                         // 82: twophasecommit.GetBalanceRequest
+	(*GetBalanceResponse)(nil),                        // 83: twophasecommit.GetBalanceResponse
+	(*DepositRequest)(nil),                            // 84: twophasecommit.DepositRequest
+	(*DepositResponse)(nil),                           // 85: twophasecommit.DepositResponse
+	(*WithdrawRequest)(nil),                           // 86: twophasecommit.WithdrawRequest
+	(*WithdrawResponse)(nil),                          // 87: twophasecommit.WithdrawResponse
+	(*OpenAccountRequest)(nil),                        // 88: twophasecommit.OpenAccountRequest
+	(*OpenAccountResponse)(nil),                       // 89: twophasecommit.OpenAccountResponse
+	(*ChangeAccountRequest)(nil),                      // 90: twophasecommit.ChangeAccountRequest
+	(*ChangeAccountResponse)(nil),                     // 91: twophasecommit.ChangeAccountResponse
+	(*ListAccountsRequest)(nil),                       // 92: twophasecommit.ListAccountsRequest
+	(*ListAccountsResponse)(nil),                      // 93: twophasecommit.ListAccountsResponse
+	(*GetHistoryRequest)(nil),                         // 94: twophasecommit.GetHistoryRequest
+	(*GetHistoryResponse)(nil),                        // 95: twophasecommit.GetHistoryResponse
+	(*GetAccountPolicyRequest)(nil),                   // 96: twophasecommit.GetAccountPolicyRequest
+	(*GetAccountPolicyResponse)(nil),                  // 97: twophasecommit.GetAccountPolicyResponse
+	(*SetAccountPolicyRequest)(nil),                   // 98: twophasecommit.SetAccountPolicyRequest
+	(*SetAccountPolicyResponse)(nil),                  // 99: twophasecommit.SetAccountPolicyResponse
+	(*PlaceHoldRequest)(nil),                          // 100: twophasecommit.PlaceHoldRequest
+	(*PlaceHoldResponse)(nil),                         // 101: twophasecommit.PlaceHoldResponse
+	(*ReleaseHoldRequest)(nil),                        // 102: twophasecommit.ReleaseHoldRequest
+	(*ReleaseHoldResponse)(nil),                       // 103: twophasecommit.ReleaseHoldResponse
+	(*ListHoldsRequest)(nil),                          // 104: twophasecommit.ListHoldsRequest
+	(*ListHoldsResponse)(nil),                         // 105: twophasecommit.ListHoldsResponse
+	(*CaptureHoldRequest)(nil),                        // 106: twophasecommit.CaptureHoldRequest
+	(*CaptureHoldResponse)(nil),                       // 107: twophasecommit.CaptureHoldResponse
+	(*PingRequest)(nil),                               // 108: twophasecommit.PingRequest
+	(*PingResponse)(nil),                              // 109: twophasecommit.PingResponse
+	(*HealthCheckRequest)(nil),                        // 110: twophasecommit.HealthCheckRequest
+	(*HealthCheckResponse)(nil),                       // 111: twophasecommit.HealthCheckResponse
+	(*GetInfoRequest)(nil),                            // 112: twophasecommit.GetInfoRequest
+	(*GetInfoResponse)(nil),                           // 113: twophasecommit.GetInfoResponse
+	(*ListParticipantsRequest)(nil),                   // 114: twophasecommit.ListParticipantsRequest
+	(*ListParticipantsResponse)(nil),                  // 115: twophasecommit.ListParticipantsResponse
+	(*GetConnectionsRequest)(nil),                     // 116: twophasecommit.GetConnectionsRequest
+	(*GetConnectionsResponse)(nil),                    // 117: twophasecommit.GetConnectionsResponse
+	(*ListNodesRequest)(nil),                          // 118: twophasecommit.ListNodesRequest
+	(*ListNodesResponse)(nil),                         // 119: twophasecommit.ListNodesResponse
+	nil,                                               // 120: twophasecommit.ResourceSnapshot.BalancesEntry
+	nil,                                               // 121: twophasecommit.ResourceSnapshot.CurrenciesEntry
+	(*timestamppb.Timestamp)(nil),                     // 122: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),                       // 123: google.protobuf.Duration
+}
+var file_twophasecommit_proto_depIdxs = []int32{
+	1,   // 0: twophasecommit.Transaction.conversion:type_name -> twophasecommit.Conversion
+	1,   // 1: twophasecommit.ResourceOperation.conversion:type_name -> twophasecommit.Conversion
+	1,   // 2: twophasecommit.LedgerEntry.conversion:type_name -> twophasecommit.Conversion
+	5,   // 3: twophasecommit.LedgerEntry.details:type_name -> twophasecommit.AccountDetails
+	122, // 4: twophasecommit.LedgerEntry.time:type_name -> google.protobuf.Timestamp
+	122, // 5: twophasecommit.Hold.created:type_name -> google.protobuf.Timestamp
+	122, // 6: twophasecommit.Hold.expires:type_name -> google.protobuf.Timestamp
+	0,   // 7: twophasecommit.DecisionEntry.transactions:type_name -> twophasecommit.Transaction
+	13,  // 8: twophasecommit.DecisionEntry.saga:type_name -> twophasecommit.SagaState
+	11,  // 9: twophasecommit.DecisionEntry.outcome:type_name -> twophasecommit.HeuristicOutcome
+	2,   // 10: twophasecommit.DecisionEntry.rates:type_name -> twophasecommit.ExchangeRate
+	9,   // 11: twophasecommit.DecisionSnapshot.entries:type_name -> twophasecommit.DecisionEntry
+	122, // 12: twophasecommit.HeuristicOutcome.reported:type_name -> google.protobuf.Timestamp
+	0,   // 13: twophasecommit.SagaStep.transaction:type_name -> twophasecommit.Transaction
+	0,   // 14: twophasecommit.SagaStep.compensation:type_name -> twophasecommit.Transaction
+	12,  // 15: twophasecommit.SagaState.steps:type_name -> twophasecommit.SagaStep
+	122, // 16: twophasecommit.SagaState.started:type_name -> google.protobuf.Timestamp
+	120, // 17: twophasecommit.ResourceSnapshot.balances:type_name -> twophasecommit.ResourceSnapshot.BalancesEntry
+	121, // 18: twophasecommit.ResourceSnapshot.currencies:type_name -> twophasecommit.ResourceSnapshot.CurrenciesEntry
+	4,   // 19: twophasecommit.ResourceSnapshot.ledger:type_name -> twophasecommit.LedgerEntry
+	122, // 20: twophasecommit.AuditReport.baseline:type_name -> google.protobuf.Timestamp
+	122, // 21: twophasecommit.AuditReport.cut:type_name -> google.protobuf.Timestamp
+	16,  // 22: twophasecommit.AuditReport.totals:type_name -> twophasecommit.CurrencyTotals
+	15,  // 23: twophasecommit.AuditReport.issues:type_name -> twophasecommit.AuditIssue
+	122, // 24: twophasecommit.ConnectionState.since:type_name -> google.protobuf.Timestamp
+	0,   // 25: twophasecommit.ParticipantCoordinatorTransactionRequest.transactions:type_name -> twophasecommit.Transaction
+	0,   // 26: twophasecommit.ParticipantCoordinatorSagaRequest.transactions:type_name -> twophasecommit.Transaction
+	13,  // 27: twophasecommit.ParticipantCoordinatorSagaResponse.saga:type_name -> twophasecommit.SagaState
+	9,   // 28: twophasecommit.AppendEntriesRequest.entries:type_name -> twophasecommit.DecisionEntry
+	10,  // 29: twophasecommit.InstallSnapshotRequest.snapshot:type_name -> twophasecommit.DecisionSnapshot
+	11,  // 30: twophasecommit.ReportHeuristicOutcomeRequest.outcome:type_name -> twophasecommit.HeuristicOutcome
+	2,   // 31: twophasecommit.SetExchangeRateRequest.rate:type_name -> twophasecommit.ExchangeRate
+	11,  // 32: twophasecommit.GetHeuristicOutcomesResponse.outcomes:type_name -> twophasecommit.HeuristicOutcome
+	13,  // 33: twophasecommit.GetSagaStatusResponse.sagas:type_name -> twophasecommit.SagaState
+	0,   // 34: twophasecommit.GetTransactionStatusResponse.transactions:type_name -> twophasecommit.Transaction
+	122, // 35: twophasecommit.AuditRequest.since:type_name -> google.protobuf.Timestamp
+	17,  // 36: twophasecommit.AuditResponse.report:type_name -> twophasecommit.AuditReport
+	0,   // 37: twophasecommit.ReceivePrepareRequest.transactions:type_name -> twophasecommit.Transaction
+	3,   // 38: twophasecommit.ReceivePrepareRequest.operations:type_name -> twophasecommit.ResourceOperation
+	0,   // 39: twophasecommit.ReceivePrepareRequest.delegated:type_name -> twophasecommit.Transaction
+	122, // 40: twophasecommit.ReceivePrepareRequest.deadline:type_name -> google.protobuf.Timestamp
+	1,   // 41: twophasecommit.ApplySagaStepRequest.conversion:type_name -> twophasecommit.Conversion
+	14,  // 42: twophasecommit.GetAuditSnapshotResponse.snapshot:type_name -> twophasecommit.ResourceSnapshot
+	0,   // 43: twophasecommit.ClientParticipantTransactionRequest.transactions:type_name -> twophasecommit.Transaction
+	0,   // 44: twophasecommit.ClientParticipantSagaRequest.transactions:type_name -> twophasecommit.Transaction
+	13,  // 45: twophasecommit.ClientParticipantSagaResponse.saga:type_name -> twophasecommit.SagaState
+	6,   // 46: twophasecommit.ListAccountsResponse.accounts:type_name -> twophasecommit.AccountInfo
+	122, // 47: twophasecommit.GetHistoryRequest.since:type_name -> google.protobuf.Timestamp
+	122, // 48: twophasecommit.GetHistoryRequest.until:type_name -> google.protobuf.Timestamp
+	4,   // 49: twophasecommit.GetHistoryResponse.entries:type_name -> twophasecommit.LedgerEntry
+	7,   // 50: twophasecommit.GetAccountPolicyResponse.policy:type_name -> twophasecommit.AccountPolicy
+	7,   // 51: twophasecommit.SetAccountPolicyRequest.policy:type_name -> twophasecommit.AccountPolicy
+	123, // 52: twophasecommit.PlaceHoldRequest.ttl:type_name -> google.protobuf.Duration
+	8,   // 53: twophasecommit.PlaceHoldResponse.hold:type_name -> twophasecommit.Hold
+	8,   // 54: twophasecommit.ListHoldsResponse.holds:type_name -> twophasecommit.Hold
+	0,   // 55: twophasecommit.CaptureHoldRequest.to:type_name -> twophasecommit.Transaction
+	122, // 56: twophasecommit.ListParticipantsResponse.last_seen:type_name -> google.protobuf.Timestamp
+	19,  // 57: twophasecommit.GetConnectionsResponse.connections:type_name -> twophasecommit.ConnectionState
+	18,  // 58: twophasecommit.ListNodesResponse.nodes:type_name -> twophasecommit.NodeInfo
+	20,  // 59: twophasecommit.Coordinator.ParticipantCoordinatorTransaction:input_type -> twophasecommit.ParticipantCoordinatorTransactionRequest
+	22,  // 60: twophasecommit.Coordinator.ParticipantCoordinatorSaga:input_type -> twophasecommit.ParticipantCoordinatorSagaRequest
+	24,  // 61: twophasecommit.Coordinator.AddParticipant:input_type -> twophasecommit.AddParticipantRequest
+	26,  // 62: twophasecommit.Coordinator.RemoveParticipant:input_type -> twophasecommit.RemoveParticipantRequest
+	28,  // 63: twophasecommit.Coordinator.RequestVote:input_type -> twophasecommit.RequestVoteRequest
+	30,  // 64: twophasecommit.Coordinator.AppendEntries:input_type -> twophasecommit.AppendEntriesRequest
+	32,  // 65: twophasecommit.Coordinator.InstallSnapshot:input_type -> twophasecommit.InstallSnapshotRequest
+	34,  // 66: twophasecommit.Coordinator.GetLeader:input_type -> twophasecommit.GetLeaderRequest
+	36,  // 67: twophasecommit.Coordinator.ReportHeuristicOutcome:input_type -> twophasecommit.ReportHeuristicOutcomeRequest
+	40,  // 68: twophasecommit.Coordinator.GetHeuristicOutcomes:input_type -> twophasecommit.GetHeuristicOutcomesRequest
+	42,  // 69: twophasecommit.Coordinator.GetSagaStatus:input_type -> twophasecommit.GetSagaStatusRequest
+	44,  // 70: twophasecommit.Coordinator.GetTransactionStatus:input_type -> twophasecommit.GetTransactionStatusRequest
+	46,  // 71: twophasecommit.Coordinator.Audit:input_type -> twophasecommit.AuditRequest
+	38,  // 72: twophasecommit.Coordinator.SetExchangeRate:input_type -> twophasecommit.SetExchangeRateRequest
+	48,  // 73: twophasecommit.Participant.ReceivePrepare:input_type -> twophasecommit.ReceivePrepareRequest
+	50,  // 74: twophasecommit.Participant.ReceiveCommit:input_type -> twophasecommit.ReceiveCommitRequest
+	52,  // 75: twophasecommit.Participant.ReceiveAbort:input_type -> twophasecommit.ReceiveAbortRequest
+	54,  // 76: twophasecommit.Participant.ApplySagaStep:input_type -> twophasecommit.ApplySagaStepRequest
+	56,  // 77: twophasecommit.Participant.GetAuditSnapshot:input_type -> twophasecommit.GetAuditSnapshotRequest
+	58,  // 78: twophasecommit.Participant.HoldDecisions:input_type -> twophasecommit.HoldDecisionsRequest
+	60,  // 79: twophasecommit.Participant.ParticipantConnectToCoordinator:input_type -> twophasecommit.ParticipantConnectToCoordinatorRequest
+	62,  // 80: twophasecommit.Participant.AddRoute:input_type -> twophasecommit.AddRouteRequest
+	64,  // 81: twophasecommit.Participant.RemoveRoute:input_type -> twophasecommit.RemoveRouteRequest
+	66,  // 82: twophasecommit.Participant.Leave:input_type -> twophasecommit.LeaveRequest
+	68,  // 83: twophasecommit.Participant.Heartbeat:input_type -> twophasecommit.HeartbeatRequest
+	70,  // 84: twophasecommit.Participant.ListInDoubt:input_type -> twophasecommit.ListInDoubtRequest
+	72,  // 85: twophasecommit.Participant.ForceHeuristicDecision:input_type -> twophasecommit.ForceHeuristicDecisionRequest
+	74,  // 86: twophasecommit.Participant.SimulateDelay:input_type -> twophasecommit.SimulateDelayRequest
+	76,  // 87: twophasecommit.Participant.P2PQueryTransactionStatus:input_type -> twophasecommit.P2PQueryTransactionStatusRequest
+	78,  // 88: twophasecommit.Client.ClientParticipantTransaction:input_type -> twophasecommit.ClientParticipantTransactionRequest
+	80,  // 89: twophasecommit.Client.ClientParticipantSaga:input_type -> twophasecommit.ClientParticipantSagaRequest
+	82,  // 90: twophasecommit.Client.GetBalance:input_type -> twophasecommit.GetBalanceRequest
+	84,  // 91: twophasecommit.Client.Deposit:input_type -> twophasecommit.DepositRequest
+	86,  // 92: twophasecommit.Client.Withdraw:input_type -> twophasecommit.WithdrawRequest
+	88,  // 93: twophasecommit.Client.OpenAccount:input_type -> twophasecommit.OpenAccountRequest
+	90,  // 94: twophasecommit.Client.ChangeAccount:input_type -> twophasecommit.ChangeAccountRequest
+	92,  // 95: twophasecommit.Client.ListAccounts:input_type -> twophasecommit.ListAccountsRequest
+	94,  // 96: twophasecommit.Client.GetHistory:input_type -> twophasecommit.GetHistoryRequest
+	96,  // 97: twophasecommit.Client.GetAccountPolicy:input_type -> twophasecommit.GetAccountPolicyRequest
+	98,  // 98: twophasecommit.Client.SetAccountPolicy:input_type -> twophasecommit.SetAccountPolicyRequest
+	100, // 99: twophasecommit.Client.PlaceHold:input_type -> twophasecommit.PlaceHoldRequest
+	102, // 100: twophasecommit.Client.ReleaseHold:input_type -> twophasecommit.ReleaseHoldRequest
+	104, // 101: twophasecommit.Client.ListHolds:input_type -> twophasecommit.ListHoldsRequest
+	106, // 102: twophasecommit.Client.CaptureHold:input_type -> twophasecommit.CaptureHoldRequest
+	108, // 103: twophasecommit.Client.Ping:input_type -> twophasecommit.PingRequest
+	110, // 104: twophasecommit.Client.HealthCheck:input_type -> twophasecommit.HealthCheckRequest
+	112, // 105: twophasecommit.Client.GetInfo:input_type -> twophasecommit.GetInfoRequest
+	114, // 106: twophasecommit.Client.ListParticipants:input_type -> twophasecommit.ListParticipantsRequest
+	116, // 107: twophasecommit.Client.GetConnections:input_type -> twophasecommit.GetConnectionsRequest
+	118, // 108: twophasecommit.Client.ListNodes:input_type -> twophasecommit.ListNodesRequest
+	21,  // 109: twophasecommit.Coordinator.ParticipantCoordinatorTransaction:output_type -> twophasecommit.ParticipantCoordinatorTransactionResponse
+	23,  // 110: twophasecommit.Coordinator.ParticipantCoordinatorSaga:output_type -> twophasecommit.ParticipantCoordinatorSagaResponse
+	25,  // 111: twophasecommit.Coordinator.AddParticipant:output_type -> twophasecommit.AddParticipantResponse
+	27,  // 112: twophasecommit.Coordinator.RemoveParticipant:output_type -> twophasecommit.RemoveParticipantResponse
+	29,  // 113: twophasecommit.Coordinator.RequestVote:output_type -> twophasecommit.RequestVoteResponse
+	31,  // 114: twophasecommit.Coordinator.AppendEntries:output_type -> twophasecommit.AppendEntriesResponse
+	33,  // 115: twophasecommit.Coordinator.InstallSnapshot:output_type -> twophasecommit.InstallSnapshotResponse
+	35,  // 116: twophasecommit.Coordinator.GetLeader:output_type -> twophasecommit.GetLeaderResponse
+	37,  // 117: twophasecommit.Coordinator.ReportHeuristicOutcome:output_type -> twophasecommit.ReportHeuristicOutcomeResponse
+	41,  // 118: twophasecommit.Coordinator.GetHeuristicOutcomes:output_type -> twophasecommit.GetHeuristicOutcomesResponse
+	43,  // 119: twophasecommit.Coordinator.GetSagaStatus:output_type -> twophasecommit.GetSagaStatusResponse
+	45,  // 120: twophasecommit.Coordinator.GetTransactionStatus:output_type -> twophasecommit.GetTransactionStatusResponse
+	47,  // 121: twophasecommit.Coordinator.Audit:output_type -> twophasecommit.AuditResponse
+	39,  // 122: twophasecommit.Coordinator.SetExchangeRate:output_type -> twophasecommit.SetExchangeRateResponse
+	49,  // 123: twophasecommit.Participant.ReceivePrepare:output_type -> twophasecommit.ReceivePrepareResponse
+	51,  // 124: twophasecommit.Participant.ReceiveCommit:output_type -> twophasecommit.ReceiveCommitResponse
+	53,  // 125: twophasecommit.Participant.ReceiveAbort:output_type -> twophasecommit.ReceiveAbortResponse
+	55,  // 126: twophasecommit.Participant.ApplySagaStep:output_type -> twophasecommit.ApplySagaStepResponse
+	57,  // 127: twophasecommit.Participant.GetAuditSnapshot:output_type -> twophasecommit.GetAuditSnapshotResponse
+	59,  // 128: twophasecommit.Participant.HoldDecisions:output_type -> twophasecommit.HoldDecisionsResponse
+	61,  // 129: twophasecommit.Participant.ParticipantConnectToCoordinator:output_type -> twophasecommit.ParticipantConnectToCoordinatorResponse
+	63,  // 130: twophasecommit.Participant.AddRoute:output_type -> twophasecommit.AddRouteResponse
+	65,  // 131: twophasecommit.Participant.RemoveRoute:output_type -> twophasecommit.RemoveRouteResponse
+	67,  // 132: twophasecommit.Participant.Leave:output_type -> twophasecommit.LeaveResponse
+	69,  // 133: twophasecommit.Participant.Heartbeat:output_type -> twophasecommit.HeartbeatResponse
+	71,  // 134: twophasecommit.Participant.ListInDoubt:output_type -> twophasecommit.ListInDoubtResponse
+	73,  // 135: twophasecommit.Participant.ForceHeuristicDecision:output_type -> twophasecommit.ForceHeuristicDecisionResponse
+	75,  // 136: twophasecommit.Participant.SimulateDelay:output_type -> twophasecommit.SimulateDelayResponse
+	77,  // 137: twophasecommit.Participant.P2PQueryTransactionStatus:output_type -> twophasecommit.P2PQueryTranactionStatusResponse
+	79,  // 138: twophasecommit.Client.ClientParticipantTransaction:output_type -> twophasecommit.ClientParticipantTransactionResponse
+	81,  // 139: twophasecommit.Client.ClientParticipantSaga:output_type -> twophasecommit.ClientParticipantSagaResponse
+	83,  // 140: twophasecommit.Client.GetBalance:output_type -> twophasecommit.GetBalanceResponse
+	85,  // 141: twophasecommit.Client.Deposit:output_type -> twophasecommit.DepositResponse
+	87,  // 142: twophasecommit.Client.Withdraw:output_type -> twophasecommit.WithdrawResponse
+	89,  // 143: twophasecommit.Client.OpenAccount:output_type -> twophasecommit.OpenAccountResponse
+	91,  // 144: twophasecommit.Client.ChangeAccount:output_type -> twophasecommit.ChangeAccountResponse
+	93,  // 145: twophasecommit.Client.ListAccounts:output_type -> twophasecommit.ListAccountsResponse
+	95,  // 146: twophasecommit.Client.GetHistory:output_type -> twophasecommit.GetHistoryResponse
+	97,  // 147: twophasecommit.Client.GetAccountPolicy:output_type -> twophasecommit.GetAccountPolicyResponse
+	99,  // 148: twophasecommit.Client.SetAccountPolicy:output_type -> twophasecommit.SetAccountPolicyResponse
+	101, // 149: twophasecommit.Client.PlaceHold:output_type -> twophasecommit.PlaceHoldResponse
+	103, // 150: twophasecommit.Client.ReleaseHold:output_type -> twophasecommit.ReleaseHoldResponse
+	105, // 151: twophasecommit.Client.ListHolds:output_type -> twophasecommit.ListHoldsResponse
+	107, // 152: twophasecommit.Client.CaptureHold:output_type -> twophasecommit.CaptureHoldResponse
+	109, // 153: twophasecommit.Client.Ping:output_type -> twophasecommit.PingResponse
+	111, // 154: twophasecommit.Client.HealthCheck:output_type -> twophasecommit.HealthCheckResponse
+	113, // 155: twophasecommit.Client.GetInfo:output_type -> twophasecommit.GetInfoResponse
+	115, // 156: twophasecommit.Client.ListParticipants:output_type -> twophasecommit.ListParticipantsResponse
+	117, // 157: twophasecommit.Client.GetConnections:output_type -> twophasecommit.GetConnectionsResponse
+	119, // 158: twophasecommit.Client.ListNodes:output_type -> twophasecommit.ListNodesResponse
+	109, // [109:159] is the sub-list for method output_type
+	59,  // [59:109] is the sub-list for method input_type
+	59,  // [59:59] is the sub-list for extension type_name
+	59,  // [59:59] is the sub-list for extension extendee
+	0,   // [0:59] is the sub-list for field type_name
+}
+
+func init() { file_twophasecommit_proto_init() }
+func file_twophasecommit_proto_init() {
+	if File_twophasecommit_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_twophasecommit_proto_rawDesc), len(file_twophasecommit_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   122,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_twophasecommit_proto_goTypes,
+		DependencyIndexes: file_twophasecommit_proto_depIdxs,
+		MessageInfos:      file_twophasecommit_proto_msgTypes,
+	}.Build()
+	File_twophasecommit_proto = out.File
+	file_twophasecommit_proto_goTypes = nil
+	file_twophasecommit_proto_depIdxs = nil
+}
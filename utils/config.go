@@ -1,3 +1,15 @@
 package utils
 
 var IPAddress = "127.0.0.1"
+
+// Storage backend for participant balances, "file" or "memory"
+var StorageBackend = "file"
+
+// Exchange rates loaded by the coordinators, a missing file allows no conversions
+var RatesFile = "rates.txt"
+
+// Transport nodes and the client dial with, "rpc" or "grpc". Nodes serve both.
+var Transport = "rpc"
+
+// Coordinator that clients and participants join the cluster through, unless told another
+var JoinAddress = "127.0.0.1:7070"
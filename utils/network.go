@@ -3,7 +3,6 @@ package utils
 import (
 	"fmt"
 	"net"
-	"net/rpc"
 	"time"
 	"twophasecommit/node"
 )
@@ -32,7 +31,7 @@ func FindAvailablePort() (int, error) {
 	return port, nil
 }
 
-func WaitForServerReady(address string) error {
+func WaitForServerReady(transport node.Transport, address string) error {
 	// Blocked until server ready or timeout
 	var backoff time.Duration = 100
 	const maxBackoff = 5 * time.Second
@@ -41,7 +40,7 @@ func WaitForServerReady(address string) error {
 	startTime := time.Now()
 
 	for retries := 0; retries < maxRetries; retries++ {
-		client, err := rpc.Dial("tcp", address)
+		client, err := transport.Dial(address)
 		if err == nil {
 			var req node.HealthCheckRequest
 			var res node.HealthCheckResponse
@@ -1,7 +1,6 @@
 package utils
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -24,7 +23,7 @@ func ClearNodeDataDir() error {
 	}
 
 	for _, file := range files {
-		err := os.Remove(filepath.Join(dir, file.Name()))
+		err := os.RemoveAll(filepath.Join(dir, file.Name()))
 		if err != nil {
 			return fmt.Errorf("error removing file %s: %v", file.Name(), err)
 		}
@@ -32,36 +31,3 @@ func ClearNodeDataDir() error {
 
 	return nil
 }
-
-func WriteNodeInfoToFile(nodesInfo []string, filename string) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	for _, info := range nodesInfo {
-		_, err := file.WriteString(info + "\n")
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-func ReadNodeInfoFromFile(filename string) ([]string, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-
-	return lines, scanner.Err()
-}